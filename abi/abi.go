@@ -0,0 +1,447 @@
+/********************************************************************************
+   This file is part of go-web3.
+   go-web3 is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-web3 is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-web3.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file abi.go
+ */
+
+// Package abi models the Application Binary Interface of the Solidity contracts:
+// the functions, the events and the errors of a contract with their typed arguments.
+package abi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/cellcycle/go-web3/utils"
+)
+
+// The types of the functions of an ABI
+const (
+	FunctionType    = "function"
+	ConstructorType = "constructor"
+	FallbackType    = "fallback"
+	ReceiveType     = "receive"
+)
+
+// The state mutability of the functions
+const (
+	Pure       = "pure"
+	View       = "view"
+	NonPayable = "nonpayable"
+	Payable    = "payable"
+)
+
+// Method - A function of a contract, or its constructor, fallback or receive function
+type Method struct {
+	Name string
+	// Type is FunctionType, ConstructorType, FallbackType or ReceiveType
+	Type            string
+	Inputs          Arguments
+	Outputs         Arguments
+	StateMutability string
+}
+
+// Signature - The name followed by the canonical input types, such as transfer(address,uint256)
+func (method *Method) Signature() string {
+	return method.Name + "(" + method.Inputs.typeList() + ")"
+}
+
+// Selector - The first 4 bytes of the Keccak-256 hash of the signature, which start the call data
+func (method *Method) Selector() [4]byte {
+	var selector [4]byte
+	hash := utils.Keccak256([]byte(method.Signature()))
+	copy(selector[:], hash[:4])
+	return selector
+}
+
+// IsConstant - Tells if the function reads the state without changing it, it is then called with eth_call
+func (method *Method) IsConstant() bool {
+	return method.StateMutability == View || method.StateMutability == Pure
+}
+
+// IsPayable - Tells if the function accepts a value
+func (method *Method) IsPayable() bool {
+	return method.StateMutability == Payable
+}
+
+// Event - An event of a contract, the logs it emits
+type Event struct {
+	Name string
+	// Anonymous events don't have their topic as the first topic of their logs
+	Anonymous bool
+	Inputs    Arguments
+}
+
+// Signature - The name followed by the canonical field types, such as Transfer(address,address,uint256)
+func (event *Event) Signature() string {
+	return event.Name + "(" + event.Inputs.typeList() + ")"
+}
+
+// Topic - The Keccak-256 hash of the signature, the first topic of the logs of the event
+func (event *Event) Topic() [32]byte {
+	return utils.Keccak256([]byte(event.Signature()))
+}
+
+// Error - A custom error of a contract, raised with revert
+type Error struct {
+	Name   string
+	Inputs Arguments
+}
+
+// Signature - The name followed by the canonical field types, such as InsufficientBalance(uint256,uint256)
+func (contractError *Error) Signature() string {
+	return contractError.Name + "(" + contractError.Inputs.typeList() + ")"
+}
+
+// Selector - The first 4 bytes of the Keccak-256 hash of the signature, which start the revert data
+func (contractError *Error) Selector() [4]byte {
+	var selector [4]byte
+	hash := utils.Keccak256([]byte(contractError.Signature()))
+	copy(selector[:], hash[:4])
+	return selector
+}
+
+// ABI - The interface of a contract. The functions, events and errors keep the order of
+// the definition, the overloaded ones share their name.
+type ABI struct {
+	Constructor *Method
+	Fallback    *Method
+	Receive     *Method
+	Methods     []*Method
+	Events      []*Event
+	Errors      []*Error
+}
+
+// entryJSON - an entry of the JSON ABI
+type entryJSON struct {
+	Type            string         `json:"type"`
+	Name            string         `json:"name"`
+	Inputs          []argumentJSON `json:"inputs"`
+	Outputs         []argumentJSON `json:"outputs"`
+	StateMutability string         `json:"stateMutability,omitempty"`
+	Anonymous       bool           `json:"anonymous,omitempty"`
+	// Constant and Payable describe the mutability before stateMutability
+	Constant bool `json:"constant,omitempty"`
+	Payable  bool `json:"payable,omitempty"`
+}
+
+// ParseJSON - Parses the JSON ABI produced by the compiler
+func ParseJSON(data []byte) (*ABI, error) {
+
+	var entries []entryJSON
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("Invalid JSON ABI: %v", err)
+	}
+
+	definition := new(ABI)
+
+	for _, entry := range entries {
+		if err := definition.addEntry(entry); err != nil {
+			return nil, err
+		}
+	}
+
+	return definition, nil
+}
+
+// MarshalJSON - Renders the JSON ABI: the constructor, the functions, the events,
+// the errors, the fallback and the receive functions
+func (definition *ABI) MarshalJSON() ([]byte, error) {
+
+	type writtenEntry struct {
+		Type            string          `json:"type"`
+		Name            string          `json:"name,omitempty"`
+		Anonymous       *bool           `json:"anonymous,omitempty"`
+		Inputs          *[]argumentJSON `json:"inputs,omitempty"`
+		Outputs         *[]argumentJSON `json:"outputs,omitempty"`
+		StateMutability string          `json:"stateMutability,omitempty"`
+	}
+
+	entries := make([]writtenEntry, 0)
+
+	for _, method := range definition.allMethods() {
+
+		entry := writtenEntry{Type: method.Type, StateMutability: method.StateMutability}
+
+		if method.Type != FallbackType && method.Type != ReceiveType {
+			inputs := method.Inputs.toJSON()
+			entry.Inputs = &inputs
+		}
+
+		if method.Type == FunctionType {
+			entry.Name = method.Name
+			outputs := method.Outputs.toJSON()
+			entry.Outputs = &outputs
+		}
+
+		entries = append(entries, entry)
+	}
+
+	for _, event := range definition.Events {
+		inputs := event.Inputs.toJSON()
+		anonymous := event.Anonymous
+		entries = append(entries, writtenEntry{Type: "event", Name: event.Name, Anonymous: &anonymous, Inputs: &inputs})
+	}
+
+	for _, contractError := range definition.Errors {
+		inputs := contractError.Inputs.toJSON()
+		entries = append(entries, writtenEntry{Type: "error", Name: contractError.Name, Inputs: &inputs})
+	}
+
+	return json.Marshal(entries)
+}
+
+func (definition *ABI) addEntry(entry entryJSON) error {
+
+	inputs, err := newArguments(entry.Inputs)
+	if err != nil {
+		return fmt.Errorf("Invalid inputs of %s %s: %v", entry.Type, entry.Name, err)
+	}
+
+	outputs, err := newArguments(entry.Outputs)
+	if err != nil {
+		return fmt.Errorf("Invalid outputs of %s %s: %v", entry.Type, entry.Name, err)
+	}
+
+	mutability := entry.StateMutability
+	if mutability == "" {
+		switch {
+		case entry.Payable:
+			mutability = Payable
+		case entry.Constant:
+			mutability = View
+		default:
+			mutability = NonPayable
+		}
+	}
+
+	switch entry.Type {
+	case FunctionType, "":
+		if entry.Name == "" {
+			return fmt.Errorf("Function without a name")
+		}
+		return definition.AddMethod(&Method{Name: entry.Name, Type: FunctionType, Inputs: inputs, Outputs: outputs, StateMutability: mutability})
+
+	case ConstructorType, FallbackType, ReceiveType:
+		return definition.AddMethod(&Method{Name: entry.Type, Type: entry.Type, Inputs: inputs, StateMutability: mutability})
+
+	case "event":
+		return definition.AddEvent(&Event{Name: entry.Name, Anonymous: entry.Anonymous, Inputs: inputs})
+
+	case "error":
+		return definition.AddError(&Error{Name: entry.Name, Inputs: inputs})
+	}
+
+	return fmt.Errorf("Unknown ABI entry type %q", entry.Type)
+}
+
+// AddMethod - Adds a function, or sets the constructor, fallback or receive function
+func (definition *ABI) AddMethod(method *Method) error {
+
+	switch method.Type {
+	case ConstructorType:
+		if definition.Constructor != nil {
+			return fmt.Errorf("Duplicate constructor")
+		}
+		definition.Constructor = method
+		return nil
+	case FallbackType:
+		definition.Fallback = method
+		return nil
+	case ReceiveType:
+		definition.Receive = method
+		return nil
+	}
+
+	signature := method.Signature()
+	for _, existing := range definition.Methods {
+		if existing.Signature() == signature {
+			return fmt.Errorf("Duplicate function %s", signature)
+		}
+	}
+
+	definition.Methods = append(definition.Methods, method)
+	return nil
+}
+
+// AddEvent - Adds an event
+func (definition *ABI) AddEvent(event *Event) error {
+
+	signature := event.Signature()
+	for _, existing := range definition.Events {
+		if existing.Signature() == signature {
+			return fmt.Errorf("Duplicate event %s", signature)
+		}
+	}
+
+	definition.Events = append(definition.Events, event)
+	return nil
+}
+
+// AddError - Adds a custom error
+func (definition *ABI) AddError(contractError *Error) error {
+
+	signature := contractError.Signature()
+	for _, existing := range definition.Errors {
+		if existing.Signature() == signature {
+			return fmt.Errorf("Duplicate error %s", signature)
+		}
+	}
+
+	definition.Errors = append(definition.Errors, contractError)
+	return nil
+}
+
+// MethodsByName - The functions called name, several when it is overloaded
+func (definition *ABI) MethodsByName(name string) []*Method {
+
+	var methods []*Method
+	for _, method := range definition.Methods {
+		if method.Name == name {
+			methods = append(methods, method)
+		}
+	}
+
+	return methods
+}
+
+// Method - The function with the signature given, such as transfer(address,uint256),
+// or with the name given when it is not overloaded
+func (definition *ABI) Method(name string) (*Method, error) {
+
+	if strings.Contains(name, "(") {
+		for _, method := range definition.Methods {
+			if method.Signature() == name {
+				return method, nil
+			}
+		}
+		return nil, fmt.Errorf("Function %s not found in the ABI", name)
+	}
+
+	methods := definition.MethodsByName(name)
+
+	switch len(methods) {
+	case 0:
+		return nil, fmt.Errorf("Function %s not found in the ABI", name)
+	case 1:
+		return methods[0], nil
+	}
+
+	return nil, fmt.Errorf("Function %s is overloaded, use one of its signatures: %s", name, methodSignatures(methods))
+}
+
+// MethodBySelector - The function called by the call data starting with selector
+func (definition *ABI) MethodBySelector(selector []byte) (*Method, error) {
+
+	if len(selector) >= 4 {
+		for _, method := range definition.Methods {
+			id := method.Selector()
+			if bytes.Equal(id[:], selector[:4]) {
+				return method, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("No function with the selector 0x%x", selector)
+}
+
+// Event - The event with the signature given, or with the name given when it is not overloaded
+func (definition *ABI) Event(name string) (*Event, error) {
+
+	var found []*Event
+	for _, event := range definition.Events {
+		if event.Signature() == name || event.Name == name {
+			found = append(found, event)
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return nil, fmt.Errorf("Event %s not found in the ABI", name)
+	case 1:
+		return found[0], nil
+	}
+
+	signatures := make([]string, len(found))
+	for index, event := range found {
+		signatures[index] = event.Signature()
+	}
+
+	return nil, fmt.Errorf("Event %s is overloaded, use one of its signatures: %s", name, strings.Join(signatures, ", "))
+}
+
+// EventByTopic - The event whose logs have topic as their first topic
+func (definition *ABI) EventByTopic(topic [32]byte) (*Event, error) {
+
+	for _, event := range definition.Events {
+		if !event.Anonymous && event.Topic() == topic {
+			return event, nil
+		}
+	}
+
+	return nil, fmt.Errorf("No event with the topic 0x%x", topic)
+}
+
+// Error - The custom error with the signature given, or with the name given when it is not overloaded
+func (definition *ABI) Error(name string) (*Error, error) {
+
+	var found []*Error
+	for _, contractError := range definition.Errors {
+		if contractError.Signature() == name || contractError.Name == name {
+			found = append(found, contractError)
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return nil, fmt.Errorf("Error %s not found in the ABI", name)
+	case 1:
+		return found[0], nil
+	}
+
+	signatures := make([]string, len(found))
+	for index, contractError := range found {
+		signatures[index] = contractError.Signature()
+	}
+
+	return nil, fmt.Errorf("Error %s is overloaded, use one of its signatures: %s", name, strings.Join(signatures, ", "))
+}
+
+// ErrorBySelector - The custom error whose revert data start with selector
+func (definition *ABI) ErrorBySelector(selector []byte) (*Error, error) {
+
+	if len(selector) >= 4 {
+		for _, contractError := range definition.Errors {
+			id := contractError.Selector()
+			if bytes.Equal(id[:], selector[:4]) {
+				return contractError, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("No error with the selector 0x%x", selector)
+}
+
+func methodSignatures(methods []*Method) string {
+
+	signatures := make([]string, len(methods))
+	for index, method := range methods {
+		signatures[index] = method.Signature()
+	}
+
+	return strings.Join(signatures, ", ")
+}
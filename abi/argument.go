@@ -0,0 +1,163 @@
+/********************************************************************************
+   This file is part of go-web3.
+   go-web3 is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-web3 is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-web3.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file argument.go
+ */
+
+package abi
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Argument - An input or an output of a function, a field of an event, an error or a tuple
+type Argument struct {
+	Name string
+	Type Type
+	// Indexed is set on the event fields sent as topics
+	Indexed bool
+}
+
+// Arguments - The inputs or the outputs of a function, the fields of an event or an error
+type Arguments []Argument
+
+// typeList joins the canonical types of the arguments with commas
+func (arguments Arguments) typeList() string {
+
+	types := make([]string, len(arguments))
+	for index, argument := range arguments {
+		types[index] = argument.Type.String()
+	}
+
+	return strings.Join(types, ",")
+}
+
+// NonIndexed - The arguments without the indexed ones, the fields of an event found in its data
+func (arguments Arguments) NonIndexed() Arguments {
+
+	var nonIndexed Arguments
+	for _, argument := range arguments {
+		if !argument.Indexed {
+			nonIndexed = append(nonIndexed, argument)
+		}
+	}
+
+	return nonIndexed
+}
+
+// argumentJSON - an argument as written in the JSON ABI
+type argumentJSON struct {
+	Name         string         `json:"name"`
+	Type         string         `json:"type"`
+	InternalType string         `json:"internalType,omitempty"`
+	Components   []argumentJSON `json:"components,omitempty"`
+	Indexed      bool           `json:"indexed,omitempty"`
+}
+
+func newArguments(arguments []argumentJSON) (Arguments, error) {
+
+	parsed := make(Arguments, len(arguments))
+
+	for index, argument := range arguments {
+
+		components, err := newArguments(argument.Components)
+		if err != nil {
+			return nil, err
+		}
+
+		if strings.HasPrefix(argument.Type, "tuple") && len(argument.Components) == 0 {
+			return nil, fmt.Errorf("Tuple argument %q without components", argument.Name)
+		}
+
+		argumentType, err := NewType(argument.Type, components...)
+		if err != nil {
+			return nil, err
+		}
+
+		setTupleName(&argumentType, argument.InternalType)
+
+		parsed[index] = Argument{Name: argument.Name, Type: argumentType, Indexed: argument.Indexed}
+	}
+
+	return parsed, nil
+}
+
+// setTupleName names the tuple of argumentType, under its arrays, from an
+// internalType such as "struct Market.Order[]"
+func setTupleName(argumentType *Type, internalType string) {
+
+	if !strings.HasPrefix(internalType, "struct ") {
+		return
+	}
+
+	name := strings.TrimPrefix(internalType, "struct ")
+	if open := strings.Index(name, "["); open >= 0 {
+		name = name[:open]
+	}
+	if dot := strings.LastIndex(name, "."); dot >= 0 {
+		name = name[dot+1:]
+	}
+
+	for argumentType.Kind == SliceKind || argumentType.Kind == ArrayKind {
+		argumentType = argumentType.Elem
+	}
+
+	if argumentType.Kind == TupleKind {
+		argumentType.TupleName = name
+	}
+}
+
+// toJSON - the arguments as written in the JSON ABI, the named tuples with their internalType
+func (arguments Arguments) toJSON() []argumentJSON {
+
+	written := make([]argumentJSON, len(arguments))
+
+	for index, argument := range arguments {
+
+		written[index] = argumentJSON{Name: argument.Name, Type: jsonType(argument.Type), Indexed: argument.Indexed}
+
+		tuple, suffix := argument.Type, ""
+		for tuple.Kind == SliceKind || tuple.Kind == ArrayKind {
+			suffix = strings.TrimPrefix(jsonType(tuple), jsonType(*tuple.Elem)) + suffix
+			tuple = *tuple.Elem
+		}
+
+		if tuple.Kind == TupleKind {
+			written[index].Components = Arguments(tuple.Components).toJSON()
+			if tuple.TupleName != "" {
+				written[index].InternalType = "struct " + tuple.TupleName + suffix
+			}
+		}
+	}
+
+	return written
+}
+
+// jsonType - the type as written in the JSON ABI, the tuples as tuple
+func jsonType(t Type) string {
+
+	switch t.Kind {
+	case SliceKind:
+		return jsonType(*t.Elem) + "[]"
+	case ArrayKind:
+		return jsonType(*t.Elem) + "[" + strconv.Itoa(t.Size) + "]"
+	case TupleKind:
+		return "tuple"
+	}
+
+	return t.String()
+}
@@ -0,0 +1,748 @@
+/********************************************************************************
+   This file is part of go-web3.
+   go-web3 is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-web3 is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-web3.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file bind.go
+ */
+
+// Package bind generates typed Go bindings of the contracts from their ABI.
+package bind
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/cellcycle/go-web3/abi"
+)
+
+// Options - What Generate writes
+type Options struct {
+	// Package is the name of the generated package
+	Package string
+	// Type is the Go name of the contract, the prefix of the generated types
+	Type string
+	// ABI is the JSON ABI of the contract
+	ABI string
+	// Bytecode is the 0x prefixed creation code, the Deploy function is generated when it is set
+	Bytecode string
+	// Source is the file the ABI was read from, named in the header of the generated code
+	Source string
+}
+
+// Artifact - A contract read by ReadArtifact
+type Artifact struct {
+	Name     string
+	ABI      string
+	Bytecode string
+}
+
+// ReadArtifact - Reads a JSON ABI, or the artifact of Truffle or Hardhat holding the
+// ABI, the name and the bytecode of a contract
+func ReadArtifact(data []byte) (*Artifact, error) {
+
+	if strings.HasPrefix(strings.TrimSpace(string(data)), "[") {
+		return &Artifact{ABI: string(data)}, nil
+	}
+
+	var artifact struct {
+		ContractName string          `json:"contractName"`
+		ABI          json.RawMessage `json:"abi"`
+		Bytecode     json.RawMessage `json:"bytecode"`
+	}
+
+	if err := json.Unmarshal(data, &artifact); err != nil {
+		return nil, fmt.Errorf("Invalid artifact: %v", err)
+	}
+
+	if len(artifact.ABI) == 0 {
+		return nil, fmt.Errorf("Invalid artifact: no abi")
+	}
+
+	read := &Artifact{Name: artifact.ContractName, ABI: string(artifact.ABI)}
+
+	// Truffle wrote the ABI in a string before its version 4
+	var text string
+	if json.Unmarshal(artifact.ABI, &text) == nil {
+		read.ABI = text
+	}
+
+	// the bytecode is a string, or the object of the solc output
+	var bytecode struct {
+		Object string `json:"object"`
+	}
+	if json.Unmarshal(artifact.Bytecode, &read.Bytecode) != nil && json.Unmarshal(artifact.Bytecode, &bytecode) == nil {
+		read.Bytecode = bytecode.Object
+	}
+
+	if read.Bytecode != "" && !strings.HasPrefix(read.Bytecode, "0x") {
+		read.Bytecode = "0x" + read.Bytecode
+	}
+
+	// an interface or an abstract contract has no code
+	if read.Bytecode == "0x" {
+		read.Bytecode = ""
+	}
+
+	return read, nil
+}
+
+// Generate - The Go source of the binding of the contract: a type calling its functions
+// with typed arguments and results, the structs of its tuples and events, the filter and
+// parse functions of its events, the selectors of its errors and, with the bytecode, a
+// Deploy function
+func Generate(options Options) ([]byte, error) {
+
+	if !isIdentifier(options.Package) || !isIdentifier(options.Type) || !unicode.IsUpper(rune(options.Type[0])) {
+		return nil, fmt.Errorf("Invalid package %q or type %q", options.Package, options.Type)
+	}
+
+	if options.Bytecode != "" {
+		if _, err := hex.DecodeString(strings.TrimPrefix(options.Bytecode, "0x")); err != nil {
+			return nil, fmt.Errorf("Invalid bytecode: %v", err)
+		}
+	}
+
+	definition, err := abi.ParseJSON([]byte(options.ABI))
+	if err != nil {
+		return nil, err
+	}
+
+	compact, err := json.Marshal(definition)
+	if err != nil {
+		return nil, err
+	}
+
+	generator := &generator{
+		prefix:  options.Type,
+		structs: make(map[string]string),
+		used:    map[string]bool{"Contract": true, "From": true},
+	}
+	generator.used[options.Type+"ABI"] = true
+	generator.used[options.Type+"Bytecode"] = true
+
+	data := &contractData{
+		Package:  options.Package,
+		Type:     options.Type,
+		Source:   options.Source,
+		ABI:      string(compact),
+		Bytecode: options.Bytecode,
+	}
+
+	if definition.Constructor != nil {
+		data.Constructor = generator.method(definition.Constructor)
+	} else {
+		data.Constructor = &methodData{}
+	}
+
+	for _, method := range definition.Methods {
+		if method.IsConstant() {
+			data.Calls = append(data.Calls, generator.method(method))
+		} else {
+			data.Transacts = append(data.Transacts, generator.method(method))
+		}
+	}
+
+	for _, event := range definition.Events {
+		data.Events = append(data.Events, generator.event(event))
+	}
+
+	for _, contractError := range definition.Errors {
+		selector := contractError.Selector()
+		data.Errors = append(data.Errors, errorData{
+			Name:        generator.unique(generator.prefix + goName(contractError.Name, 0) + "Selector"),
+			Declaration: declaration("error", contractError.Name, contractError.Inputs),
+			Selector:    "0x" + hex.EncodeToString(selector[:]),
+		})
+	}
+
+	data.Structs = generator.structList
+
+	var source bytes.Buffer
+	if err := bindingTemplate.Execute(&source, data); err != nil {
+		return nil, err
+	}
+
+	formatted, err := format.Source(source.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("Invalid generated code: %v", err)
+	}
+
+	return formatted, nil
+}
+
+type contractData struct {
+	Package     string
+	Type        string
+	Source      string
+	ABI         string
+	Bytecode    string
+	Constructor *methodData
+	Calls       []*methodData
+	Transacts   []*methodData
+	Events      []*eventData
+	Errors      []errorData
+	Structs     []*structData
+}
+
+// UsesBig tells if math/big is imported
+func (data *contractData) UsesBig() bool {
+
+	var types []fieldData
+	types = append(types, data.Constructor.Params...)
+	for _, methods := range [][]*methodData{data.Calls, data.Transacts} {
+		for _, method := range methods {
+			types = append(types, method.Params...)
+			types = append(types, method.Outputs...)
+		}
+	}
+	for _, event := range data.Events {
+		types = append(types, event.Fields...)
+	}
+	for _, tuple := range data.Structs {
+		types = append(types, tuple.Fields...)
+	}
+
+	for _, field := range types {
+		if strings.Contains(field.Type, "big.Int") {
+			return true
+		}
+	}
+
+	return false
+}
+
+type methodData struct {
+	Name        string
+	Signature   string
+	Declaration string
+	Params      []fieldData
+	Outputs     []fieldData
+	// Output is the type of the result, a struct for several outputs
+	Output string
+}
+
+type eventData struct {
+	Name        string
+	Type        string
+	Signature   string
+	Declaration string
+	Fields      []fieldData
+	Indexed     string
+	Raw         string
+}
+
+type errorData struct {
+	Name        string
+	Declaration string
+	Selector    string
+}
+
+type structData struct {
+	Name      string
+	Canonical string
+	Fields    []fieldData
+}
+
+type fieldData struct {
+	// Name is the Go name, a parameter or a field
+	Name string
+	Type string
+	// ABIName is the name of the argument in the ABI
+	ABIName string
+}
+
+type generator struct {
+	prefix string
+	// structs are the Go names of the tuples by their canonical type and name
+	structs    map[string]string
+	structList []*structData
+	used       map[string]bool
+}
+
+// unique returns name, or name followed by the first number making it unused
+func (generator *generator) unique(name string) string {
+	return uniqueIn(generator.used, name)
+}
+
+// uniqueIn returns name, or name followed by the first number making it unused
+// in used, such as the fields of a struct or the parameters of a function
+func uniqueIn(used map[string]bool, name string) string {
+
+	candidate := name
+	for index := 0; used[candidate]; index++ {
+		candidate = fmt.Sprintf("%s%d", name, index)
+	}
+
+	used[candidate] = true
+
+	return candidate
+}
+
+func (generator *generator) method(method *abi.Method) *methodData {
+
+	data := &methodData{
+		Signature:   method.Signature(),
+		Declaration: declaration(method.Type, method.Name, method.Inputs),
+	}
+
+	if method.Type == abi.FunctionType {
+		data.Name = generator.unique(goName(method.Name, 0))
+	}
+
+	params := make(map[string]bool)
+	for index, input := range method.Inputs {
+		data.Params = append(data.Params, fieldData{
+			Name:    uniqueIn(params, paramName(input.Name, index)),
+			Type:    generator.goType(input.Type, data.Name+goName(input.Name, index)),
+			ABIName: input.Name,
+		})
+	}
+
+	outputs := make(map[string]bool)
+	for index, output := range method.Outputs {
+		data.Outputs = append(data.Outputs, fieldData{
+			Name:    uniqueIn(outputs, goName(output.Name, index)),
+			Type:    generator.goType(output.Type, data.Name+goName(output.Name, index)),
+			ABIName: output.Name,
+		})
+	}
+
+	switch len(data.Outputs) {
+	case 0:
+	case 1:
+		data.Output = data.Outputs[0].Type
+	default:
+		name := generator.unique(generator.prefix + data.Name + "Output")
+		generator.structList = append(generator.structList, &structData{
+			Name:      name,
+			Canonical: "(" + strings.Join(outputTypes(method.Outputs), ",") + ") returned by " + method.Signature(),
+			Fields:    data.Outputs,
+		})
+		data.Output = name
+	}
+
+	return data
+}
+
+func (generator *generator) event(event *abi.Event) *eventData {
+
+	// the parse and filter functions are unique among the methods
+	name := goName(event.Name, 0)
+	for index := 0; generator.used["Parse"+name] || generator.used["Filter"+name]; index++ {
+		name = fmt.Sprintf("%s%d", goName(event.Name, 0), index)
+	}
+	generator.used["Parse"+name] = true
+	generator.used["Filter"+name] = true
+
+	data := &eventData{
+		Name:        name,
+		Signature:   event.Signature(),
+		Declaration: declaration("event", event.Name, event.Inputs),
+		Raw:         "Raw",
+	}
+
+	data.Type = generator.unique(generator.prefix + data.Name)
+
+	var indexed []string
+	fields := make(map[string]bool)
+
+	for index, input := range event.Inputs {
+
+		fieldType := input.Type
+		if input.Indexed && fieldType.IsHashedTopic() {
+			fieldType = abi.Type{Kind: abi.FixedBytesKind, Size: 32}
+		}
+
+		data.Fields = append(data.Fields, fieldData{
+			Name:    uniqueIn(fields, goName(input.Name, index)),
+			Type:    generator.goType(fieldType, data.Name+goName(input.Name, index)),
+			ABIName: input.Name,
+		})
+
+		if input.Indexed {
+			indexed = append(indexed, paramName(input.Name, index))
+		}
+	}
+
+	if fields[data.Raw] {
+		data.Raw = uniqueIn(fields, "RawLog")
+	}
+
+	data.Indexed = strings.Join(indexed, ", ")
+
+	return data
+}
+
+// goType - the Go type of the values of t, the tuples named by their struct
+// or else by hint
+func (generator *generator) goType(t abi.Type, hint string) string {
+
+	switch t.Kind {
+	case abi.IntKind, abi.UintKind:
+		if t.Size == 8 || t.Size == 16 || t.Size == 32 || t.Size == 64 {
+			if t.Kind == abi.IntKind {
+				return fmt.Sprintf("int%d", t.Size)
+			}
+			return fmt.Sprintf("uint%d", t.Size)
+		}
+		return "*big.Int"
+	case abi.FixedKind, abi.UfixedKind:
+		return "*big.Int"
+	case abi.BoolKind:
+		return "bool"
+	case abi.AddressKind:
+		return "string"
+	case abi.StringKind:
+		return "string"
+	case abi.BytesKind:
+		return "[]byte"
+	case abi.FixedBytesKind, abi.FunctionKind:
+		return fmt.Sprintf("[%d]byte", t.Size)
+	case abi.SliceKind:
+		return "[]" + generator.goType(*t.Elem, hint)
+	case abi.ArrayKind:
+		return fmt.Sprintf("[%d]%s", t.Size, generator.goType(*t.Elem, hint))
+	case abi.TupleKind:
+		return generator.tuple(t, hint)
+	}
+
+	return "interface{}"
+}
+
+// tuple - the Go struct of a tuple, generated once for each name and components
+func (generator *generator) tuple(t abi.Type, hint string) string {
+
+	key := namedType(t)
+
+	if name, ok := generator.structs[key]; ok {
+		return name
+	}
+
+	base := t.TupleName
+	if base == "" {
+		base = hint
+	}
+
+	data := &structData{Name: generator.unique(generator.prefix + goName(base, 0)), Canonical: t.String()}
+	generator.structs[key] = data.Name
+
+	fields := make(map[string]bool)
+	for index, component := range t.Components {
+		data.Fields = append(data.Fields, fieldData{
+			Name:    uniqueIn(fields, goName(component.Name, index)),
+			Type:    generator.goType(component.Type, base+goName(component.Name, index)),
+			ABIName: component.Name,
+		})
+	}
+
+	generator.structList = append(generator.structList, data)
+
+	return data.Name
+}
+
+// namedType - the canonical type of t with the names of its tuples and of their
+// components, nested ones included, the tuples sharing a struct have the same
+func namedType(t abi.Type) string {
+
+	switch t.Kind {
+	case abi.SliceKind:
+		return namedType(*t.Elem) + "[]"
+	case abi.ArrayKind:
+		return fmt.Sprintf("%s[%d]", namedType(*t.Elem), t.Size)
+	case abi.TupleKind:
+		components := make([]string, len(t.Components))
+		for index, component := range t.Components {
+			components[index] = namedType(component.Type) + " " + component.Name
+		}
+		return t.TupleName + "(" + strings.Join(components, ",") + ")"
+	}
+
+	return t.String()
+}
+
+func outputTypes(arguments abi.Arguments) []string {
+
+	types := make([]string, len(arguments))
+	for index, argument := range arguments {
+		types[index] = argument.Type.String()
+	}
+
+	return types
+}
+
+// declaration - the human-readable declaration written in the comments
+func declaration(kind string, name string, arguments abi.Arguments) string {
+
+	written := make([]string, len(arguments))
+	for index, argument := range arguments {
+		written[index] = argument.Type.String()
+		if argument.Indexed {
+			written[index] += " indexed"
+		}
+		if argument.Name != "" {
+			written[index] += " " + argument.Name
+		}
+	}
+
+	if kind == abi.ConstructorType {
+		return "constructor(" + strings.Join(written, ", ") + ")"
+	}
+
+	return kind + " " + name + "(" + strings.Join(written, ", ") + ")"
+}
+
+// goName - the exported Go name of an ABI name, such as TotalSupply for total_supply,
+// Arg<position> for the unnamed ones
+func goName(name string, position int) string {
+
+	var written strings.Builder
+	upper := true
+
+	for _, char := range name {
+		switch {
+		case char == '_' || char == '$':
+			upper = true
+		case unicode.IsLetter(char) || unicode.IsDigit(char):
+			if upper {
+				char = unicode.ToUpper(char)
+				upper = false
+			}
+			written.WriteRune(char)
+		}
+	}
+
+	result := written.String()
+	if result == "" || !unicode.IsLetter(rune(result[0])) {
+		return fmt.Sprintf("Arg%d", position)
+	}
+
+	return result
+}
+
+// reserved - the names a parameter can't take in the generated functions
+var reserved = map[string]bool{
+	"break": true, "case": true, "chan": true, "const": true, "continue": true, "default": true,
+	"defer": true, "else": true, "fallthrough": true, "for": true, "func": true, "go": true,
+	"goto": true, "if": true, "import": true, "interface": true, "map": true, "package": true,
+	"range": true, "return": true, "select": true, "struct": true, "switch": true, "type": true,
+	"var": true, "string": true, "bool": true, "byte": true, "error": true, "big": true,
+	"dto": true, "eth": true, "fmt": true, "context": true, "ctx": true, "binding": true,
+	"client": true, "contract": true, "transaction": true, "out": true, "err": true,
+}
+
+// paramName - the unexported Go name of a parameter
+func paramName(name string, position int) string {
+
+	exported := goName(name, position)
+	if strings.HasPrefix(exported, "Arg") && exported == fmt.Sprintf("Arg%d", position) {
+		return fmt.Sprintf("arg%d", position)
+	}
+
+	lower := strings.ToLower(exported[:1]) + exported[1:]
+	if reserved[lower] {
+		return lower + "Arg"
+	}
+
+	return lower
+}
+
+func isIdentifier(name string) bool {
+
+	if name == "" || !unicode.IsLetter(rune(name[0])) {
+		return false
+	}
+
+	for _, char := range name {
+		if !unicode.IsLetter(char) && !unicode.IsDigit(char) && char != '_' {
+			return false
+		}
+	}
+
+	return true
+}
+
+var bindingTemplate = template.Must(template.New("binding").Funcs(template.FuncMap{
+	"params": func(fields []fieldData) string {
+		var written strings.Builder
+		for _, field := range fields {
+			written.WriteString(", " + field.Name + " " + field.Type)
+		}
+		return written.String()
+	},
+	"args": func(fields []fieldData) string {
+		var written strings.Builder
+		for _, field := range fields {
+			written.WriteString(", " + field.Name)
+		}
+		return written.String()
+	},
+}).Parse(`// Code generated by abigen{{if .Source}} from {{.Source}}{{end}}. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"context"
+{{- if .Events}}
+	"fmt"
+{{- end}}
+{{- if .UsesBig}}
+	"math/big"
+{{- end}}
+
+	"github.com/cellcycle/go-web3/dto"
+	"github.com/cellcycle/go-web3/eth"
+)
+
+// {{.Type}}ABI - The JSON ABI of {{.Type}}
+const {{.Type}}ABI = ` + "`{{.ABI}}`" + `
+{{if .Bytecode}}
+// {{.Type}}Bytecode - The creation code of {{.Type}}
+const {{.Type}}Bytecode = "{{.Bytecode}}"
+{{end}}
+{{- range .Errors}}
+// {{.Name}} - The selector of {{.Declaration}}
+const {{.Name}} = "{{.Selector}}"
+{{end}}
+{{- range .Structs}}
+// {{.Name}} - The tuple {{.Canonical}}
+type {{.Name}} struct {
+{{- range .Fields}}
+	{{.Name}} {{.Type}} ` + "`" + `abi:"{{.ABIName}}"` + "`" + `
+{{- end}}
+}
+{{end}}
+// {{.Type}} - The binding of the {{.Type}} contract
+type {{.Type}} struct {
+	Contract *eth.Contract
+	// From is the sender of the calls, optional
+	From string
+}
+
+// New{{.Type}} - Binds {{.Type}} deployed at address
+func New{{.Type}}(client *eth.Eth, address string) (*{{.Type}}, error) {
+
+	contract, err := client.NewContract({{.Type}}ABI)
+	if err != nil {
+		return nil, err
+	}
+
+	return &{{.Type}}{Contract: contract.At(address)}, nil
+}
+{{if .Bytecode}}
+// Deploy{{.Type}} - Deploys {{.Type}}{{if .Constructor.Params}} calling {{.Constructor.Declaration}}{{end}}, returns the hash of the transaction
+func Deploy{{.Type}}(ctx context.Context, client *eth.Eth, transaction *dto.TransactionParameters{{params .Constructor.Params}}) (string, error) {
+
+	contract, err := client.NewContract({{.Type}}ABI)
+	if err != nil {
+		return "", err
+	}
+
+	return contract.DeployCtx(ctx, transaction, {{.Type}}Bytecode{{args .Constructor.Params}})
+}
+{{end}}
+// call - the parameters of the calls
+func (binding *{{.Type}}) call() *dto.TransactionParameters {
+	return &dto.TransactionParameters{From: binding.From, To: binding.Contract.Address()}
+}
+
+// transact - a copy of transaction sent to the contract
+func (binding *{{.Type}}) transact(transaction *dto.TransactionParameters) *dto.TransactionParameters {
+
+	sent := dto.TransactionParameters{From: binding.From}
+	if transaction != nil {
+		sent = *transaction
+	}
+	sent.To = binding.Contract.Address()
+
+	return &sent
+}
+{{range .Calls}}
+// {{.Name}} - Calls {{.Declaration}}
+func (binding *{{$.Type}}) {{.Name}}(ctx context.Context{{params .Params}}) {{if .Output}}({{.Output}}, error){{else}}error{{end}} {
+{{- if .Output}}
+
+	var out {{.Output}}
+	err := binding.Contract.CallIntoCtx(ctx, binding.call(), &out, "{{.Signature}}"{{args .Params}})
+
+	return out, err
+{{- else}}
+
+	_, err := binding.Contract.CallCtx(ctx, binding.call(), "{{.Signature}}"{{args .Params}})
+
+	return err
+{{- end}}
+}
+{{end}}
+{{- range .Transacts}}
+// {{.Name}} - Sends a transaction calling {{.Declaration}}, returns its hash
+func (binding *{{$.Type}}) {{.Name}}(ctx context.Context, transaction *dto.TransactionParameters{{params .Params}}) (string, error) {
+	return binding.Contract.SendCtx(ctx, binding.transact(transaction), "{{.Signature}}"{{args .Params}})
+}
+{{end}}
+{{- range .Events}}
+// {{.Type}} - A log of {{.Declaration}}
+type {{.Type}} struct {
+{{- range .Fields}}
+	{{.Name}} {{.Type}} ` + "`" + `abi:"{{.ABIName}}"` + "`" + `
+{{- end}}
+	{{.Raw}} *dto.TransactionLogs ` + "`" + `abi:"-"` + "`" + `
+}
+
+// Parse{{.Name}} - Decodes a log of {{.Declaration}}
+func (binding *{{$.Type}}) Parse{{.Name}}(log *dto.TransactionLogs) (*{{.Type}}, error) {
+
+	event, err := binding.Contract.ParseLog(log)
+	if err != nil {
+		return nil, err
+	}
+
+	if event.Event.Signature() != "{{.Signature}}" {
+		return nil, fmt.Errorf("The log was emitted by %s, not by {{.Signature}}", event.Event.Signature())
+	}
+
+	parsed := &{{.Type}}{ {{- .Raw}}: log}
+	if err := event.Decode(parsed); err != nil {
+		return nil, err
+	}
+
+	return parsed, nil
+}
+
+// Filter{{.Name}} - The logs of {{.Signature}} between fromBlock and toBlock{{if .Indexed}},
+// the indexed fields {{.Indexed}} equal to indexedArgs in order, nil matching any value{{end}}
+func (binding *{{$.Type}}) Filter{{.Name}}(ctx context.Context, fromBlock string, toBlock string, indexedArgs ...interface{}) ([]*{{.Type}}, error) {
+
+	events, err := binding.Contract.FilterLogsCtx(ctx, "{{.Signature}}", fromBlock, toBlock, indexedArgs...)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed := make([]*{{.Type}}, len(events))
+
+	for index, event := range events {
+		parsed[index] = &{{.Type}}{ {{- .Raw}}: event.Log}
+		if err := event.Decode(parsed[index]); err != nil {
+			return nil, err
+		}
+	}
+
+	return parsed, nil
+}
+{{end}}`))
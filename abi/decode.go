@@ -0,0 +1,458 @@
+/********************************************************************************
+   This file is part of go-web3.
+   go-web3 is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-web3 is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-web3.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file decode.go
+ */
+
+package abi
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// Unpack - Decodes data, the encoding of the arguments as a tuple, into Go values:
+//   - intN, uintN, fixedMxN, ufixedMxN: *big.Int
+//   - bool: bool
+//   - address: [20]byte
+//   - bytesN: [N]byte, function: [24]byte
+//   - bytes: []byte
+//   - string: string
+//   - T[], T[k]: a slice or an array of the values of T
+//   - tuples: a struct with a field per component, tagged abi:"name"
+func (arguments Arguments) Unpack(data []byte) ([]interface{}, error) {
+
+	values := make([]interface{}, len(arguments))
+	targets := make([]reflect.Value, len(arguments))
+	types := make([]Type, len(arguments))
+
+	for index, argument := range arguments {
+		targets[index] = reflect.ValueOf(&values[index]).Elem()
+		types[index] = argument.Type
+	}
+
+	if err := decodeTuple(types, arguments.names(), data, targets); err != nil {
+		return nil, err
+	}
+
+	return values, nil
+}
+
+// UnpackInto - Decodes data into out, a pointer. A single argument is decoded into
+// out itself, several arguments into the fields of a struct, matched as the components
+// of the tuples are, or into the elements of a []interface{}.
+// Besides the values returned by Unpack, the integers are decoded into big.Int and
+// the Go integers when they fit, the addresses and the fixed bytes into the byte arrays
+// of their length, the byte slices and 0x prefixed hex strings, and the bytes into strings.
+func (arguments Arguments) UnpackInto(out interface{}, data []byte) error {
+
+	pointer := reflect.ValueOf(out)
+	if pointer.Kind() != reflect.Ptr || pointer.IsNil() {
+		return fmt.Errorf("Expected a non nil pointer to decode into, got %T", out)
+	}
+
+	target := pointer.Elem()
+
+	// several values, or a single one which is not a tuple, decoded into the fields of a struct
+	asFields := target.Kind() == reflect.Struct && target.Type() != bigIntType &&
+		(len(arguments) != 1 || arguments[0].Type.Kind != TupleKind)
+
+	if len(arguments) == 1 && !asFields {
+		return decodeTuple([]Type{arguments[0].Type}, arguments.names(), data, []reflect.Value{target})
+	}
+
+	return decode(Type{Kind: TupleKind, Components: arguments}, data, target)
+}
+
+// decodeTuple decodes data, a tuple of types, into the settable targets
+func decodeTuple(types []Type, names []string, data []byte, targets []reflect.Value) error {
+
+	size := 0
+	for _, t := range types {
+		size += headSize(t)
+	}
+
+	if len(data) < size {
+		return fmt.Errorf("Data too short: %d bytes given, at least %d expected", len(data), size)
+	}
+
+	position := 0
+
+	for index, t := range types {
+
+		var err error
+
+		if t.IsDynamic() {
+			var offset int
+			offset, err = readLength(data, position, "offset")
+			if err == nil {
+				err = decode(t, data[offset:], targets[index])
+			}
+		} else {
+			err = decode(t, data[position:], targets[index])
+		}
+
+		if err != nil {
+			return fmt.Errorf("%s: %v", names[index], err)
+		}
+
+		position += headSize(t)
+	}
+
+	return nil
+}
+
+// decode decodes the value of type t found at the start of data into target
+func decode(t Type, data []byte, target reflect.Value) error {
+
+	// the pointers are allocated, *big.Int is set as a whole
+	for target.Kind() == reflect.Ptr && target.Type().Elem() != bigIntType {
+		if target.IsNil() {
+			target.Set(reflect.New(target.Type().Elem()))
+		}
+		target = target.Elem()
+	}
+
+	if target.Kind() == reflect.Interface {
+		if target.NumMethod() != 0 {
+			return fmt.Errorf("Can't decode a %s into %s", t, target.Type())
+		}
+		value := reflect.New(goType(t)).Elem()
+		if err := decode(t, data, value); err != nil {
+			return err
+		}
+		target.Set(value)
+		return nil
+	}
+
+	switch t.Kind {
+	case IntKind, UintKind, FixedKind, UfixedKind:
+		return decodeInteger(t, data, target)
+
+	case BoolKind:
+		word, err := readWord(data, 0)
+		if err != nil {
+			return err
+		}
+		number := new(big.Int).SetBytes(word)
+		if number.BitLen() > 1 {
+			return fmt.Errorf("Invalid bool 0x%x", word)
+		}
+		if target.Kind() != reflect.Bool {
+			return fmt.Errorf("Can't decode a bool into %s", target.Type())
+		}
+		target.SetBool(number.Sign() == 1)
+		return nil
+
+	case AddressKind:
+		word, err := readWord(data, 0)
+		if err != nil {
+			return err
+		}
+		if !isZero(word[:wordSize-20]) {
+			return fmt.Errorf("Invalid address 0x%x", word)
+		}
+		return setBytes(t, word[wordSize-20:], target)
+
+	case FixedBytesKind, FunctionKind:
+		word, err := readWord(data, 0)
+		if err != nil {
+			return err
+		}
+		if !isZero(word[t.Size:]) {
+			return fmt.Errorf("Invalid %s 0x%x", t, word)
+		}
+		return setBytes(t, word[:t.Size], target)
+
+	case StringKind, BytesKind:
+		length, err := readLength(data, 0, "length")
+		if err != nil {
+			return err
+		}
+		if len(data)-wordSize < length {
+			return fmt.Errorf("Data too short: %d bytes of %s expected, %d given", length, t, len(data)-wordSize)
+		}
+		content := data[wordSize : wordSize+length]
+		switch {
+		case target.Kind() == reflect.String:
+			target.SetString(string(content))
+		case target.Kind() == reflect.Slice && target.Type().Elem().Kind() == reflect.Uint8:
+			target.SetBytes(append([]byte{}, content...))
+		default:
+			return fmt.Errorf("Can't decode a %s into %s", t, target.Type())
+		}
+		return nil
+
+	case SliceKind, ArrayKind:
+		length := t.Size
+		if t.Kind == SliceKind {
+			var err error
+			if length, err = readLength(data, 0, "length"); err != nil {
+				return err
+			}
+			data = data[wordSize:]
+			// each element takes a word at least
+			if length > len(data)/wordSize {
+				return fmt.Errorf("Data too short: %d elements of %s expected in %d bytes", length, t, len(data))
+			}
+		}
+
+		switch target.Kind() {
+		case reflect.Slice:
+			target.Set(reflect.MakeSlice(target.Type(), length, length))
+		case reflect.Array:
+			if target.Len() != length {
+				return fmt.Errorf("Can't decode %d elements of %s into %s", length, t, target.Type())
+			}
+		default:
+			return fmt.Errorf("Can't decode a %s into %s", t, target.Type())
+		}
+
+		types := make([]Type, length)
+		names := make([]string, length)
+		elements := make([]reflect.Value, length)
+		for index := 0; index < length; index++ {
+			types[index] = *t.Elem
+			names[index] = fmt.Sprintf("[%d]", index)
+			elements[index] = target.Index(index)
+		}
+
+		return decodeTuple(types, names, data, elements)
+
+	case TupleKind:
+		fields, err := tupleTargets(t, target)
+		if err != nil {
+			return err
+		}
+
+		types := make([]Type, len(t.Components))
+		for index, component := range t.Components {
+			types[index] = component.Type
+		}
+
+		return decodeTuple(types, Arguments(t.Components).names(), data, fields)
+	}
+
+	return fmt.Errorf("Unsupported type %s", t)
+}
+
+// tupleTargets returns the settable values receiving the components of t: the
+// fields of a struct or the elements of a []interface{}
+func tupleTargets(t Type, target reflect.Value) ([]reflect.Value, error) {
+
+	fields := make([]reflect.Value, len(t.Components))
+
+	switch target.Kind() {
+	case reflect.Struct:
+		if target.Type() == bigIntType {
+			break
+		}
+		for index, component := range t.Components {
+			field, ok := structField(target, index, component.Name)
+			if !ok {
+				return nil, fmt.Errorf("No field of %s for the component %q", target.Type(), component.Name)
+			}
+			fields[index] = field
+		}
+		return fields, nil
+
+	case reflect.Slice:
+		if target.Type().Elem().Kind() != reflect.Interface {
+			break
+		}
+		target.Set(reflect.MakeSlice(target.Type(), len(fields), len(fields)))
+		for index := range fields {
+			fields[index] = target.Index(index)
+		}
+		return fields, nil
+	}
+
+	return nil, fmt.Errorf("Can't decode a %s into %s", t, target.Type())
+}
+
+// decodeInteger decodes the two's complement in the word at the start of data,
+// checks it is within the bits of t and sets it into target
+func decodeInteger(t Type, data []byte, target reflect.Value) error {
+
+	word, err := readWord(data, 0)
+	if err != nil {
+		return err
+	}
+
+	number := new(big.Int).SetBytes(word)
+
+	signed := t.Kind == IntKind || t.Kind == FixedKind
+	if signed && word[0]&0x80 != 0 {
+		number.Sub(number, twoTo256)
+	}
+
+	min, max := integerBounds(t)
+	if number.Cmp(min) < 0 || number.Cmp(max) >= 0 {
+		return fmt.Errorf("Invalid %s 0x%x", t, word)
+	}
+
+	switch target.Kind() {
+	case reflect.Ptr:
+		target.Set(reflect.ValueOf(number))
+		return nil
+
+	case reflect.Struct:
+		if target.Type() == bigIntType {
+			target.Set(reflect.ValueOf(number).Elem())
+			return nil
+		}
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if !number.IsInt64() || target.OverflowInt(number.Int64()) {
+			return fmt.Errorf("%s overflows %s", number, target.Type())
+		}
+		target.SetInt(number.Int64())
+		return nil
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if !number.IsUint64() || target.OverflowUint(number.Uint64()) {
+			return fmt.Errorf("%s overflows %s", number, target.Type())
+		}
+		target.SetUint(number.Uint64())
+		return nil
+	}
+
+	return fmt.Errorf("Can't decode a %s into %s", t, target.Type())
+}
+
+// setBytes sets data, the bytes of an address or of fixed bytes, into a byte
+// array of the same length, a byte slice or a 0x prefixed hex string
+func setBytes(t Type, data []byte, target reflect.Value) error {
+
+	switch target.Kind() {
+	case reflect.Array:
+		if target.Type().Elem().Kind() == reflect.Uint8 && target.Len() == len(data) {
+			reflect.Copy(target, reflect.ValueOf(data))
+			return nil
+		}
+
+	case reflect.Slice:
+		if target.Type().Elem().Kind() == reflect.Uint8 {
+			target.SetBytes(append([]byte{}, data...))
+			return nil
+		}
+
+	case reflect.String:
+		target.SetString("0x" + hex.EncodeToString(data))
+		return nil
+	}
+
+	return fmt.Errorf("Can't decode a %s into %s", t, target.Type())
+}
+
+// readWord returns the word at position of data
+func readWord(data []byte, position int) ([]byte, error) {
+
+	if len(data) < position+wordSize {
+		return nil, fmt.Errorf("Data too short: a word expected at byte %d of %d", position, len(data))
+	}
+
+	return data[position : position+wordSize], nil
+}
+
+// readLength reads the word at position of data as an offset or a length,
+// which can't go past the end of data
+func readLength(data []byte, position int, what string) (int, error) {
+
+	word, err := readWord(data, position)
+	if err != nil {
+		return 0, err
+	}
+
+	number := new(big.Int).SetBytes(word)
+	if !number.IsInt64() || number.Int64() > int64(len(data)) {
+		return 0, fmt.Errorf("Invalid %s %s, past the %d bytes of data", what, number, len(data))
+	}
+
+	return int(number.Int64()), nil
+}
+
+func isZero(data []byte) bool {
+	for _, b := range data {
+		if b != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// goType - the Go type of the values of t returned by Unpack
+func goType(t Type) reflect.Type {
+
+	switch t.Kind {
+	case IntKind, UintKind, FixedKind, UfixedKind:
+		return reflect.PtrTo(bigIntType)
+	case BoolKind:
+		return reflect.TypeOf(false)
+	case AddressKind, FixedBytesKind, FunctionKind:
+		return reflect.ArrayOf(t.Size, reflect.TypeOf(byte(0)))
+	case StringKind:
+		return reflect.TypeOf("")
+	case BytesKind:
+		return reflect.TypeOf([]byte{})
+	case SliceKind:
+		return reflect.SliceOf(goType(*t.Elem))
+	case ArrayKind:
+		return reflect.ArrayOf(t.Size, goType(*t.Elem))
+	case TupleKind:
+		fields := make([]reflect.StructField, len(t.Components))
+		used := make(map[string]bool)
+		for index, component := range t.Components {
+			name := fieldName(component.Name)
+			if name == "" || used[name] {
+				name = fmt.Sprintf("Field%d", index)
+			}
+			// the name of a component can be taken by the fallback of another one
+			for suffix := 1; used[name]; suffix++ {
+				name = fmt.Sprintf("Field%d_%d", index, suffix)
+			}
+			used[name] = true
+			fields[index] = reflect.StructField{
+				Name: name,
+				Type: goType(component.Type),
+				Tag:  reflect.StructTag(fmt.Sprintf(`abi:"%s"`, component.Name)),
+			}
+		}
+		return reflect.StructOf(fields)
+	}
+
+	return reflect.TypeOf((*interface{})(nil)).Elem()
+}
+
+// fieldName - the exported Go name of a component, such as From for _from
+func fieldName(name string) string {
+
+	name = strings.TrimLeft(name, "_")
+	if name == "" || !unicode.IsLetter(rune(name[0])) {
+		return ""
+	}
+
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' {
+			return ""
+		}
+	}
+
+	return strings.ToUpper(name[:1]) + name[1:]
+}
@@ -0,0 +1,456 @@
+/********************************************************************************
+   This file is part of go-web3.
+   go-web3 is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-web3 is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-web3.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file encode.go
+ */
+
+package abi
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+)
+
+// wordSize - the size of the slots of the encoding
+const wordSize = 32
+
+var (
+	bigIntType = reflect.TypeOf(big.Int{})
+	// twoTo256 - added to the negative integers to get their two's complement
+	twoTo256 = new(big.Int).Lsh(big.NewInt(1), 256)
+)
+
+// Pack - The call data of the function: its selector followed by the encoded values
+func (method *Method) Pack(values ...interface{}) ([]byte, error) {
+
+	arguments, err := method.Inputs.Pack(values...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", method.Signature(), err)
+	}
+
+	selector := method.Selector()
+
+	return append(selector[:], arguments...), nil
+}
+
+// Pack - Encodes values as the arguments, as a tuple of their types.
+// The Go values accepted for each type are:
+//   - intN, uintN: *big.Int, big.Int and the Go integers, within the bounds of N bits
+//   - fixedMxN, ufixedMxN: the same integers, holding the value times 10^N
+//   - bool: bool
+//   - address: a 0x prefixed hex string, [20]byte or a 20 bytes slice
+//   - bytes, bytesN, function: []byte, [N]byte or a 0x prefixed hex string
+//   - string: string
+//   - T[], T[k]: a slice or an array of the values of T
+//   - tuples: a struct, its fields matched to the components by their abi:"name"
+//     tag or by their name, a map[string]interface{} or a []interface{} in order
+func (arguments Arguments) Pack(values ...interface{}) ([]byte, error) {
+
+	if len(values) != len(arguments) {
+		return nil, fmt.Errorf("%d values given for %d arguments", len(values), len(arguments))
+	}
+
+	types := make([]Type, len(arguments))
+	fields := make([]reflect.Value, len(arguments))
+
+	for index, argument := range arguments {
+		types[index] = argument.Type
+		fields[index] = reflect.ValueOf(values[index])
+	}
+
+	return encodeTuple(types, fields, arguments.names())
+}
+
+// names - the names of the arguments, their position for the anonymous ones
+func (arguments Arguments) names() []string {
+
+	names := make([]string, len(arguments))
+	for index, argument := range arguments {
+		names[index] = argument.Name
+		if names[index] == "" {
+			names[index] = fmt.Sprintf("#%d", index)
+		}
+	}
+
+	return names
+}
+
+// headSize - the size of the type in the head of its tuple: the whole encoding
+// of the static types, the offset of the dynamic ones
+func headSize(t Type) int {
+
+	if t.IsDynamic() {
+		return wordSize
+	}
+
+	switch t.Kind {
+	case ArrayKind:
+		return t.Size * headSize(*t.Elem)
+	case TupleKind:
+		size := 0
+		for _, component := range t.Components {
+			size += headSize(component.Type)
+		}
+		return size
+	}
+
+	return wordSize
+}
+
+// encodeTuple encodes values as a tuple of types: the static values and the offsets
+// of the dynamic ones first, then the dynamic values
+func encodeTuple(types []Type, values []reflect.Value, names []string) ([]byte, error) {
+
+	size := 0
+	for _, t := range types {
+		size += headSize(t)
+	}
+
+	var head, tail []byte
+
+	for index, t := range types {
+
+		encoded, err := encode(t, values[index])
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", names[index], err)
+		}
+
+		if t.IsDynamic() {
+			head = append(head, encodeUint(big.NewInt(int64(size+len(tail))))...)
+			tail = append(tail, encoded...)
+		} else {
+			head = append(head, encoded...)
+		}
+	}
+
+	return append(head, tail...), nil
+}
+
+// encode encodes a single value of type t
+func encode(t Type, value reflect.Value) ([]byte, error) {
+
+	value = indirect(value)
+	if !value.IsValid() {
+		return nil, fmt.Errorf("Missing value of type %s", t)
+	}
+
+	switch t.Kind {
+	case IntKind, UintKind, FixedKind, UfixedKind:
+		return encodeInteger(t, value)
+
+	case BoolKind:
+		if value.Kind() != reflect.Bool {
+			return nil, fmt.Errorf("Expected a bool, got %s", value.Type())
+		}
+		if value.Bool() {
+			return encodeUint(big.NewInt(1)), nil
+		}
+		return encodeUint(big.NewInt(0)), nil
+
+	case AddressKind:
+		data, err := bytesOf(value)
+		if err != nil {
+			return nil, err
+		}
+		if len(data) != 20 {
+			return nil, fmt.Errorf("Expected a 20 bytes address, got %d bytes", len(data))
+		}
+		return leftPad(data), nil
+
+	case FixedBytesKind, FunctionKind:
+		data, err := bytesOf(value)
+		if err != nil {
+			return nil, err
+		}
+		if len(data) != t.Size {
+			return nil, fmt.Errorf("%d bytes given for a %s", len(data), t)
+		}
+		return rightPad(data), nil
+
+	case StringKind:
+		if value.Kind() != reflect.String {
+			return nil, fmt.Errorf("Expected a string, got %s", value.Type())
+		}
+		return encodeDynamicBytes([]byte(value.String())), nil
+
+	case BytesKind:
+		data, err := bytesOf(value)
+		if err != nil {
+			return nil, err
+		}
+		return encodeDynamicBytes(data), nil
+
+	case SliceKind, ArrayKind:
+		if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+			return nil, fmt.Errorf("Expected a slice for %s, got %s", t, value.Type())
+		}
+
+		length := value.Len()
+		if t.Kind == ArrayKind && length != t.Size {
+			return nil, fmt.Errorf("%d elements given for a %s", length, t)
+		}
+
+		types := make([]Type, length)
+		elements := make([]reflect.Value, length)
+		names := make([]string, length)
+		for index := 0; index < length; index++ {
+			types[index] = *t.Elem
+			elements[index] = value.Index(index)
+			names[index] = fmt.Sprintf("[%d]", index)
+		}
+
+		encoded, err := encodeTuple(types, elements, names)
+		if err != nil {
+			return nil, err
+		}
+
+		if t.Kind == SliceKind {
+			return append(encodeUint(big.NewInt(int64(length))), encoded...), nil
+		}
+		return encoded, nil
+
+	case TupleKind:
+		fields, err := tupleFields(t, value)
+		if err != nil {
+			return nil, err
+		}
+
+		types := make([]Type, len(t.Components))
+		for index, component := range t.Components {
+			types[index] = component.Type
+		}
+
+		return encodeTuple(types, fields, Arguments(t.Components).names())
+	}
+
+	return nil, fmt.Errorf("Unsupported type %s", t)
+}
+
+// indirect unwraps the interfaces and the pointers, except the *big.Int
+func indirect(value reflect.Value) reflect.Value {
+
+	for value.IsValid() && (value.Kind() == reflect.Interface || value.Kind() == reflect.Ptr) {
+		if value.IsNil() {
+			return reflect.Value{}
+		}
+		if value.Kind() == reflect.Ptr && value.Type().Elem() == bigIntType {
+			return value
+		}
+		value = value.Elem()
+	}
+
+	return value
+}
+
+// integerOf returns the value of the Go integers and of the big.Int
+func integerOf(value reflect.Value) (*big.Int, error) {
+
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return big.NewInt(value.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return new(big.Int).SetUint64(value.Uint()), nil
+	case reflect.Ptr:
+		if number, ok := value.Interface().(*big.Int); ok {
+			return number, nil
+		}
+	case reflect.Struct:
+		if value.Type() == bigIntType {
+			number := value.Interface().(big.Int)
+			return &number, nil
+		}
+	}
+
+	return nil, fmt.Errorf("Expected an integer, got %s", value.Type())
+}
+
+// encodeInteger checks the value is within the bits of t and encodes it in two's complement
+func encodeInteger(t Type, value reflect.Value) ([]byte, error) {
+
+	number, err := integerOf(value)
+	if err != nil {
+		return nil, err
+	}
+
+	min, max := integerBounds(t)
+	if number.Cmp(min) < 0 || number.Cmp(max) >= 0 {
+		return nil, fmt.Errorf("%s out of the bounds of %s", number, t)
+	}
+
+	if number.Sign() < 0 {
+		number = new(big.Int).Add(number, twoTo256)
+	}
+
+	return encodeUint(number), nil
+}
+
+// integerBounds - the lowest value of the integers of t, and the one past the highest
+func integerBounds(t Type) (*big.Int, *big.Int) {
+
+	if t.Kind == IntKind || t.Kind == FixedKind {
+		max := new(big.Int).Lsh(big.NewInt(1), uint(t.Size-1))
+		return new(big.Int).Neg(max), max
+	}
+
+	return new(big.Int), new(big.Int).Lsh(big.NewInt(1), uint(t.Size))
+}
+
+// encodeUint encodes a positive integer below 2^256 in a word
+func encodeUint(number *big.Int) []byte {
+	return leftPad(number.Bytes())
+}
+
+// bytesOf returns the bytes of a []byte, a byte array or a 0x prefixed hex string
+func bytesOf(value reflect.Value) ([]byte, error) {
+
+	switch value.Kind() {
+	case reflect.String:
+		text := value.String()
+		if !strings.HasPrefix(text, "0x") && !strings.HasPrefix(text, "0X") {
+			return nil, fmt.Errorf("Expected a 0x prefixed hex string, got %q", text)
+		}
+		data, err := hex.DecodeString(text[2:])
+		if err != nil {
+			return nil, fmt.Errorf("Invalid hex string %q: %v", text, err)
+		}
+		return data, nil
+
+	case reflect.Slice:
+		if value.Type().Elem().Kind() == reflect.Uint8 {
+			return value.Bytes(), nil
+		}
+
+	case reflect.Array:
+		if value.Type().Elem().Kind() == reflect.Uint8 {
+			data := make([]byte, value.Len())
+			reflect.Copy(reflect.ValueOf(data), value)
+			return data, nil
+		}
+	}
+
+	return nil, fmt.Errorf("Expected bytes, got %s", value.Type())
+}
+
+// encodeDynamicBytes encodes the length of data followed by data padded to a word
+func encodeDynamicBytes(data []byte) []byte {
+
+	encoded := encodeUint(big.NewInt(int64(len(data))))
+	if len(data) == 0 {
+		return encoded
+	}
+
+	return append(encoded, rightPad(data)...)
+}
+
+// leftPad pads data with zeros before it, to a word
+func leftPad(data []byte) []byte {
+	padded := make([]byte, wordSize)
+	copy(padded[wordSize-len(data):], data)
+	return padded
+}
+
+// rightPad pads data with zeros after it, to a multiple of a word
+func rightPad(data []byte) []byte {
+	size := (len(data) + wordSize - 1) / wordSize * wordSize
+	if size == 0 {
+		size = wordSize
+	}
+	padded := make([]byte, size)
+	copy(padded, data)
+	return padded
+}
+
+// tupleFields returns the values of the components of t found in value: a
+// struct, a map from the component names or a slice of the values in order
+func tupleFields(t Type, value reflect.Value) ([]reflect.Value, error) {
+
+	fields := make([]reflect.Value, len(t.Components))
+
+	switch value.Kind() {
+	case reflect.Struct:
+		for index, component := range t.Components {
+			field, ok := structField(value, index, component.Name)
+			if !ok {
+				return nil, fmt.Errorf("No field of %s for the component %q", value.Type(), component.Name)
+			}
+			fields[index] = field
+		}
+		return fields, nil
+
+	case reflect.Map:
+		if value.Type().Key().Kind() != reflect.String {
+			break
+		}
+		for index, component := range t.Components {
+			field := value.MapIndex(reflect.ValueOf(component.Name).Convert(value.Type().Key()))
+			if !field.IsValid() {
+				return nil, fmt.Errorf("No value for the component %q", component.Name)
+			}
+			fields[index] = field
+		}
+		return fields, nil
+
+	case reflect.Slice, reflect.Array:
+		if value.Len() != len(t.Components) {
+			return nil, fmt.Errorf("%d values given for a tuple of %d components", value.Len(), len(t.Components))
+		}
+		for index := range t.Components {
+			fields[index] = value.Index(index)
+		}
+		return fields, nil
+	}
+
+	return nil, fmt.Errorf("Expected a struct for %s, got %s", t, value.Type())
+}
+
+// structField finds the exported field tagged abi:"name", or else named name
+// regardless of its case. The unnamed components take the exported field at position.
+func structField(value reflect.Value, position int, name string) (reflect.Value, bool) {
+
+	structType := value.Type()
+
+	if name == "" {
+		exported := 0
+		for index := 0; index < structType.NumField(); index++ {
+			if structType.Field(index).PkgPath != "" {
+				continue
+			}
+			if exported == position {
+				return value.Field(index), true
+			}
+			exported++
+		}
+		return reflect.Value{}, false
+	}
+
+	for index := 0; index < structType.NumField(); index++ {
+		field := structType.Field(index)
+		if field.PkgPath == "" && field.Tag.Get("abi") == name {
+			return value.Field(index), true
+		}
+	}
+
+	for index := 0; index < structType.NumField(); index++ {
+		field := structType.Field(index)
+		if field.PkgPath == "" && field.Tag.Get("abi") == "" && strings.EqualFold(field.Name, strings.TrimPrefix(name, "_")) {
+			return value.Field(index), true
+		}
+	}
+
+	return reflect.Value{}, false
+}
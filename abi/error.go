@@ -0,0 +1,74 @@
+/********************************************************************************
+   This file is part of go-web3.
+   go-web3 is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-web3 is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-web3.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file error.go
+ */
+
+package abi
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// Unpack - Decodes the arguments of the error from data, the revert data starting
+// with its selector, into the values returned by Arguments.Unpack
+func (contractError *Error) Unpack(data []byte) ([]interface{}, error) {
+
+	body, err := contractError.body(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return contractError.Inputs.Unpack(body)
+}
+
+// UnpackInto - Decodes the arguments of the error from data into out, as Arguments.UnpackInto
+func (contractError *Error) UnpackInto(out interface{}, data []byte) error {
+
+	body, err := contractError.body(data)
+	if err != nil {
+		return err
+	}
+
+	return contractError.Inputs.UnpackInto(out, body)
+}
+
+// body - the encoded arguments following the selector of the error
+func (contractError *Error) body(data []byte) ([]byte, error) {
+
+	selector := contractError.Selector()
+	if len(data) < 4 || !bytes.Equal(data[:4], selector[:]) {
+		return nil, fmt.Errorf("The revert data isn't a %s", contractError.Signature())
+	}
+
+	return data[4:], nil
+}
+
+// UnpackError - Decodes revert data with the custom error of the ABI whose selector starts it
+func (definition *ABI) UnpackError(data []byte) (*Error, []interface{}, error) {
+
+	contractError, err := definition.ErrorBySelector(data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	values, err := contractError.Unpack(data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Invalid %s: %v", contractError.Signature(), err)
+	}
+
+	return contractError, values, nil
+}
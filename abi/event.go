@@ -0,0 +1,261 @@
+/********************************************************************************
+   This file is part of go-web3.
+   go-web3 is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-web3 is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-web3.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file event.go
+ */
+
+package abi
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/cellcycle/go-web3/utils"
+)
+
+// hashType - the type of the topics of the indexed fields stored as their hash
+var hashType = Type{Kind: FixedBytesKind, Size: 32}
+
+// IsHashedTopic - Tells if an indexed field of type t is stored in its topic as the
+// Keccak-256 hash of its value: the strings, the bytes, the arrays and the tuples
+func (t Type) IsHashedTopic() bool {
+
+	switch t.Kind {
+	case StringKind, BytesKind, SliceKind, ArrayKind, TupleKind:
+		return true
+	}
+
+	return false
+}
+
+// UnpackLog - Decodes the fields of a log of the event, the indexed ones from
+// topics and the others from data, into the values returned by Arguments.Unpack.
+// The indexed fields stored as their hash are decoded as a [32]byte.
+func (event *Event) UnpackLog(topics [][32]byte, data []byte) ([]interface{}, error) {
+
+	values := make([]interface{}, len(event.Inputs))
+	targets := make([]reflect.Value, len(event.Inputs))
+
+	for index := range event.Inputs {
+		targets[index] = reflect.ValueOf(&values[index]).Elem()
+	}
+
+	if err := event.unpackLog(topics, data, targets); err != nil {
+		return nil, err
+	}
+
+	return values, nil
+}
+
+// UnpackLogInto - Decodes the fields of a log of the event into out, a pointer to
+// a struct with a field per event field, or to a []interface{}
+func (event *Event) UnpackLogInto(out interface{}, topics [][32]byte, data []byte) error {
+
+	pointer := reflect.ValueOf(out)
+	if pointer.Kind() != reflect.Ptr || pointer.IsNil() {
+		return fmt.Errorf("Expected a non nil pointer to decode into, got %T", out)
+	}
+
+	targets, err := tupleTargets(Type{Kind: TupleKind, Components: event.Inputs}, pointer.Elem())
+	if err != nil {
+		return err
+	}
+
+	return event.unpackLog(topics, data, targets)
+}
+
+func (event *Event) unpackLog(topics [][32]byte, data []byte, targets []reflect.Value) error {
+
+	if !event.Anonymous {
+		if len(topics) == 0 || topics[0] != event.Topic() {
+			return fmt.Errorf("The log wasn't emitted by the event %s", event.Signature())
+		}
+		topics = topics[1:]
+	}
+
+	var indexed int
+	for _, input := range event.Inputs {
+		if input.Indexed {
+			indexed++
+		}
+	}
+
+	if len(topics) != indexed {
+		return fmt.Errorf("%s has %d indexed fields, the log %d topics", event.Signature(), indexed, len(topics))
+	}
+
+	names := event.Inputs.names()
+
+	var types []Type
+	var dataNames []string
+	var dataTargets []reflect.Value
+
+	for index, input := range event.Inputs {
+
+		if !input.Indexed {
+			types = append(types, input.Type)
+			dataNames = append(dataNames, names[index])
+			dataTargets = append(dataTargets, targets[index])
+			continue
+		}
+
+		topicType := input.Type
+		if topicType.IsHashedTopic() {
+			topicType = hashType
+		}
+
+		topic := topics[0]
+		topics = topics[1:]
+
+		if err := decode(topicType, topic[:], targets[index]); err != nil {
+			return fmt.Errorf("%s: %v", names[index], err)
+		}
+	}
+
+	return decodeTuple(types, dataNames, data, dataTargets)
+}
+
+// Topics - The topics filtering the logs of the event: the topic of the event
+// unless it is anonymous, followed by the topics of the indexed fields equal to
+// values, in order. A nil value, or a missing one, matches any topic. Each position
+// holds the accepted topics, an empty position matches any topic.
+func (event *Event) Topics(values ...interface{}) ([][][32]byte, error) {
+
+	var indexed Arguments
+	for _, input := range event.Inputs {
+		if input.Indexed {
+			indexed = append(indexed, input)
+		}
+	}
+
+	if len(values) > len(indexed) {
+		return nil, fmt.Errorf("%d values given for the %d indexed fields of %s", len(values), len(indexed), event.Signature())
+	}
+
+	var topics [][][32]byte
+	if !event.Anonymous {
+		topics = append(topics, [][32]byte{event.Topic()})
+	}
+
+	names := indexed.names()
+
+	for index, value := range values {
+
+		if value == nil {
+			topics = append(topics, nil)
+			continue
+		}
+
+		topic, err := EncodeTopic(indexed[index].Type, value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", names[index], err)
+		}
+
+		topics = append(topics, [][32]byte{topic})
+	}
+
+	// the trailing positions matching any topic are left out
+	for len(topics) > 0 && len(topics[len(topics)-1]) == 0 {
+		topics = topics[:len(topics)-1]
+	}
+
+	return topics, nil
+}
+
+// EncodeTopic - The topic of an indexed field of type t equal to value: the
+// encoding of the value types, the hash of the others
+func EncodeTopic(t Type, value interface{}) ([32]byte, error) {
+
+	var topic [32]byte
+
+	if !t.IsHashedTopic() {
+		encoded, err := encode(t, reflect.ValueOf(value))
+		if err != nil {
+			return topic, err
+		}
+		copy(topic[:], encoded)
+		return topic, nil
+	}
+
+	encoded, err := encodeInPlace(t, reflect.ValueOf(value), true)
+	if err != nil {
+		return topic, err
+	}
+
+	return utils.Keccak256(encoded), nil
+}
+
+// encodeInPlace encodes the hashed indexed fields: the strings and the bytes without
+// their length, padded to a word unless at the top, the arrays and the tuples as the
+// concatenation of their elements, without offsets or lengths
+func encodeInPlace(t Type, value reflect.Value, top bool) ([]byte, error) {
+
+	value = indirect(value)
+	if !value.IsValid() {
+		return nil, fmt.Errorf("Missing value of type %s", t)
+	}
+
+	switch t.Kind {
+	case StringKind, BytesKind:
+		var data []byte
+		if t.Kind == StringKind && value.Kind() == reflect.String {
+			data = []byte(value.String())
+		} else {
+			var err error
+			if data, err = bytesOf(value); err != nil {
+				return nil, err
+			}
+		}
+		if top || len(data) == 0 {
+			return data, nil
+		}
+		return rightPad(data), nil
+
+	case SliceKind, ArrayKind:
+		if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+			return nil, fmt.Errorf("Expected a slice for %s, got %s", t, value.Type())
+		}
+		if t.Kind == ArrayKind && value.Len() != t.Size {
+			return nil, fmt.Errorf("%d elements given for a %s", value.Len(), t)
+		}
+		var encoded []byte
+		for index := 0; index < value.Len(); index++ {
+			element, err := encodeInPlace(*t.Elem, value.Index(index), false)
+			if err != nil {
+				return nil, fmt.Errorf("[%d]: %v", index, err)
+			}
+			encoded = append(encoded, element...)
+		}
+		return encoded, nil
+
+	case TupleKind:
+		fields, err := tupleFields(t, value)
+		if err != nil {
+			return nil, err
+		}
+		names := Arguments(t.Components).names()
+		var encoded []byte
+		for index, component := range t.Components {
+			field, err := encodeInPlace(component.Type, fields[index], false)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %v", names[index], err)
+			}
+			encoded = append(encoded, field...)
+		}
+		return encoded, nil
+	}
+
+	return encode(t, value)
+}
@@ -0,0 +1,660 @@
+/********************************************************************************
+   This file is part of go-web3.
+   go-web3 is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-web3 is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-web3.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file human.go
+ */
+
+package abi
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// ParseHumanReadable - Parses the human-readable ABI, Solidity declarations such as
+//
+//	struct Fee { uint16 rate; address recipient; }
+//	constructor(address owner)
+//	function transfer(address to, uint256 amount) returns (bool)
+//	function balanceOf(address) view returns (uint256)
+//	function place(tuple(address maker, Fee fee)[] orders) payable
+//	event Transfer(address indexed from, address indexed to, uint256 value)
+//	error InsufficientBalance(uint256 available, uint256 required)
+//	receive() external payable
+//
+// given one by one or several in a string, optionally separated by semicolons.
+// The structs can be declared anywhere, they name the tuples they are used for.
+func ParseHumanReadable(declarations ...string) (*ABI, error) {
+
+	parser := &humanParser{structs: make(map[string][]humanParam)}
+
+	var entries []humanEntry
+
+	for _, declaration := range declarations {
+
+		tokens, err := tokenize(declaration)
+		if err != nil {
+			return nil, err
+		}
+		parser.tokens, parser.position = tokens, 0
+
+		for !parser.done() {
+			if parser.accept(";") {
+				continue
+			}
+			entry, err := parser.declaration()
+			if err != nil {
+				return nil, fmt.Errorf("Invalid declaration %q: %v", strings.TrimSpace(declaration), err)
+			}
+			if entry != nil {
+				entries = append(entries, *entry)
+			}
+		}
+	}
+
+	definition := new(ABI)
+
+	for _, entry := range entries {
+
+		inputs, err := parser.arguments(entry.inputs, nil)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid inputs of %s %s: %v", entry.kind, entry.name, err)
+		}
+
+		outputs, err := parser.arguments(entry.outputs, nil)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid outputs of %s %s: %v", entry.kind, entry.name, err)
+		}
+
+		switch entry.kind {
+		case FunctionType, ConstructorType, FallbackType, ReceiveType:
+			method := &Method{Name: entry.name, Type: entry.kind, Inputs: inputs, Outputs: outputs, StateMutability: entry.mutability}
+			if entry.kind != FunctionType {
+				method.Name, method.Outputs = entry.kind, nil
+			}
+			err = definition.AddMethod(method)
+		case "event":
+			err = definition.AddEvent(&Event{Name: entry.name, Anonymous: entry.anonymous, Inputs: inputs})
+		case "error":
+			err = definition.AddError(&Error{Name: entry.name, Inputs: inputs})
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return definition, nil
+}
+
+// humanEntry - a declaration of the human-readable ABI, its types not resolved yet
+type humanEntry struct {
+	kind       string
+	name       string
+	inputs     []humanParam
+	outputs    []humanParam
+	mutability string
+	anonymous  bool
+}
+
+// humanParam - a parameter, or a field of a struct: the base type is a type of
+// the ABI, a struct name or an inline tuple with its components
+type humanParam struct {
+	base       string
+	components []humanParam
+	arrays     string
+	name       string
+	indexed    bool
+}
+
+// declarationKeywords - the words starting the declarations
+var declarationKeywords = map[string]bool{
+	FunctionType: true, ConstructorType: true, FallbackType: true, ReceiveType: true,
+	"event": true, "error": true, "struct": true,
+}
+
+type humanParser struct {
+	tokens   []string
+	position int
+	structs  map[string][]humanParam
+}
+
+// tokenize splits text into the words and the punctuation of the declarations
+func tokenize(text string) ([]string, error) {
+
+	var tokens []string
+
+	for index := 0; index < len(text); {
+
+		char := rune(text[index])
+
+		switch {
+		case unicode.IsSpace(char):
+			index++
+
+		case strings.ContainsRune("(),[]{};", char):
+			tokens = append(tokens, string(char))
+			index++
+
+		case char == '_' || char == '$' || unicode.IsLetter(char) || unicode.IsDigit(char):
+			start := index
+			for index < len(text) && (text[index] == '_' || text[index] == '$' || unicode.IsLetter(rune(text[index])) || unicode.IsDigit(rune(text[index]))) {
+				index++
+			}
+			tokens = append(tokens, text[start:index])
+
+		default:
+			return nil, fmt.Errorf("Unexpected character %q in %q", char, text)
+		}
+	}
+
+	return tokens, nil
+}
+
+func (parser *humanParser) done() bool {
+	return parser.position >= len(parser.tokens)
+}
+
+func (parser *humanParser) peek() string {
+	if parser.done() {
+		return ""
+	}
+	return parser.tokens[parser.position]
+}
+
+func (parser *humanParser) next() string {
+	token := parser.peek()
+	parser.position++
+	return token
+}
+
+// accept consumes the next token when it is token
+func (parser *humanParser) accept(token string) bool {
+	if parser.peek() == token && !parser.done() {
+		parser.position++
+		return true
+	}
+	return false
+}
+
+func (parser *humanParser) expect(token string) error {
+	if !parser.accept(token) {
+		return fmt.Errorf("Expected %q, got %q", token, parser.peek())
+	}
+	return nil
+}
+
+// identifier consumes a name
+func (parser *humanParser) identifier() (string, error) {
+
+	token := parser.peek()
+	if token == "" || strings.ContainsAny(token, "(),[]{};") || unicode.IsDigit(rune(token[0])) {
+		return "", fmt.Errorf("Expected a name, got %q", token)
+	}
+
+	return parser.next(), nil
+}
+
+// declaration parses a function, a constructor, a fallback, a receive, an event,
+// an error or a struct, nil for the structs
+func (parser *humanParser) declaration() (*humanEntry, error) {
+
+	keyword := parser.next()
+	entry := &humanEntry{kind: keyword, mutability: NonPayable}
+
+	var err error
+
+	switch keyword {
+	case "struct":
+		return nil, parser.structDeclaration()
+
+	case FunctionType, "event", "error":
+		if entry.name, err = parser.identifier(); err != nil {
+			return nil, err
+		}
+
+	case ConstructorType, FallbackType:
+
+	case ReceiveType:
+		entry.mutability = Payable
+
+	default:
+		return nil, fmt.Errorf("Expected function, constructor, fallback, receive, event, error or struct, got %q", keyword)
+	}
+
+	if entry.inputs, err = parser.params(keyword == "event"); err != nil {
+		return nil, err
+	}
+
+	// the modifiers and the outputs of the functions, up to the next declaration
+	for !parser.done() && parser.peek() != ";" && !declarationKeywords[parser.peek()] {
+
+		modifier := parser.next()
+
+		switch modifier {
+		case "external", "public", "virtual", "override":
+		case View, Pure, Payable, NonPayable:
+			entry.mutability = modifier
+		case "constant":
+			entry.mutability = View
+		case "anonymous":
+			if keyword != "event" {
+				return nil, fmt.Errorf("Unexpected %q", modifier)
+			}
+			entry.anonymous = true
+		case "returns":
+			if keyword != FunctionType {
+				return nil, fmt.Errorf("Unexpected %q", modifier)
+			}
+			if entry.outputs, err = parser.params(false); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("Unexpected %q", modifier)
+		}
+	}
+
+	return entry, nil
+}
+
+// structDeclaration parses struct Name { type name; ... }
+func (parser *humanParser) structDeclaration() error {
+
+	name, err := parser.identifier()
+	if err != nil {
+		return err
+	}
+
+	if _, ok := parser.structs[name]; ok {
+		return fmt.Errorf("Duplicate struct %s", name)
+	}
+
+	if err := parser.expect("{"); err != nil {
+		return err
+	}
+
+	var fields []humanParam
+
+	for !parser.accept("}") {
+		if parser.done() {
+			return fmt.Errorf("Unclosed struct %s", name)
+		}
+		field, err := parser.param(false)
+		if err != nil {
+			return err
+		}
+		if field.name == "" {
+			return fmt.Errorf("Field of the struct %s without a name", name)
+		}
+		fields = append(fields, field)
+		if err := parser.expect(";"); err != nil {
+			return err
+		}
+	}
+
+	if len(fields) == 0 {
+		return fmt.Errorf("Struct %s without fields", name)
+	}
+
+	parser.structs[name] = fields
+
+	return nil
+}
+
+// params parses a parenthesized list of parameters
+func (parser *humanParser) params(event bool) ([]humanParam, error) {
+
+	if err := parser.expect("("); err != nil {
+		return nil, err
+	}
+
+	var params []humanParam
+
+	if parser.accept(")") {
+		return params, nil
+	}
+
+	for {
+		param, err := parser.param(event)
+		if err != nil {
+			return nil, err
+		}
+		params = append(params, param)
+
+		if parser.accept(")") {
+			return params, nil
+		}
+		if err := parser.expect(","); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// param parses a type, followed by indexed for the fields of the events, a
+// data location and a name, all optional
+func (parser *humanParser) param(event bool) (humanParam, error) {
+
+	var param humanParam
+	var err error
+
+	switch {
+	case parser.peek() == "(":
+		param.base = "tuple"
+		param.components, err = parser.params(false)
+	case parser.accept("tuple"):
+		param.base = "tuple"
+		param.components, err = parser.params(false)
+	default:
+		param.base, err = parser.identifier()
+		// address payable is an address
+		if param.base == "address" {
+			parser.accept(Payable)
+		}
+	}
+
+	if err != nil {
+		return param, err
+	}
+
+	for parser.accept("[") {
+		length := ""
+		if parser.peek() != "]" {
+			length = parser.next()
+		}
+		if err := parser.expect("]"); err != nil {
+			return param, err
+		}
+		param.arrays += "[" + length + "]"
+	}
+
+	if event && parser.accept("indexed") {
+		param.indexed = true
+	}
+
+	switch parser.peek() {
+	case "memory", "calldata", "storage":
+		parser.next()
+	}
+
+	if token := parser.peek(); token != "," && token != ")" && token != ";" && token != "" {
+		if param.name, err = parser.identifier(); err != nil {
+			return param, err
+		}
+	}
+
+	return param, nil
+}
+
+// arguments resolves the types of params, the struct names to their tuples.
+// resolving holds the structs being resolved, which can't contain themselves.
+func (parser *humanParser) arguments(params []humanParam, resolving map[string]bool) (Arguments, error) {
+
+	arguments := make(Arguments, len(params))
+
+	for index, param := range params {
+
+		var components Arguments
+		var err error
+
+		base := param.base
+		structName := ""
+
+		if fields, ok := parser.structs[base]; ok {
+			if resolving[base] {
+				return nil, fmt.Errorf("Recursive struct %s", base)
+			}
+			inner := map[string]bool{base: true}
+			for name := range resolving {
+				inner[name] = true
+			}
+			if components, err = parser.arguments(fields, inner); err != nil {
+				return nil, err
+			}
+			base, structName = "tuple", base
+		} else if base == "tuple" {
+			if components, err = parser.arguments(param.components, resolving); err != nil {
+				return nil, err
+			}
+		}
+
+		argumentType, err := NewType(base+param.arrays, components...)
+		if err != nil {
+			return nil, err
+		}
+
+		if structName != "" {
+			setTupleName(&argumentType, "struct "+structName)
+		}
+
+		arguments[index] = Argument{Name: param.name, Type: argumentType, Indexed: param.indexed}
+	}
+
+	return arguments, nil
+}
+
+// HumanReadable - The declarations of the human-readable ABI: the structs naming
+// tuples, the constructor, the functions, the events, the errors, the fallback
+// and the receive functions. The tuples without a name, or whose name is used by
+// different tuples, are written inline.
+func (definition *ABI) HumanReadable() []string {
+
+	structs := definition.structs()
+
+	names := make([]string, 0, len(structs))
+	for name, components := range structs {
+		if components != nil {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var declarations []string
+
+	for _, name := range names {
+		fields := make([]string, len(structs[name]))
+		for index, field := range structs[name] {
+			fields[index] = humanType(field.Type, structs) + " " + field.Name + ";"
+		}
+		declarations = append(declarations, "struct "+name+" { "+strings.Join(fields, " ")+" }")
+	}
+
+	if definition.Constructor != nil {
+		declarations = append(declarations, humanMethod(definition.Constructor, structs))
+	}
+
+	for _, method := range definition.Methods {
+		declarations = append(declarations, humanMethod(method, structs))
+	}
+
+	for _, event := range definition.Events {
+		declaration := "event " + event.Name + "(" + humanArguments(event.Inputs, structs) + ")"
+		if event.Anonymous {
+			declaration += " anonymous"
+		}
+		declarations = append(declarations, declaration)
+	}
+
+	for _, contractError := range definition.Errors {
+		declarations = append(declarations, "error "+contractError.Name+"("+humanArguments(contractError.Inputs, structs)+")")
+	}
+
+	if definition.Fallback != nil {
+		declarations = append(declarations, humanMethod(definition.Fallback, structs))
+	}
+
+	if definition.Receive != nil {
+		declarations = append(declarations, humanMethod(definition.Receive, structs))
+	}
+
+	return declarations
+}
+
+func humanMethod(method *Method, structs map[string][]Argument) string {
+
+	var declaration string
+
+	switch method.Type {
+	case FunctionType:
+		declaration = "function " + method.Name + "(" + humanArguments(method.Inputs, structs) + ")"
+	case ReceiveType:
+		return "receive() external payable"
+	default:
+		declaration = method.Type + "(" + humanArguments(method.Inputs, structs) + ")"
+	}
+
+	if method.StateMutability != NonPayable && method.StateMutability != "" {
+		declaration += " " + method.StateMutability
+	}
+
+	if len(method.Outputs) > 0 {
+		declaration += " returns (" + humanArguments(method.Outputs, structs) + ")"
+	}
+
+	return declaration
+}
+
+func humanArguments(arguments Arguments, structs map[string][]Argument) string {
+
+	written := make([]string, len(arguments))
+
+	for index, argument := range arguments {
+		written[index] = humanType(argument.Type, structs)
+		if argument.Indexed {
+			written[index] += " indexed"
+		}
+		if argument.Name != "" {
+			written[index] += " " + argument.Name
+		}
+	}
+
+	return strings.Join(written, ", ")
+}
+
+// humanType - the type as written in the declarations, the tuples by the name
+// of their struct when it is declared
+func humanType(t Type, structs map[string][]Argument) string {
+
+	switch t.Kind {
+	case SliceKind:
+		return humanType(*t.Elem, structs) + "[]"
+	case ArrayKind:
+		return fmt.Sprintf("%s[%d]", humanType(*t.Elem, structs), t.Size)
+	case TupleKind:
+		if t.TupleName != "" && structs[t.TupleName] != nil {
+			return t.TupleName
+		}
+		return "tuple(" + humanArguments(t.Components, structs) + ")"
+	}
+
+	return t.String()
+}
+
+// structs - the components of the named tuples of the ABI by their name, nil
+// for the names given to tuples of different components
+func (definition *ABI) structs() map[string][]Argument {
+
+	structs := make(map[string][]Argument)
+	conflicts := make(map[string]bool)
+
+	var collect func(t Type)
+	collect = func(t Type) {
+		switch t.Kind {
+		case SliceKind, ArrayKind:
+			collect(*t.Elem)
+		case TupleKind:
+			for _, component := range t.Components {
+				collect(component.Type)
+			}
+			if t.TupleName == "" || conflicts[t.TupleName] {
+				return
+			}
+			if existing, ok := structs[t.TupleName]; ok && !sameComponents(existing, t.Components) {
+				conflicts[t.TupleName] = true
+				structs[t.TupleName] = nil
+				return
+			}
+			structs[t.TupleName] = t.Components
+		}
+	}
+
+	collectArguments := func(arguments Arguments) {
+		for _, argument := range arguments {
+			collect(argument.Type)
+		}
+	}
+
+	for _, method := range definition.allMethods() {
+		collectArguments(method.Inputs)
+		collectArguments(method.Outputs)
+	}
+	for _, event := range definition.Events {
+		collectArguments(event.Inputs)
+	}
+	for _, contractError := range definition.Errors {
+		collectArguments(contractError.Inputs)
+	}
+
+	// the fields of a struct need names
+	for name, components := range structs {
+		for _, component := range components {
+			if component.Name == "" {
+				structs[name] = nil
+				break
+			}
+		}
+	}
+
+	return structs
+}
+
+// sameComponents tells if two tuples have the same fields
+func sameComponents(first []Argument, second []Argument) bool {
+
+	if len(first) != len(second) {
+		return false
+	}
+
+	for index := range first {
+		if first[index].Name != second[index].Name || first[index].Type.String() != second[index].Type.String() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// allMethods - the constructor, the functions, the fallback and the receive functions
+func (definition *ABI) allMethods() []*Method {
+
+	var methods []*Method
+
+	if definition.Constructor != nil {
+		methods = append(methods, definition.Constructor)
+	}
+
+	methods = append(methods, definition.Methods...)
+
+	if definition.Fallback != nil {
+		methods = append(methods, definition.Fallback)
+	}
+
+	if definition.Receive != nil {
+		methods = append(methods, definition.Receive)
+	}
+
+	return methods
+}
@@ -0,0 +1,237 @@
+/********************************************************************************
+   This file is part of go-web3.
+   go-web3 is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-web3 is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-web3.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file type.go
+ */
+
+package abi
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Kind - The family of a Solidity type
+type Kind int
+
+const (
+	// IntKind - int8 to int256
+	IntKind Kind = iota
+	// UintKind - uint8 to uint256
+	UintKind
+	// BoolKind - bool
+	BoolKind
+	// AddressKind - address
+	AddressKind
+	// StringKind - string
+	StringKind
+	// BytesKind - bytes, of any length
+	BytesKind
+	// FixedBytesKind - bytes1 to bytes32
+	FixedBytesKind
+	// FunctionKind - function, an address followed by a selector
+	FunctionKind
+	// FixedKind - fixedMxN
+	FixedKind
+	// UfixedKind - ufixedMxN
+	UfixedKind
+	// SliceKind - T[], of any length
+	SliceKind
+	// ArrayKind - T[k]
+	ArrayKind
+	// TupleKind - (T1,T2,...), the structs of Solidity
+	TupleKind
+)
+
+// Type - A Solidity type, as named in the ABI
+type Type struct {
+	Kind Kind
+	// Size is the bits of the integers and of the fixed point numbers, the bytes
+	// of the fixed bytes and the length of the arrays
+	Size int
+	// Decimals is the N of fixedMxN and ufixedMxN
+	Decimals int
+	// Elem is the type of the elements of the slices and the arrays
+	Elem *Type
+	// Components are the fields of the tuples
+	Components []Argument
+	// TupleName is the name of the struct of a tuple, taken from the internalType
+	// of the JSON ABI such as "struct Market.Order", empty when unknown
+	TupleName string
+}
+
+// NewType - Parses a type of the ABI, such as uint256, bytes32[] or tuple[2].
+// The components are the fields of a tuple type, they are ignored otherwise.
+func NewType(name string, components ...Argument) (Type, error) {
+
+	name = strings.TrimSpace(name)
+
+	if strings.HasSuffix(name, "]") {
+
+		open := strings.LastIndex(name, "[")
+		if open < 0 {
+			return Type{}, fmt.Errorf("Invalid ABI type %q", name)
+		}
+
+		elem, err := NewType(name[:open], components...)
+		if err != nil {
+			return Type{}, err
+		}
+
+		length := name[open+1 : len(name)-1]
+		if length == "" {
+			return Type{Kind: SliceKind, Elem: &elem}, nil
+		}
+
+		size, err := strconv.Atoi(length)
+		if err != nil || size <= 0 {
+			return Type{}, fmt.Errorf("Invalid length of the ABI array type %q", name)
+		}
+
+		return Type{Kind: ArrayKind, Size: size, Elem: &elem}, nil
+	}
+
+	switch name {
+	case "bool":
+		return Type{Kind: BoolKind}, nil
+	case "address":
+		return Type{Kind: AddressKind, Size: 20}, nil
+	case "string":
+		return Type{Kind: StringKind}, nil
+	case "bytes":
+		return Type{Kind: BytesKind}, nil
+	case "function":
+		return Type{Kind: FunctionKind, Size: 24}, nil
+	case "tuple":
+		return Type{Kind: TupleKind, Components: components}, nil
+	// the aliases of the 256 bits integers and of the 128x18 fixed point numbers
+	case "int":
+		return Type{Kind: IntKind, Size: 256}, nil
+	case "uint":
+		return Type{Kind: UintKind, Size: 256}, nil
+	case "fixed":
+		return Type{Kind: FixedKind, Size: 128, Decimals: 18}, nil
+	case "ufixed":
+		return Type{Kind: UfixedKind, Size: 128, Decimals: 18}, nil
+	}
+
+	switch {
+	case strings.HasPrefix(name, "uint"):
+		return newIntegerType(name, UintKind, name[len("uint"):])
+	case strings.HasPrefix(name, "int"):
+		return newIntegerType(name, IntKind, name[len("int"):])
+	case strings.HasPrefix(name, "bytes"):
+		size, err := strconv.Atoi(name[len("bytes"):])
+		if err != nil || size < 1 || size > 32 {
+			return Type{}, fmt.Errorf("Invalid ABI type %q", name)
+		}
+		return Type{Kind: FixedBytesKind, Size: size}, nil
+	case strings.HasPrefix(name, "ufixed"):
+		return newFixedType(name, UfixedKind, name[len("ufixed"):])
+	case strings.HasPrefix(name, "fixed"):
+		return newFixedType(name, FixedKind, name[len("fixed"):])
+	}
+
+	return Type{}, fmt.Errorf("Invalid ABI type %q", name)
+}
+
+// newIntegerType parses the bits of an intN or uintN, a multiple of 8 up to 256
+func newIntegerType(name string, kind Kind, bits string) (Type, error) {
+
+	size, err := strconv.Atoi(bits)
+	if err != nil || size < 8 || size > 256 || size%8 != 0 {
+		return Type{}, fmt.Errorf("Invalid ABI type %q", name)
+	}
+
+	return Type{Kind: kind, Size: size}, nil
+}
+
+// newFixedType parses the MxN of a fixedMxN or ufixedMxN
+func newFixedType(name string, kind Kind, suffix string) (Type, error) {
+
+	parts := strings.Split(suffix, "x")
+	if len(parts) != 2 {
+		return Type{}, fmt.Errorf("Invalid ABI type %q", name)
+	}
+
+	size, err := strconv.Atoi(parts[0])
+	if err != nil || size < 8 || size > 256 || size%8 != 0 {
+		return Type{}, fmt.Errorf("Invalid ABI type %q", name)
+	}
+
+	decimals, err := strconv.Atoi(parts[1])
+	if err != nil || decimals < 1 || decimals > 80 {
+		return Type{}, fmt.Errorf("Invalid ABI type %q", name)
+	}
+
+	return Type{Kind: kind, Size: size, Decimals: decimals}, nil
+}
+
+// String - The canonical name of the type, as hashed in the signatures: uint
+// is uint256 and a tuple is the list of its component types, such as (address,uint256)[]
+func (t Type) String() string {
+
+	switch t.Kind {
+	case IntKind:
+		return "int" + strconv.Itoa(t.Size)
+	case UintKind:
+		return "uint" + strconv.Itoa(t.Size)
+	case BoolKind:
+		return "bool"
+	case AddressKind:
+		return "address"
+	case StringKind:
+		return "string"
+	case BytesKind:
+		return "bytes"
+	case FixedBytesKind:
+		return "bytes" + strconv.Itoa(t.Size)
+	case FunctionKind:
+		return "function"
+	case FixedKind:
+		return fmt.Sprintf("fixed%dx%d", t.Size, t.Decimals)
+	case UfixedKind:
+		return fmt.Sprintf("ufixed%dx%d", t.Size, t.Decimals)
+	case SliceKind:
+		return t.Elem.String() + "[]"
+	case ArrayKind:
+		return t.Elem.String() + "[" + strconv.Itoa(t.Size) + "]"
+	case TupleKind:
+		return "(" + Arguments(t.Components).typeList() + ")"
+	}
+
+	return "unknown"
+}
+
+// IsDynamic - Tells if the encoding of the type has no fixed size, it is then
+// placed after the static part of its tuple and pointed to by an offset
+func (t Type) IsDynamic() bool {
+
+	switch t.Kind {
+	case StringKind, BytesKind, SliceKind:
+		return true
+	case ArrayKind:
+		return t.Elem.IsDynamic()
+	case TupleKind:
+		for _, component := range t.Components {
+			if component.Type.IsDynamic() {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,107 @@
+/********************************************************************************
+   This file is part of go-web3.
+   go-web3 is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-web3 is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-web3.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file main.go
+ */
+
+// Command abigen generates the typed Go binding of a contract from its JSON ABI,
+// or from the artifact of Truffle or Hardhat holding the ABI and the bytecode.
+//
+//	abigen -abi build/contracts/SimpleToken.json -pkg token -out token/token.go
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/cellcycle/go-web3/abi/bind"
+)
+
+func main() {
+
+	abiPath := flag.String("abi", "", "the JSON ABI, or the Truffle or Hardhat artifact, of the contract (required)")
+	binPath := flag.String("bin", "", "the file holding the bytecode, overriding the one of the artifact")
+	pkg := flag.String("pkg", "", "the name of the generated package (required)")
+	typeName := flag.String("type", "", "the Go type of the contract, the name of the artifact by default")
+	out := flag.String("out", "", "the generated file, the standard output by default")
+	flag.Parse()
+
+	if *abiPath == "" || *pkg == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err := run(*abiPath, *binPath, *pkg, *typeName, *out); err != nil {
+		fmt.Fprintln(os.Stderr, "abigen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(abiPath string, binPath string, pkg string, typeName string, out string) error {
+
+	content, err := ioutil.ReadFile(abiPath)
+	if err != nil {
+		return err
+	}
+
+	artifact, err := bind.ReadArtifact(content)
+	if err != nil {
+		return err
+	}
+
+	if binPath != "" {
+		bytecode, err := ioutil.ReadFile(binPath)
+		if err != nil {
+			return err
+		}
+		artifact.Bytecode = strings.TrimSpace(string(bytecode))
+		if !strings.HasPrefix(artifact.Bytecode, "0x") {
+			artifact.Bytecode = "0x" + artifact.Bytecode
+		}
+	}
+
+	if typeName == "" {
+		typeName = artifact.Name
+	}
+	if typeName == "" {
+		typeName = strings.TrimSuffix(filepath.Base(abiPath), filepath.Ext(abiPath))
+	}
+	if typeName != "" {
+		typeName = string(unicode.ToUpper(rune(typeName[0]))) + typeName[1:]
+	}
+
+	code, err := bind.Generate(bind.Options{
+		Package:  pkg,
+		Type:     typeName,
+		ABI:      artifact.ABI,
+		Bytecode: artifact.Bytecode,
+		Source:   filepath.Base(abiPath),
+	})
+	if err != nil {
+		return err
+	}
+
+	if out == "" {
+		_, err = os.Stdout.Write(code)
+		return err
+	}
+
+	return ioutil.WriteFile(out, code, 0644)
+}
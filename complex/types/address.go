@@ -0,0 +1,204 @@
+/********************************************************************************
+   This file is part of go-web3.
+   go-web3 is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-web3 is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-web3.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file address.go
+ */
+
+package types
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/cellcycle/go-web3/internal/keccak"
+)
+
+// AddressLength - The bytes of an address
+const AddressLength = 20
+
+// Address - The address of an account or a contract. Addresses are comparable with ==,
+// written as their EIP-55 checksum and read from hex strings with ParseAddress.
+type Address [AddressLength]byte
+
+// ParseAddress - Parses a 0x prefixed address of 40 hex digits. The mixed case
+// addresses must match their EIP-55 checksum, the lower and upper case ones are
+// accepted as they are.
+func ParseAddress(text string) (Address, error) {
+
+	var address Address
+
+	if err := parseHex(address[:], text, "address"); err != nil {
+		return Address{}, err
+	}
+
+	digits := text[2:]
+	if digits != strings.ToLower(digits) && digits != strings.ToUpper(digits) && address.Hex() != "0x"+digits {
+		return Address{}, fmt.Errorf("Invalid address %q: wrong EIP-55 checksum", text)
+	}
+
+	return address, nil
+}
+
+// MustParseAddress - Same as ParseAddress, panics on invalid addresses. It is meant
+// for the constant addresses of a program.
+func MustParseAddress(text string) Address {
+
+	address, err := ParseAddress(text)
+	if err != nil {
+		panic(err)
+	}
+
+	return address
+}
+
+// ParseAddresses - Parses a list of addresses with ParseAddress
+func ParseAddresses(texts []string) ([]Address, error) {
+
+	addresses := make([]Address, len(texts))
+	for index, text := range texts {
+		address, err := ParseAddress(text)
+		if err != nil {
+			return nil, err
+		}
+		addresses[index] = address
+	}
+
+	return addresses, nil
+}
+
+// IsChecksumAddress - Tells if text is an address written as its EIP-55 checksum
+func IsChecksumAddress(text string) bool {
+
+	address, err := ParseAddress(text)
+
+	return err == nil && address.Hex() == text
+}
+
+// Hex - The address written as its EIP-55 checksum: the letters of the hex digits
+// are upper case when the matching digit of the Keccak-256 hash of the lower case
+// address is 8 or more
+func (address Address) Hex() string {
+
+	digits := []byte(hex.EncodeToString(address[:]))
+	hash := keccak.Sum256(digits)
+
+	for index, digit := range digits {
+		nibble := hash[index/2] >> 4
+		if index%2 == 1 {
+			nibble = hash[index/2] & 0x0f
+		}
+		if digit >= 'a' && nibble >= 8 {
+			digits[index] = digit - 'a' + 'A'
+		}
+	}
+
+	return "0x" + string(digits)
+}
+
+// String - Same as Hex
+func (address Address) String() string {
+	return address.Hex()
+}
+
+// Bytes - The 20 bytes of the address
+func (address Address) Bytes() []byte {
+	return address[:]
+}
+
+// IsZero - Tells if the address is 0x0000000000000000000000000000000000000000
+func (address Address) IsZero() bool {
+	return address == Address{}
+}
+
+// Cmp - Compares the bytes of the addresses: -1, 0 or +1 when address is lower,
+// equal or greater than other
+func (address Address) Cmp(other Address) int {
+	return bytes.Compare(address[:], other[:])
+}
+
+// MarshalText - Writes the address as its EIP-55 checksum, in JSON too
+func (address Address) MarshalText() ([]byte, error) {
+	return []byte(address.Hex()), nil
+}
+
+// UnmarshalText - Reads the address with ParseAddress, in JSON too
+func (address *Address) UnmarshalText(text []byte) error {
+
+	parsed, err := ParseAddress(string(text))
+	if err != nil {
+		return err
+	}
+
+	*address = parsed
+
+	return nil
+}
+
+// Scan - Reads the address from a database column holding its 20 bytes or its
+// hex text, a NULL is the zero address
+func (address *Address) Scan(src interface{}) error {
+	return scanBytes(address[:], src, "address", func(text string) error {
+		return address.UnmarshalText([]byte(text))
+	})
+}
+
+// Value - Writes the address to a database column as its 20 bytes
+func (address Address) Value() (driver.Value, error) {
+	return address[:], nil
+}
+
+// parseHex decodes text, 0x followed by the hex digits of exactly len(target) bytes, into target
+func parseHex(target []byte, text string, what string) error {
+
+	if !strings.HasPrefix(text, "0x") && !strings.HasPrefix(text, "0X") {
+		return fmt.Errorf("Invalid %s %q: expected a 0x prefix", what, text)
+	}
+
+	if len(text)-2 != 2*len(target) {
+		return fmt.Errorf("Invalid %s %q: expected %d hex digits, got %d", what, text, 2*len(target), len(text)-2)
+	}
+
+	if _, err := hex.Decode(target, []byte(text[2:])); err != nil {
+		return fmt.Errorf("Invalid %s %q: %v", what, text, err)
+	}
+
+	return nil
+}
+
+// scanBytes reads the column src into target: its raw bytes when it holds len(target)
+// bytes, its text with parse otherwise
+func scanBytes(target []byte, src interface{}, what string, parse func(text string) error) error {
+
+	switch value := src.(type) {
+	case nil:
+		for index := range target {
+			target[index] = 0
+		}
+		return nil
+	case []byte:
+		if len(value) == len(target) {
+			copy(target, value)
+			return nil
+		}
+		return parse(string(value))
+	case string:
+		return parse(value)
+	}
+
+	return fmt.Errorf("Can't scan %T into the %s", src, what)
+}
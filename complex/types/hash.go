@@ -0,0 +1,113 @@
+/********************************************************************************
+   This file is part of go-web3.
+   go-web3 is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-web3 is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-web3.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file hash.go
+ */
+
+package types
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/hex"
+)
+
+// HashLength - The bytes of a hash
+const HashLength = 32
+
+// Hash - The Keccak-256 hash of a block, a transaction or a topic. Hashes are
+// comparable with ==, written in lower case hex and read with ParseHash.
+type Hash [HashLength]byte
+
+// ParseHash - Parses a 0x prefixed hash of 64 hex digits
+func ParseHash(text string) (Hash, error) {
+
+	var hash Hash
+
+	if err := parseHex(hash[:], text, "hash"); err != nil {
+		return Hash{}, err
+	}
+
+	return hash, nil
+}
+
+// MustParseHash - Same as ParseHash, panics on invalid hashes. It is meant for the
+// constant hashes of a program.
+func MustParseHash(text string) Hash {
+
+	hash, err := ParseHash(text)
+	if err != nil {
+		panic(err)
+	}
+
+	return hash
+}
+
+// Hex - The hash as 0x followed by its lower case hex digits
+func (hash Hash) Hex() string {
+	return "0x" + hex.EncodeToString(hash[:])
+}
+
+// String - Same as Hex
+func (hash Hash) String() string {
+	return hash.Hex()
+}
+
+// Bytes - The 32 bytes of the hash
+func (hash Hash) Bytes() []byte {
+	return hash[:]
+}
+
+// IsZero - Tells if all the bytes of the hash are 0
+func (hash Hash) IsZero() bool {
+	return hash == Hash{}
+}
+
+// Cmp - Compares the bytes of the hashes: -1, 0 or +1 when hash is lower, equal
+// or greater than other
+func (hash Hash) Cmp(other Hash) int {
+	return bytes.Compare(hash[:], other[:])
+}
+
+// MarshalText - Writes the hash with Hex, in JSON too
+func (hash Hash) MarshalText() ([]byte, error) {
+	return []byte(hash.Hex()), nil
+}
+
+// UnmarshalText - Reads the hash with ParseHash, in JSON too
+func (hash *Hash) UnmarshalText(text []byte) error {
+
+	parsed, err := ParseHash(string(text))
+	if err != nil {
+		return err
+	}
+
+	*hash = parsed
+
+	return nil
+}
+
+// Scan - Reads the hash from a database column holding its 32 bytes or its hex
+// text, a NULL is the zero hash
+func (hash *Hash) Scan(src interface{}) error {
+	return scanBytes(hash[:], src, "hash", func(text string) error {
+		return hash.UnmarshalText([]byte(text))
+	})
+}
+
+// Value - Writes the hash to a database column as its 32 bytes
+func (hash Hash) Value() (driver.Value, error) {
+	return hash[:], nil
+}
@@ -30,4 +30,20 @@ var (
 	UNPARSEABLEINTERFACE = errors.New("Unparseable Interface")
 	// WEBSOCKETNOTDENIFIED - Websocket connection dont exist
 	WEBSOCKETNOTDENIFIED = errors.New("Websocket connection dont exist")
+	// PROVIDERCLOSED - the provider was closed before the response arrived
+	PROVIDERCLOSED = errors.New("Provider closed")
+	// SUBSCRIPTIONSUNSUPPORTED - the provider can't receive notifications
+	SUBSCRIPTIONSUNSUPPORTED = errors.New("Provider does not support subscriptions")
+	// SUBSCRIPTIONOVERFLOW - the consumer was too slow to read the notifications
+	SUBSCRIPTIONOVERFLOW = errors.New("Subscription queue overflow")
+	// NONCETOOLOW - the node already has a transaction with that nonce
+	NONCETOOLOW = errors.New("Nonce too low")
+	// INSUFFICIENTFUNDS - the sender can't pay the value plus gas * price
+	INSUFFICIENTFUNDS = errors.New("Insufficient funds for gas * price + value")
+	// EXECUTIONREVERTED - the EVM execution was reverted
+	EXECUTIONREVERTED = errors.New("Execution reverted")
+	// METHODNOTFOUND - the node does not serve the method
+	METHODNOTFOUND = errors.New("Method not found")
+	// RATELIMITED - the node or its gateway throttled the request
+	RATELIMITED = errors.New("Rate limited")
 )
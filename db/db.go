@@ -22,6 +22,7 @@
 package db
 
 import (
+	"context"
 	"github.com/cellcycle/go-web3/dto"
 	"github.com/cellcycle/go-web3/providers"
 )
@@ -42,12 +43,18 @@ func NewDB(provider providers.ProviderInterface) *DB {
 // Note this function is deprecated and will be removed in the future.
 // Reference: https://github.com/ethereum/wiki/wiki/JSON-RPC#db_putstring
 // Parameters:
-//    - String - Database name.
-//	  - String - Key name.
-//    - String - String to store.
+//   - String - Database name.
+//   - String - Key name.
+//   - String - String to store.
+//
 // Returns:
-//	  - Boolean - returns true if the value was stored, otherwise false.
+//   - Boolean - returns true if the value was stored, otherwise false.
 func (db *DB) PutString(databaseName string, keyName string, stringToStore string) (bool, error) {
+	return db.PutStringCtx(context.Background(), databaseName, keyName, stringToStore)
+}
+
+// PutStringCtx - Same as PutString, using ctx to cancel the request or set its deadline.
+func (db *DB) PutStringCtx(ctx context.Context, databaseName string, keyName string, stringToStore string) (bool, error) {
 
 	params := make([]string, 3)
 
@@ -57,7 +64,7 @@ func (db *DB) PutString(databaseName string, keyName string, stringToStore strin
 
 	pointer := &dto.RequestResult{}
 
-	err := db.provider.SendRequest(pointer, "db_putString", params)
+	err := db.provider.SendRequestCtx(ctx, pointer, "db_putString", params)
 
 	if err != nil {
 		return false, err
@@ -0,0 +1,181 @@
+/********************************************************************************
+   This file is part of go-web3.
+   go-web3 is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-web3 is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-web3.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file dial.go
+ */
+
+package web3
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/cellcycle/go-web3/providers"
+)
+
+// DialOption - An option of Dial
+type DialOption func(*dialConfig)
+
+type dialConfig struct {
+	header    http.Header
+	tlsConfig *tls.Config
+	timeout   time.Duration
+	auth      providers.Authenticator
+	proxy     func(*http.Request) (*url.URL, error)
+	reconnect *providers.ReconnectPolicy
+}
+
+// WithHeader - Sends the header with every HTTP request, or with the websocket handshake
+func WithHeader(key, value string) DialOption {
+	return func(config *dialConfig) {
+		config.header.Set(key, value)
+	}
+}
+
+// WithBasicAuth - Authenticates with the basic scheme, as a user:password@ in the url does
+func WithBasicAuth(username, password string) DialOption {
+	return func(config *dialConfig) {
+		providers.BasicAuth(username, password).Authenticate(config.header)
+	}
+}
+
+// WithBearerToken - Authenticates with the bearer scheme, as a token@ in the url does
+func WithBearerToken(token string) DialOption {
+	return func(config *dialConfig) {
+		providers.BearerToken(token).Authenticate(config.header)
+	}
+}
+
+// WithAuth - Authenticates every HTTP request, or every websocket handshake, with auth,
+// such as a providers.JWTAuth for the authrpc port of the node
+func WithAuth(auth providers.Authenticator) DialOption {
+	return func(config *dialConfig) {
+		config.auth = auth
+	}
+}
+
+// WithTLSConfig - The TLS config of the https:// and wss:// urls
+func WithTLSConfig(tlsConfig *tls.Config) DialOption {
+	return func(config *dialConfig) {
+		config.tlsConfig = tlsConfig
+	}
+}
+
+// WithTimeout - Bounds each HTTP request, or the websocket handshake, or the connection to the IPC socket
+func WithTimeout(timeout time.Duration) DialOption {
+	return func(config *dialConfig) {
+		config.timeout = timeout
+	}
+}
+
+// WithProxy - Sends the requests through the HTTP proxy returned by proxy, such as
+// http.ProxyFromEnvironment or http.ProxyURL(proxyURL)
+func WithProxy(proxy func(*http.Request) (*url.URL, error)) DialOption {
+	return func(config *dialConfig) {
+		config.proxy = proxy
+	}
+}
+
+// WithReconnect - Restores the websocket or IPC connection when it is lost, with policy
+func WithReconnect(policy providers.ReconnectPolicy) DialOption {
+	return func(config *dialConfig) {
+		config.reconnect = &policy
+	}
+}
+
+// Dial - Connects to the node at rawurl, the transport is chosen from the url:
+//   - http:// and https:// post each request
+//   - ws:// and wss:// share a websocket connection, dialed on the first call
+//   - a path, such as /home/user/.ethereum/geth.ipc, shares a unix socket connection
+//
+// The user info of the url authenticates the requests: user:password@ with the basic
+// scheme and token@ with the bearer scheme. The options given override it.
+func Dial(rawurl string, options ...DialOption) (*Web3, error) {
+
+	provider, err := DialProvider(rawurl, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewWeb3(provider), nil
+}
+
+// DialProvider - Same as Dial, returning the provider to wrap it with middlewares
+func DialProvider(rawurl string, options ...DialOption) (providers.ProviderInterface, error) {
+
+	location, err := url.Parse(rawurl)
+	if err != nil {
+		return nil, err
+	}
+
+	config := &dialConfig{header: make(http.Header)}
+
+	if location.User != nil {
+		username := location.User.Username()
+		if password, ok := location.User.Password(); ok {
+			WithBasicAuth(username, password)(config)
+		} else {
+			WithBearerToken(username)(config)
+		}
+		// the credentials travel in the header, not in the address
+		location.User = nil
+	}
+
+	for _, option := range options {
+		option(config)
+	}
+
+	switch strings.ToLower(location.Scheme) {
+	case "http", "https":
+		provider := providers.NewHTTPProviderURLWithClient(location.String(), config.httpClient())
+		return provider.WithAuth(providers.MultiAuth(providers.HeaderAuth(config.header), config.auth)), nil
+
+	case "ws", "wss":
+		return providers.NewWebSocketProviderWithOptions(location.String(), providers.WebSocketOptions{
+			Header:      config.header,
+			Auth:        config.auth,
+			TLSConfig:   config.tlsConfig,
+			Proxy:       config.proxy,
+			DialTimeout: config.timeout,
+			Reconnect:   config.reconnect,
+		}), nil
+
+	case "":
+		if len(config.header) > 0 || config.auth != nil || config.tlsConfig != nil || config.proxy != nil {
+			return nil, fmt.Errorf("Headers, authentication, TLS and proxy options don't apply to the IPC endpoint %s", rawurl)
+		}
+		return providers.NewIPCProviderWithOptions(rawurl, providers.IPCOptions{
+			DialTimeout: config.timeout,
+			Reconnect:   config.reconnect,
+		}), nil
+	}
+
+	return nil, fmt.Errorf("Unsupported scheme %q in %s", location.Scheme, location.Redacted())
+}
+
+func (config *dialConfig) httpClient() *http.Client {
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = config.proxy
+	if config.tlsConfig != nil {
+		transport.TLSClientConfig = config.tlsConfig
+	}
+
+	return &http.Client{Transport: transport, Timeout: config.timeout}
+}
@@ -26,6 +26,8 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+
+	"github.com/cellcycle/go-web3/complex/types"
 )
 
 type Block struct {
@@ -40,6 +42,26 @@ type Block struct {
 	Timestamp  *big.Int `json:"timestamp"`
 }
 
+// ParseHash - The hash of the block
+func (b *Block) ParseHash() (types.Hash, error) {
+	return types.ParseHash(b.Hash)
+}
+
+// ParseParentHash - The hash of the parent of the block
+func (b *Block) ParseParentHash() (types.Hash, error) {
+	return types.ParseHash(b.ParentHash)
+}
+
+// ParseMiner - The beneficiary of the rewards of the block, its author for Parity
+func (b *Block) ParseMiner() (types.Address, error) {
+
+	if b.Miner == "" {
+		return types.ParseAddress(b.Author)
+	}
+
+	return types.ParseAddress(b.Miner)
+}
+
 /**
  * How to un-marshal the block struct using the Big.Int rather than the
  * `complexReturn` type.
@@ -61,34 +83,34 @@ func (b *Block) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
-	num, success := big.NewInt(0).SetString(temp.Number[2:], 16)
+	num, err := blockQuantity(temp.Number)
 
-	if !success {
-		return errors.New(fmt.Sprintf("Error converting %s to bigInt", temp.Number))
+	if err != nil {
+		return err
 	}
 
-	size, success := big.NewInt(0).SetString(temp.Size[2:], 16)
+	size, err := blockQuantity(temp.Size)
 
-	if !success {
-		return errors.New(fmt.Sprintf("Error converting %s to bigInt", temp.Size))
+	if err != nil {
+		return err
 	}
 
-	gas, success := big.NewInt(0).SetString(temp.GasUsed[2:], 16)
+	gas, err := blockQuantity(temp.GasUsed)
 
-	if !success {
-		return errors.New(fmt.Sprintf("Error converting %s to bigInt", temp.GasUsed))
+	if err != nil {
+		return err
 	}
 
-	nonce, success := big.NewInt(0).SetString(temp.Nonce[2:], 16)
+	nonce, err := blockQuantity(temp.Nonce)
 
-	if !success {
-		return errors.New(fmt.Sprintf("Error converting %s to bigInt", temp.Nonce))
+	if err != nil {
+		return err
 	}
 
-	timestamp, success := big.NewInt(0).SetString(temp.Timestamp[2:], 16)
+	timestamp, err := blockQuantity(temp.Timestamp)
 
-	if !success {
-		return errors.New(fmt.Sprintf("Error converting %s to bigInt", temp.Timestamp))
+	if err != nil {
+		return err
 	}
 
 	b.Number = num
@@ -99,3 +121,24 @@ func (b *Block) UnmarshalJSON(data []byte) error {
 
 	return nil
 }
+
+// blockQuantity parses a QUANTITY field of a block, the fields a block header
+// does not carry (as the size in the newHeads notifications) are left nil
+func blockQuantity(value string) (*big.Int, error) {
+
+	if value == "" {
+		return nil, nil
+	}
+
+	if len(value) < 2 {
+		return nil, errors.New(fmt.Sprintf("Error converting %s to bigInt", value))
+	}
+
+	quantity, success := big.NewInt(0).SetString(value[2:], 16)
+
+	if !success {
+		return nil, errors.New(fmt.Sprintf("Error converting %s to bigInt", value))
+	}
+
+	return quantity, nil
+}
@@ -0,0 +1,74 @@
+/********************************************************************************
+   This file is part of go-web3.
+   go-web3 is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-web3 is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-web3.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file filter.go
+ */
+
+package dto
+
+import "encoding/json"
+
+// FilterParameters - The log filter used by eth_getLogs and the logs subscriptions.
+// Every position of Topics holds the accepted values of that topic, an empty
+// position matches any value.
+type FilterParameters struct {
+	FromBlock string
+	ToBlock   string
+	BlockHash string
+	Address   []string
+	Topics    [][]string
+}
+
+func (filter FilterParameters) MarshalJSON() ([]byte, error) {
+
+	request := make(map[string]interface{})
+
+	if filter.FromBlock != "" {
+		request["fromBlock"] = filter.FromBlock
+	}
+
+	if filter.ToBlock != "" {
+		request["toBlock"] = filter.ToBlock
+	}
+
+	if filter.BlockHash != "" {
+		request["blockHash"] = filter.BlockHash
+	}
+
+	switch len(filter.Address) {
+	case 0:
+	case 1:
+		request["address"] = filter.Address[0]
+	default:
+		request["address"] = filter.Address
+	}
+
+	if len(filter.Topics) > 0 {
+		topics := make([]interface{}, len(filter.Topics))
+		for index, values := range filter.Topics {
+			switch len(values) {
+			case 0:
+				topics[index] = nil
+			case 1:
+				topics[index] = values[0]
+			default:
+				topics[index] = values
+			}
+		}
+		request["topics"] = topics
+	}
+
+	return json.Marshal(request)
+}
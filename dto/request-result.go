@@ -40,6 +40,9 @@ type RequestResult struct {
 	Result  interface{} `json:"result"`
 	Error   *Error      `json:"error,omitempty"`
 	Data    string      `json:"data,omitempty"`
+
+	// raw is the result as received, Result being decoded from it
+	raw json.RawMessage
 }
 
 type Error struct {
@@ -48,6 +51,42 @@ type Error struct {
 	Data    interface{} `json:"data"`
 }
 
+// UnmarshalJSON - Decodes a response, keeping its result as received for RawResult
+func (pointer *RequestResult) UnmarshalJSON(data []byte) error {
+
+	// Response has the fields of RequestResult, without this method
+	type Response RequestResult
+
+	decoded := struct {
+		*Response
+		Result json.RawMessage `json:"result"`
+	}{Response: (*Response)(pointer)}
+
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	if decoded.Result == nil {
+		return nil
+	}
+
+	var result interface{}
+	if err := json.Unmarshal(decoded.Result, &result); err != nil {
+		return err
+	}
+
+	pointer.Result = result
+	pointer.raw = decoded.Result
+
+	return nil
+}
+
+// RawResult - The result as received, its numbers and the order of its keys
+// unchanged, nil when the response was not decoded from JSON
+func (pointer *RequestResult) RawResult() json.RawMessage {
+	return pointer.raw
+}
+
 func (pointer *RequestResult) ToStringArray() ([]string, error) {
 
 	if err := pointer.checkResponse(); err != nil {
@@ -125,7 +164,7 @@ func (pointer *RequestResult) ToBigInt() (*big.Int, error) {
 func (pointer *RequestResult) ToComplexIntResponse() (types.ComplexIntResponse, error) {
 
 	if err := pointer.checkResponse(); err != nil {
-		return types.ComplexIntResponse(0), err
+		return types.ComplexIntResponse(""), err
 	}
 
 	result := (pointer).Result.(interface{})
@@ -235,6 +274,32 @@ func (pointer *RequestResult) ToTransactionReceipt() (*TransactionReceipt, error
 
 }
 
+func (pointer *RequestResult) ToTransactionLogs() ([]TransactionLogs, error) {
+
+	if err := pointer.checkResponse(); err != nil {
+		return nil, err
+	}
+
+	result, ok := (pointer).Result.([]interface{})
+
+	if !ok {
+		return nil, customerror.UNPARSEABLEINTERFACE
+	}
+
+	logs := make([]TransactionLogs, 0, len(result))
+
+	marshal, err := json.Marshal(result)
+
+	if err != nil {
+		return nil, customerror.UNPARSEABLEINTERFACE
+	}
+
+	err = json.Unmarshal([]byte(marshal), &logs)
+
+	return logs, err
+
+}
+
 func (pointer *RequestResult) ToBlock() (*Block, error) {
 
 	if err := pointer.checkResponse(); err != nil {
@@ -300,7 +365,7 @@ func (pointer *RequestResult) ToSyncingResponse() (*SyncingResponse, error) {
 func (pointer *RequestResult) checkResponse() error {
 
 	if pointer.Error != nil {
-		return errors.New(pointer.Error.Message)
+		return NewRPCError(pointer.Error)
 	}
 
 	if pointer.Result == nil {
@@ -0,0 +1,193 @@
+/********************************************************************************
+   This file is part of go-web3.
+   go-web3 is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-web3 is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-web3.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file rpc-error.go
+ */
+
+package dto
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/cellcycle/go-web3/constants"
+)
+
+var (
+	// errorSelector - the first 4 bytes of keccak256("Error(string)")
+	errorSelector = []byte{0x08, 0xc3, 0x79, 0xa0}
+	// panicSelector - the first 4 bytes of keccak256("Panic(uint256)")
+	panicSelector = []byte{0x4e, 0x48, 0x7b, 0x71}
+)
+
+// panicReasons - the meaning of the Panic(uint256) codes raised by the compiler
+var panicReasons = map[int64]string{
+	0x00: "generic compiler panic",
+	0x01: "assert failed",
+	0x11: "arithmetic overflow or underflow",
+	0x12: "division or modulo by zero",
+	0x21: "invalid enum value",
+	0x22: "invalid storage byte array encoding",
+	0x31: "pop on an empty array",
+	0x32: "array index out of bounds",
+	0x41: "out of memory",
+	0x51: "call to a zero-initialized function",
+}
+
+// RPCError - The error object of a JSON-RPC response.
+// Use errors.As to reach it, and errors.Is with the sentinels of the
+// customerror package to test the common conditions.
+type RPCError struct {
+	Code    int
+	Message string
+	Data    interface{}
+}
+
+// NewRPCError - The error carried by a JSON-RPC error object
+func NewRPCError(err *Error) *RPCError {
+	return &RPCError{Code: err.Code, Message: err.Message, Data: err.Data}
+}
+
+func (err *RPCError) Error() string {
+	return err.Message
+}
+
+// Is - Matches the customerror sentinels NONCETOOLOW, INSUFFICIENTFUNDS,
+// EXECUTIONREVERTED, METHODNOTFOUND and RATELIMITED from the code and the
+// message of the error, as the clients do not agree on the codes.
+func (err *RPCError) Is(target error) bool {
+
+	message := strings.ToLower(err.Message)
+
+	switch target {
+	case customerror.NONCETOOLOW:
+		return strings.Contains(message, "nonce too low")
+	case customerror.INSUFFICIENTFUNDS:
+		return strings.Contains(message, "insufficient funds")
+	case customerror.EXECUTIONREVERTED:
+		return err.Code == 3 || strings.Contains(message, "revert")
+	case customerror.METHODNOTFOUND:
+		return err.Code == -32601 || strings.Contains(message, "method not found") ||
+			(strings.Contains(message, "method") && strings.Contains(message, "does not exist"))
+	case customerror.RATELIMITED:
+		return err.Code == 429 || err.Code == -32005 || strings.Contains(message, "rate limit") ||
+			strings.Contains(message, "too many requests")
+	}
+
+	return false
+}
+
+// RevertData - The bytes returned by a reverted execution, nil when the
+// error carries none. Some clients nest them in a data object.
+func (err *RPCError) RevertData() []byte {
+
+	data := err.Data
+
+	if object, ok := data.(map[string]interface{}); ok {
+		data = object["data"]
+	}
+
+	value, ok := data.(string)
+	if !ok || !strings.HasPrefix(value, "0x") || len(value) < 10 {
+		return nil
+	}
+
+	decoded, decodeErr := hex.DecodeString(value[2:])
+	if decodeErr != nil {
+		return nil
+	}
+
+	return decoded
+}
+
+// Revert - Decodes the revert data of the error, it returns false when the
+// data is missing or is not an Error(string) or a Panic(uint256).
+func (err *RPCError) Revert() (*Revert, bool) {
+
+	data := err.RevertData()
+	if data == nil {
+		return nil, false
+	}
+
+	revert, decodeErr := DecodeRevert(data)
+
+	return revert, decodeErr == nil
+}
+
+// Revert - The reason given by a reverted execution
+type Revert struct {
+	// Reason is the message of require or revert, empty for a panic
+	Reason string
+	// PanicCode is the code of the failed check, nil for Error(string)
+	PanicCode *big.Int
+	// Data is the raw revert data
+	Data []byte
+}
+
+func (revert *Revert) String() string {
+
+	if revert.PanicCode == nil {
+		return revert.Reason
+	}
+
+	if revert.PanicCode.IsInt64() {
+		if reason, ok := panicReasons[revert.PanicCode.Int64()]; ok {
+			return fmt.Sprintf("panic 0x%x: %s", revert.PanicCode, reason)
+		}
+	}
+
+	return fmt.Sprintf("panic 0x%x", revert.PanicCode)
+}
+
+// DecodeRevert - Decodes revert data encoded as Error(string) or Panic(uint256)
+func DecodeRevert(data []byte) (*Revert, error) {
+
+	if len(data) < 4 {
+		return nil, errors.New("Revert data too short")
+	}
+
+	selector, body := data[:4], data[4:]
+
+	switch {
+
+	case bytes.Equal(selector, errorSelector):
+		if len(body) < 64 {
+			return nil, errors.New("Revert reason too short")
+		}
+		offset := new(big.Int).SetBytes(body[:32])
+		// len(body) is at least 64, the comparison can't overflow
+		if !offset.IsUint64() || offset.Uint64() > uint64(len(body)-32) {
+			return nil, errors.New("Revert reason offset out of range")
+		}
+		start := offset.Uint64() + 32
+		length := new(big.Int).SetBytes(body[offset.Uint64():start])
+		if !length.IsUint64() || length.Uint64() > uint64(len(body))-start {
+			return nil, errors.New("Revert reason length out of range")
+		}
+		return &Revert{Reason: string(body[start : start+length.Uint64()]), Data: data}, nil
+
+	case bytes.Equal(selector, panicSelector):
+		if len(body) < 32 {
+			return nil, errors.New("Panic code too short")
+		}
+		return &Revert{PanicCode: new(big.Int).SetBytes(body[:32]), Data: data}, nil
+	}
+
+	return nil, fmt.Errorf("Unknown revert selector 0x%x", selector)
+}
@@ -21,10 +21,97 @@
 
 package dto
 
-import "math/big"
+import (
+	"encoding/json"
+	"math/big"
+)
 
 type SyncingResponse struct {
 	StartingBlock *big.Int `json:"startingBlock"`
 	CurrentBlock  *big.Int `json:"currentBlock"`
 	HighestBlock  *big.Int `json:"highestBlock"`
 }
+
+func (s *SyncingResponse) UnmarshalJSON(data []byte) error {
+
+	temp := &struct {
+		StartingBlock string `json:"startingBlock"`
+		CurrentBlock  string `json:"currentBlock"`
+		HighestBlock  string `json:"highestBlock"`
+	}{}
+
+	if err := json.Unmarshal(data, temp); err != nil {
+		return err
+	}
+
+	startingBlock, err := blockQuantity(temp.StartingBlock)
+
+	if err != nil {
+		return err
+	}
+
+	currentBlock, err := blockQuantity(temp.CurrentBlock)
+
+	if err != nil {
+		return err
+	}
+
+	highestBlock, err := blockQuantity(temp.HighestBlock)
+
+	if err != nil {
+		return err
+	}
+
+	s.StartingBlock = startingBlock
+	s.CurrentBlock = currentBlock
+	s.HighestBlock = highestBlock
+
+	return nil
+}
+
+// SyncingNotification - The payload of a syncing subscription, Status is only
+// set while the node is syncing
+type SyncingNotification struct {
+	Syncing bool
+	Status  *SyncingResponse
+}
+
+func (n *SyncingNotification) UnmarshalJSON(data []byte) error {
+
+	var syncing bool
+
+	// nodes answer false once they are synced
+	if err := json.Unmarshal(data, &syncing); err == nil {
+		n.Syncing = syncing
+		n.Status = nil
+		return nil
+	}
+
+	temp := &struct {
+		Syncing *bool           `json:"syncing"`
+		Status  json.RawMessage `json:"status"`
+	}{}
+
+	if err := json.Unmarshal(data, temp); err != nil {
+		return err
+	}
+
+	status := json.RawMessage(data)
+
+	if temp.Syncing != nil {
+		n.Syncing = *temp.Syncing
+		status = temp.Status
+	} else {
+		n.Syncing = true
+	}
+
+	n.Status = nil
+
+	if len(status) == 0 || string(status) == "null" {
+		return nil
+	}
+
+	n.Status = &SyncingResponse{}
+
+	return json.Unmarshal(status, n.Status)
+}
@@ -76,22 +76,42 @@ func (params *TransactionParameters) Transform() *RequestTransactionParameters {
 	return request
 }
 
+// SetFrom - Sets the sender of the transaction
+func (params *TransactionParameters) SetFrom(address types.Address) {
+	params.From = address.Hex()
+}
+
+// SetTo - Sets the receiver of the transaction
+func (params *TransactionParameters) SetTo(address types.Address) {
+	params.To = address.Hex()
+}
+
+// ParseFrom - The sender of the transaction, validated with types.ParseAddress
+func (params *TransactionParameters) ParseFrom() (types.Address, error) {
+	return types.ParseAddress(params.From)
+}
+
+// ParseTo - The receiver of the transaction, nil when it creates a contract
+func (params *TransactionParameters) ParseTo() (*types.Address, error) {
+	return parseOptionalAddress(params.To)
+}
+
 type SignTransactionResponse struct {
 	Raw         types.ComplexString     `json:"raw"`
 	Transaction SignedTransactionParams `json:"tx"`
 }
 
 type SignedTransactionParams struct {
-	Gas      *big.Int `json:gas`
-	GasPrice *big.Int `json:gasPrice`
-	Hash     string   `json:hash`
-	Input    string   `json:input`
-	Nonce    *big.Int `json:nonce`
-	S        string   `json:s`
-	R        string   `json:r`
-	V        *big.Int `json:v`
-	To       string   `json:to`
-	Value    *big.Int `json:value`
+	Gas      *big.Int `json:"gas"`
+	GasPrice *big.Int `json:"gasPrice"`
+	Hash     string   `json:"hash"`
+	Input    string   `json:"input"`
+	Nonce    *big.Int `json:"nonce"`
+	S        string   `json:"s"`
+	R        string   `json:"r"`
+	V        *big.Int `json:"v"`
+	To       string   `json:"to"`
+	Value    *big.Int `json:"value"`
 }
 
 type TransactionResponse struct {
@@ -137,6 +157,112 @@ type TransactionLogs struct {
 	Removed          bool     `json:"removed"`
 }
 
+// ParseHash - The hash of the transaction
+func (t *TransactionResponse) ParseHash() (types.Hash, error) {
+	return types.ParseHash(t.Hash)
+}
+
+// ParseBlockHash - The hash of the block of the transaction, nil when it is pending
+func (t *TransactionResponse) ParseBlockHash() (*types.Hash, error) {
+	return parseOptionalHash(t.BlockHash)
+}
+
+// ParseFrom - The sender of the transaction
+func (t *TransactionResponse) ParseFrom() (types.Address, error) {
+	return types.ParseAddress(t.From)
+}
+
+// ParseTo - The receiver of the transaction, nil when it creates a contract
+func (t *TransactionResponse) ParseTo() (*types.Address, error) {
+	return parseOptionalAddress(t.To)
+}
+
+// ParseTransactionHash - The hash of the transaction
+func (r *TransactionReceipt) ParseTransactionHash() (types.Hash, error) {
+	return types.ParseHash(r.TransactionHash)
+}
+
+// ParseBlockHash - The hash of the block of the transaction
+func (r *TransactionReceipt) ParseBlockHash() (types.Hash, error) {
+	return types.ParseHash(r.BlockHash)
+}
+
+// ParseFrom - The sender of the transaction
+func (r *TransactionReceipt) ParseFrom() (types.Address, error) {
+	return types.ParseAddress(r.From)
+}
+
+// ParseTo - The receiver of the transaction, nil when it created a contract
+func (r *TransactionReceipt) ParseTo() (*types.Address, error) {
+	return parseOptionalAddress(r.To)
+}
+
+// ParseContractAddress - The address of the contract created by the transaction, nil
+// when it didn't create one
+func (r *TransactionReceipt) ParseContractAddress() (*types.Address, error) {
+	return parseOptionalAddress(r.ContractAddress)
+}
+
+// ParseAddress - The address of the contract which emitted the log
+func (r *TransactionLogs) ParseAddress() (types.Address, error) {
+	return types.ParseAddress(r.Address)
+}
+
+// ParseTopics - The topics of the log
+func (r *TransactionLogs) ParseTopics() ([]types.Hash, error) {
+
+	topics := make([]types.Hash, len(r.Topics))
+	for i, topic := range r.Topics {
+		hash, err := types.ParseHash(topic)
+		if err != nil {
+			return nil, err
+		}
+		topics[i] = hash
+	}
+
+	return topics, nil
+}
+
+// ParseTransactionHash - The hash of the transaction of the log
+func (r *TransactionLogs) ParseTransactionHash() (types.Hash, error) {
+	return types.ParseHash(r.TransactionHash)
+}
+
+// ParseBlockHash - The hash of the block of the log
+func (r *TransactionLogs) ParseBlockHash() (types.Hash, error) {
+	return types.ParseHash(r.BlockHash)
+}
+
+// parseOptionalAddress parses the addresses which may be empty or null
+func parseOptionalAddress(text string) (*types.Address, error) {
+
+	if text == "" {
+		return nil, nil
+	}
+
+	address, err := types.ParseAddress(text)
+	if err != nil {
+		return nil, err
+	}
+
+	return &address, nil
+}
+
+// parseOptionalHash parses the hashes which may be empty or null
+func parseOptionalHash(text string) (*types.Hash, error) {
+
+	if text == "" {
+		return nil, nil
+	}
+
+	hash, err := types.ParseHash(text)
+	if err != nil {
+		return nil, err
+	}
+
+	return &hash, nil
+}
+
 func (t *TransactionResponse) UnmarshalJSON(data []byte) error {
 	type Alias TransactionResponse
 	temp := &struct {
@@ -313,11 +439,11 @@ func (sp *SignedTransactionParams) UnmarshalJSON(data []byte) error {
 	type Alias SignedTransactionParams
 
 	temp := &struct {
-		Gas      string `json:gas`
-		GasPrice string `json:gasPrice`
-		Nonce    string `json:nonce`
-		V        string `json:v`
-		Value    string `json:value`
+		Gas      string `json:"gas"`
+		GasPrice string `json:"gasPrice"`
+		Nonce    string `json:"nonce"`
+		V        string `json:"v"`
+		Value    string `json:"value"`
 		*Alias
 	}{
 		Alias: (*Alias)(sp),
@@ -0,0 +1,420 @@
+/********************************************************************************
+   This file is part of go-web3.
+   go-web3 is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-web3 is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-web3.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file batch.go
+ */
+
+package eth
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+
+	"github.com/cellcycle/go-web3/complex/types"
+	"github.com/cellcycle/go-web3/dto"
+	"github.com/cellcycle/go-web3/eth/block"
+	"github.com/cellcycle/go-web3/providers"
+	"github.com/cellcycle/go-web3/utils"
+)
+
+// Batch - Queues Eth calls to send them to the node in a single JSON-RPC batch.
+// Every queued call returns a typed handle whose Result is available once the
+// batch has been executed.
+type Batch struct {
+	eth      *Eth
+	elems    []providers.BatchElem
+	executed bool
+}
+
+// NewBatch - Creates an empty batch of calls using the Eth provider
+func (eth *Eth) NewBatch() *Batch {
+	batch := new(Batch)
+	batch.eth = eth
+	return batch
+}
+
+// Len - Number of calls queued in the batch
+func (batch *Batch) Len() int {
+	return len(batch.elems)
+}
+
+// Execute - Sends all the queued calls in one round trip
+func (batch *Batch) Execute() error {
+	return batch.ExecuteCtx(context.Background())
+}
+
+// ExecuteCtx - Same as Execute, using ctx to cancel the request or set its deadline.
+func (batch *Batch) ExecuteCtx(ctx context.Context) error {
+
+	pending := make([]providers.BatchElem, 0, len(batch.elems))
+	positions := make([]int, 0, len(batch.elems))
+
+	// calls rejected while queueing already carry their error
+	for index := range batch.elems {
+		if batch.elems[index].Error == nil {
+			pending = append(pending, batch.elems[index])
+			positions = append(positions, index)
+		}
+	}
+
+	batch.executed = true
+
+	if len(pending) == 0 {
+		return nil
+	}
+
+	err := providers.SendBatchCtx(ctx, batch.eth.provider, pending)
+
+	for index, position := range positions {
+		batch.elems[position] = pending[index]
+		if err != nil {
+			batch.elems[position].Error = err
+		}
+	}
+
+	return err
+}
+
+func (batch *Batch) queue(method string, params interface{}) batchCall {
+	batch.elems = append(batch.elems, providers.BatchElem{Method: method, Params: params, Result: &dto.RequestResult{}})
+	return batchCall{batch: batch, index: len(batch.elems) - 1}
+}
+
+func (batch *Batch) reject(method string, err error) batchCall {
+	batch.elems = append(batch.elems, providers.BatchElem{Method: method, Error: err})
+	return batchCall{batch: batch, index: len(batch.elems) - 1}
+}
+
+// batchCall - Position of a queued call inside its batch
+type batchCall struct {
+	batch *Batch
+	index int
+}
+
+func (call batchCall) result() (*dto.RequestResult, error) {
+
+	if !call.batch.executed {
+		return nil, errors.New("batch not executed")
+	}
+
+	elem := call.batch.elems[call.index]
+
+	if elem.Error != nil {
+		return nil, elem.Error
+	}
+
+	return elem.Result, nil
+}
+
+// BigIntCall - A queued call answering a QUANTITY
+type BigIntCall struct{ batchCall }
+
+// Result - The decoded response of the call
+func (call *BigIntCall) Result() (*big.Int, error) {
+	pointer, err := call.result()
+	if err != nil {
+		return nil, err
+	}
+	return pointer.ToBigInt()
+}
+
+// StringCall - A queued call answering a DATA string
+type StringCall struct{ batchCall }
+
+// Result - The decoded response of the call
+func (call *StringCall) Result() (string, error) {
+	pointer, err := call.result()
+	if err != nil {
+		return "", err
+	}
+	return pointer.ToString()
+}
+
+// ComplexStringCall - A queued call answering an encoded string
+type ComplexStringCall struct{ batchCall }
+
+// Result - The decoded response of the call
+func (call *ComplexStringCall) Result() (types.ComplexString, error) {
+	pointer, err := call.result()
+	if err != nil {
+		return "", err
+	}
+	return pointer.ToComplexString()
+}
+
+// StringArrayCall - A queued call answering an array of DATA
+type StringArrayCall struct{ batchCall }
+
+// Result - The decoded response of the call
+func (call *StringArrayCall) Result() ([]string, error) {
+	pointer, err := call.result()
+	if err != nil {
+		return nil, err
+	}
+	return pointer.ToStringArray()
+}
+
+// BooleanCall - A queued call answering a Boolean
+type BooleanCall struct{ batchCall }
+
+// Result - The decoded response of the call
+func (call *BooleanCall) Result() (bool, error) {
+	pointer, err := call.result()
+	if err != nil {
+		return false, err
+	}
+	return pointer.ToBoolean()
+}
+
+// SyncingCall - A queued eth_syncing call
+type SyncingCall struct{ batchCall }
+
+// Result - The decoded response of the call
+func (call *SyncingCall) Result() (*dto.SyncingResponse, error) {
+	pointer, err := call.result()
+	if err != nil {
+		return nil, err
+	}
+	return pointer.ToSyncingResponse()
+}
+
+// TransactionCall - A queued call answering a transaction object
+type TransactionCall struct{ batchCall }
+
+// Result - The decoded response of the call
+func (call *TransactionCall) Result() (*dto.TransactionResponse, error) {
+	pointer, err := call.result()
+	if err != nil {
+		return nil, err
+	}
+	return pointer.ToTransactionResponse()
+}
+
+// SignTransactionCall - A queued eth_signTransaction call
+type SignTransactionCall struct{ batchCall }
+
+// Result - The decoded response of the call
+func (call *SignTransactionCall) Result() (*dto.SignTransactionResponse, error) {
+	pointer, err := call.result()
+	if err != nil {
+		return nil, err
+	}
+	return pointer.ToSignTransactionResponse()
+}
+
+// ReceiptCall - A queued eth_getTransactionReceipt call
+type ReceiptCall struct{ batchCall }
+
+// Result - The decoded response of the call
+func (call *ReceiptCall) Result() (*dto.TransactionReceipt, error) {
+	pointer, err := call.result()
+	if err != nil {
+		return nil, err
+	}
+	return pointer.ToTransactionReceipt()
+}
+
+// BlockCall - A queued call answering a block object
+type BlockCall struct{ batchCall }
+
+// Result - The decoded response of the call
+func (call *BlockCall) Result() (*dto.Block, error) {
+	pointer, err := call.result()
+	if err != nil {
+		return nil, err
+	}
+	return pointer.ToBlock()
+}
+
+// RawCall - A queued call whose response is left undecoded
+type RawCall struct{ batchCall }
+
+// Result - The response of the call
+func (call *RawCall) Result() (*dto.RequestResult, error) {
+	return call.result()
+}
+
+// checkBlockHash - Validates a 32 bytes block hash and adds the 0x prefix when missing
+func checkBlockHash(hash string) (string, error) {
+
+	if strings.HasPrefix(hash, "0x") {
+		if len(hash) != 66 {
+			return "", errors.New("malformed block hash")
+		}
+		return hash, nil
+	}
+
+	if len(hash) != 64 {
+		return "", errors.New("malformed block hash")
+	}
+
+	return "0x" + hash, nil
+}
+
+// IsSyncing - Queues an eth_syncing call, see Eth.IsSyncing
+func (batch *Batch) IsSyncing() *SyncingCall {
+	return &SyncingCall{batch.queue("eth_syncing", nil)}
+}
+
+// GetCoinbase - Queues an eth_coinbase call, see Eth.GetCoinbase
+func (batch *Batch) GetCoinbase() *StringCall {
+	return &StringCall{batch.queue("eth_coinbase", nil)}
+}
+
+// IsMining - Queues an eth_mining call, see Eth.IsMining
+func (batch *Batch) IsMining() *BooleanCall {
+	return &BooleanCall{batch.queue("eth_mining", nil)}
+}
+
+// GetHashRate - Queues an eth_hashrate call, see Eth.GetHashRate
+func (batch *Batch) GetHashRate() *BigIntCall {
+	return &BigIntCall{batch.queue("eth_hashrate", nil)}
+}
+
+// GetGasPrice - Queues an eth_gasPrice call, see Eth.GetGasPrice
+func (batch *Batch) GetGasPrice() *BigIntCall {
+	return &BigIntCall{batch.queue("eth_gasPrice", nil)}
+}
+
+// ListAccounts - Queues an eth_accounts call, see Eth.ListAccounts
+func (batch *Batch) ListAccounts() *StringArrayCall {
+	return &StringArrayCall{batch.queue("eth_accounts", nil)}
+}
+
+// GetBlockNumber - Queues an eth_blockNumber call, see Eth.GetBlockNumber
+func (batch *Batch) GetBlockNumber() *BigIntCall {
+	return &BigIntCall{batch.queue("eth_blockNumber", nil)}
+}
+
+// GetBalance - Queues an eth_getBalance call, see Eth.GetBalance
+func (batch *Batch) GetBalance(address string, defaultBlockParameter string) *BigIntCall {
+	return &BigIntCall{batch.queue("eth_getBalance", []string{address, defaultBlockParameter})}
+}
+
+// GetTransactionCount - Queues an eth_getTransactionCount call, see Eth.GetTransactionCount
+func (batch *Batch) GetTransactionCount(address string, defaultBlockParameter string) *BigIntCall {
+	return &BigIntCall{batch.queue("eth_getTransactionCount", []string{address, defaultBlockParameter})}
+}
+
+// GetStorageAt - Queues an eth_getStorageAt call, see Eth.GetStorageAt
+func (batch *Batch) GetStorageAt(address string, position *big.Int, defaultBlockParameter string) *StringCall {
+	return &StringCall{batch.queue("eth_getStorageAt", []string{address, utils.IntToHex(position), defaultBlockParameter})}
+}
+
+// EstimateGas - Queues an eth_estimateGas call, see Eth.EstimateGas
+func (batch *Batch) EstimateGas(transaction *dto.TransactionParameters) *BigIntCall {
+	return &BigIntCall{batch.queue("eth_estimateGas", []*dto.RequestTransactionParameters{transaction.Transform()})}
+}
+
+// GetTransactionByHash - Queues an eth_getTransactionByHash call, see Eth.GetTransactionByHash
+func (batch *Batch) GetTransactionByHash(hash string) *TransactionCall {
+	return &TransactionCall{batch.queue("eth_getTransactionByHash", []string{hash})}
+}
+
+// GetTransactionByBlockHashAndIndex - Queues an eth_getTransactionByBlockHashAndIndex call, see Eth.GetTransactionByBlockHashAndIndex
+func (batch *Batch) GetTransactionByBlockHashAndIndex(hash string, index *big.Int) *TransactionCall {
+
+	hash, err := checkBlockHash(hash)
+	if err != nil {
+		return &TransactionCall{batch.reject("eth_getTransactionByBlockHashAndIndex", err)}
+	}
+
+	return &TransactionCall{batch.queue("eth_getTransactionByBlockHashAndIndex", []string{hash, utils.IntToHex(index)})}
+}
+
+// GetTransactionByBlockNumberAndIndex - Queues an eth_getTransactionByBlockNumberAndIndex call, see Eth.GetTransactionByBlockNumberAndIndex
+func (batch *Batch) GetTransactionByBlockNumberAndIndex(blockIndex *big.Int, index *big.Int) *TransactionCall {
+	return &TransactionCall{batch.queue("eth_getTransactionByBlockNumberAndIndex", []string{utils.IntToHex(blockIndex), utils.IntToHex(index)})}
+}
+
+// SendTransaction - Queues an eth_sendTransaction call, see Eth.SendTransaction
+func (batch *Batch) SendTransaction(transaction *dto.TransactionParameters) *StringCall {
+	return &StringCall{batch.queue("eth_sendTransaction", []*dto.RequestTransactionParameters{transaction.Transform()})}
+}
+
+// SignTransaction - Queues an eth_signTransaction call, see Eth.SignTransaction
+func (batch *Batch) SignTransaction(transaction *dto.TransactionParameters) *SignTransactionCall {
+	return &SignTransactionCall{batch.queue("eth_signTransaction", []*dto.RequestTransactionParameters{transaction.Transform()})}
+}
+
+// Call - Queues an eth_call call on the latest block, see Eth.Call
+func (batch *Batch) Call(transaction *dto.TransactionParameters) *RawCall {
+	return &RawCall{batch.queue("eth_call", []interface{}{transaction.Transform(), block.LATEST})}
+}
+
+// CompileSolidity - Queues an eth_compileSolidity call, see Eth.CompileSolidity
+func (batch *Batch) CompileSolidity(sourceCode string) *ComplexStringCall {
+	return &ComplexStringCall{batch.queue("eth_compileSolidity", []string{sourceCode})}
+}
+
+// GetTransactionReceipt - Queues an eth_getTransactionReceipt call, see Eth.GetTransactionReceipt
+func (batch *Batch) GetTransactionReceipt(hash string) *ReceiptCall {
+	return &ReceiptCall{batch.queue("eth_getTransactionReceipt", []string{hash})}
+}
+
+// GetBlockByNumber - Queues an eth_getBlockByNumber call, see Eth.GetBlockByNumber
+func (batch *Batch) GetBlockByNumber(number *big.Int, transactionDetails bool) *BlockCall {
+	return &BlockCall{batch.queue("eth_getBlockByNumber", []interface{}{utils.IntToHex(number), transactionDetails})}
+}
+
+// GetBlockTransactionCountByHash - Queues an eth_getBlockTransactionCountByHash call, see Eth.GetBlockTransactionCountByHash
+func (batch *Batch) GetBlockTransactionCountByHash(hash string) *BigIntCall {
+
+	hash, err := checkBlockHash(hash)
+	if err != nil {
+		return &BigIntCall{batch.reject("eth_getBlockTransactionCountByHash", err)}
+	}
+
+	return &BigIntCall{batch.queue("eth_getBlockTransactionCountByHash", []string{hash})}
+}
+
+// GetBlockTransactionCountByNumber - Queues an eth_getBlockTransactionCountByNumber call, see Eth.GetBlockTransactionCountByNumber
+func (batch *Batch) GetBlockTransactionCountByNumber(defaultBlockParameter string) *BigIntCall {
+	return &BigIntCall{batch.queue("eth_getBlockTransactionCountByNumber", []string{defaultBlockParameter})}
+}
+
+// GetBlockByHash - Queues an eth_getBlockByHash call, see Eth.GetBlockByHash
+func (batch *Batch) GetBlockByHash(hash string, transactionDetails bool) *BlockCall {
+
+	hash, err := checkBlockHash(hash)
+	if err != nil {
+		return &BlockCall{batch.reject("eth_getBlockByHash", err)}
+	}
+
+	return &BlockCall{batch.queue("eth_getBlockByHash", []interface{}{hash, transactionDetails})}
+}
+
+// GetUncleCountByBlockHash - Queues an eth_getUncleCountByBlockHash call, see Eth.GetUncleCountByBlockHash
+func (batch *Batch) GetUncleCountByBlockHash(hash string) *BigIntCall {
+
+	hash, err := checkBlockHash(hash)
+	if err != nil {
+		return &BigIntCall{batch.reject("eth_getUncleCountByBlockHash", err)}
+	}
+
+	return &BigIntCall{batch.queue("eth_getUncleCountByBlockHash", []string{hash})}
+}
+
+// GetUncleCountByBlockNumber - Queues an eth_getUncleCountByBlockNumber call, see Eth.GetUncleCountByBlockNumber
+func (batch *Batch) GetUncleCountByBlockNumber(quantity *big.Int) *BigIntCall {
+	return &BigIntCall{batch.queue("eth_getUncleCountByBlockNumber", []string{utils.IntToHex(quantity)})}
+}
+
+// GetCode - Queues an eth_getCode call, see Eth.GetCode
+func (batch *Batch) GetCode(address string, defaultBlockParameter string) *StringCall {
+	return &StringCall{batch.queue("eth_getCode", []string{address, defaultBlockParameter})}
+}
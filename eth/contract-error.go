@@ -0,0 +1,185 @@
+/********************************************************************************
+   This file is part of go-web3.
+   go-web3 is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-web3 is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-web3.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file contract-error.go
+ */
+
+package eth
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"github.com/cellcycle/go-web3/abi"
+	"github.com/cellcycle/go-web3/dto"
+)
+
+// ContractError - A reverted execution of a contract, its revert data decoded
+// with the custom errors of the ABI, or as Error(string) or Panic(uint256).
+// It wraps the JSON-RPC error, errors.Is(err, customerror.EXECUTIONREVERTED) holds.
+type ContractError struct {
+	// Name is the name of the custom error, or Error or Panic, empty when the
+	// revert data is unknown
+	Name string
+	// Definition is the custom error of the ABI, nil for Error and Panic
+	Definition *abi.Error
+	// Values are the arguments of the custom error in the order of its definition
+	Values []interface{}
+	// Arguments are the values by the name of their argument, the unnamed ones left out
+	Arguments map[string]interface{}
+	// Revert holds the reason of Error(string) and the code of Panic(uint256)
+	Revert *dto.Revert
+	// Data is the raw revert data
+	Data []byte
+	// Err is the JSON-RPC error carrying the revert data
+	Err *dto.RPCError
+}
+
+func (err *ContractError) Error() string {
+
+	switch {
+	case err.Definition != nil:
+		arguments := make([]string, len(err.Values))
+		for index, value := range err.Values {
+			arguments[index] = formatValue(value)
+			if name := err.Definition.Inputs[index].Name; name != "" {
+				arguments[index] = name + ": " + arguments[index]
+			}
+		}
+		return fmt.Sprintf("execution reverted: %s(%s)", err.Name, strings.Join(arguments, ", "))
+
+	case err.Revert != nil:
+		if reason := err.Revert.String(); reason != "" {
+			return "execution reverted: " + reason
+		}
+		return "execution reverted"
+	}
+
+	return fmt.Sprintf("execution reverted: unknown error 0x%x", err.Data)
+}
+
+// Unwrap - The JSON-RPC error
+func (err *ContractError) Unwrap() error {
+	return err.Err
+}
+
+// IsPanic - Tells if the execution failed a check of the compiler, Revert holds its code
+func (err *ContractError) IsPanic() bool {
+	return err.Revert != nil && err.Revert.PanicCode != nil
+}
+
+// Decode - Decodes the arguments of the custom error into out, a pointer to a
+// struct with a field per argument, matched by their abi:"name" tag or their name
+func (err *ContractError) Decode(out interface{}) error {
+
+	if err.Definition == nil {
+		return fmt.Errorf("The revert isn't a custom error of the contract")
+	}
+
+	return err.Definition.UnpackInto(out, err.Data)
+}
+
+// DecodeError - Returns a *ContractError for the reverted executions with revert
+// data, err otherwise
+func (contract *Contract) DecodeError(err error) error {
+
+	var rpcError *dto.RPCError
+	if !errors.As(err, &rpcError) {
+		return err
+	}
+
+	data := rpcError.RevertData()
+	if data == nil {
+		return err
+	}
+
+	contractError := &ContractError{Data: data, Err: rpcError}
+
+	if definition, values, decodeErr := contract.definition.UnpackError(data); decodeErr == nil {
+		contractError.Name = definition.Name
+		contractError.Definition = definition
+		contractError.Values = values
+		contractError.Arguments = make(map[string]interface{})
+		for index, input := range definition.Inputs {
+			if input.Name != "" {
+				contractError.Arguments[input.Name] = values[index]
+			}
+		}
+		return contractError
+	}
+
+	if revert, ok := rpcError.Revert(); ok {
+		contractError.Revert = revert
+		contractError.Name = "Error"
+		if revert.PanicCode != nil {
+			contractError.Name = "Panic"
+		}
+	}
+
+	return contractError
+}
+
+// EstimateGas - Estimates the gas used by a transaction calling functionName
+// with args, the reverts are returned as a *ContractError
+func (contract *Contract) EstimateGas(transaction *dto.TransactionParameters, functionName string, args ...interface{}) (*big.Int, error) {
+	return contract.EstimateGasCtx(context.Background(), transaction, functionName, args...)
+}
+
+// EstimateGasCtx - Same as EstimateGas, using ctx to cancel the request or set its deadline.
+func (contract *Contract) EstimateGasCtx(ctx context.Context, transaction *dto.TransactionParameters, functionName string, args ...interface{}) (*big.Int, error) {
+
+	method, err := contract.method(functionName, args)
+	if err != nil {
+		return nil, err
+	}
+
+	transaction, err = contract.prepareTransaction(transaction, method, args)
+	if err != nil {
+		return nil, err
+	}
+
+	gas, err := contract.super.EstimateGasCtx(ctx, transaction)
+	if err != nil {
+		return nil, contract.DecodeError(err)
+	}
+
+	return gas, nil
+
+}
+
+// formatValue - a decoded value as written in the messages, the bytes in hex
+func formatValue(value interface{}) string {
+
+	switch typed := value.(type) {
+	case string:
+		return fmt.Sprintf("%q", typed)
+	case []byte:
+		return "0x" + hex.EncodeToString(typed)
+	}
+
+	reflected := reflect.ValueOf(value)
+	if reflected.Kind() == reflect.Array && reflected.Type().Elem().Kind() == reflect.Uint8 {
+		data := make([]byte, reflected.Len())
+		reflect.Copy(reflect.ValueOf(data), reflected)
+		return "0x" + hex.EncodeToString(data)
+	}
+
+	return fmt.Sprint(value)
+}
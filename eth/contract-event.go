@@ -0,0 +1,200 @@
+/********************************************************************************
+   This file is part of go-web3.
+   go-web3 is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-web3 is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-web3.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file contract-event.go
+ */
+
+package eth
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/cellcycle/go-web3/abi"
+	"github.com/cellcycle/go-web3/dto"
+)
+
+// ContractEvent - A log decoded with the event of the contract which emitted it
+type ContractEvent struct {
+	Name  string
+	Event *abi.Event
+	// Values are the fields of the event in the order of its definition, the
+	// indexed ones stored as their hash are a [32]byte
+	Values []interface{}
+	// Arguments are the values by the name of their field, the unnamed ones left out
+	Arguments map[string]interface{}
+	Log       *dto.TransactionLogs
+}
+
+// Decode - Decodes the fields of the event into out, a pointer to a struct with a
+// field per event field, matched by their abi:"name" tag or their name
+func (event *ContractEvent) Decode(out interface{}) error {
+
+	topics, data, err := logContent(event.Log)
+	if err != nil {
+		return err
+	}
+
+	return event.Event.UnpackLogInto(out, topics, data)
+}
+
+// ParseLog - Decodes log with the event of the contract whose topic is its first
+// topic, or else with the first anonymous event matching it
+func (contract *Contract) ParseLog(log *dto.TransactionLogs) (*ContractEvent, error) {
+
+	topics, data, err := logContent(log)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(topics) > 0 {
+		if event, err := contract.definition.EventByTopic(topics[0]); err == nil {
+			return newContractEvent(event, log, topics, data)
+		}
+	}
+
+	for _, event := range contract.definition.Events {
+		if !event.Anonymous {
+			continue
+		}
+		if parsed, err := newContractEvent(event, log, topics, data); err == nil {
+			return parsed, nil
+		}
+	}
+
+	if len(topics) == 0 {
+		return nil, fmt.Errorf("No event of the contract matches the log without topics")
+	}
+
+	return nil, fmt.Errorf("No event of the contract has the topic 0x%x", topics[0])
+}
+
+// EventFilter - The filter of the logs of the event eventName emitted by the
+// contract, their indexed fields equal to the indexedArgs given in order, a nil
+// value matching any field. Set the blocks searched before using it.
+func (contract *Contract) EventFilter(eventName string, indexedArgs ...interface{}) (*dto.FilterParameters, error) {
+
+	if contract.address == "" {
+		return nil, fmt.Errorf("The contract isn't bound to an address, see At")
+	}
+
+	event, err := contract.definition.Event(eventName)
+	if err != nil {
+		return nil, err
+	}
+
+	topics, err := event.Topics(indexedArgs...)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := &dto.FilterParameters{Address: []string{contract.address}}
+
+	for _, accepted := range topics {
+		position := make([]string, len(accepted))
+		for index, topic := range accepted {
+			position[index] = "0x" + hex.EncodeToString(topic[:])
+		}
+		filter.Topics = append(filter.Topics, position)
+	}
+
+	return filter, nil
+}
+
+// FilterLogs - The logs of the event eventName emitted by the contract between
+// fromBlock and toBlock, decoded. The indexed fields are filtered by indexedArgs
+// as in EventFilter.
+func (contract *Contract) FilterLogs(eventName string, fromBlock string, toBlock string, indexedArgs ...interface{}) ([]*ContractEvent, error) {
+	return contract.FilterLogsCtx(context.Background(), eventName, fromBlock, toBlock, indexedArgs...)
+}
+
+// FilterLogsCtx - Same as FilterLogs, using ctx to cancel the request or set its deadline.
+func (contract *Contract) FilterLogsCtx(ctx context.Context, eventName string, fromBlock string, toBlock string, indexedArgs ...interface{}) ([]*ContractEvent, error) {
+
+	filter, err := contract.EventFilter(eventName, indexedArgs...)
+	if err != nil {
+		return nil, err
+	}
+
+	filter.FromBlock = fromBlock
+	filter.ToBlock = toBlock
+
+	logs, err := contract.super.GetLogsCtx(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	event, err := contract.definition.Event(eventName)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]*ContractEvent, len(logs))
+
+	for index := range logs {
+
+		topics, data, err := logContent(&logs[index])
+		if err != nil {
+			return nil, err
+		}
+
+		events[index], err = newContractEvent(event, &logs[index], topics, data)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return events, nil
+}
+
+func newContractEvent(event *abi.Event, log *dto.TransactionLogs, topics [][32]byte, data []byte) (*ContractEvent, error) {
+
+	values, err := event.UnpackLog(topics, data)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid log of %s: %v", event.Signature(), err)
+	}
+
+	arguments := make(map[string]interface{})
+	for index, input := range event.Inputs {
+		if input.Name != "" {
+			arguments[input.Name] = values[index]
+		}
+	}
+
+	return &ContractEvent{Name: event.Name, Event: event, Values: values, Arguments: arguments, Log: log}, nil
+}
+
+// logContent - the topics and the data of log, decoded from hex
+func logContent(log *dto.TransactionLogs) ([][32]byte, []byte, error) {
+
+	topics := make([][32]byte, len(log.Topics))
+
+	for index, topic := range log.Topics {
+		decoded, err := hex.DecodeString(strings.TrimPrefix(topic, "0x"))
+		if err != nil || len(decoded) != 32 {
+			return nil, nil, fmt.Errorf("Invalid topic %q", topic)
+		}
+		copy(topics[index][:], decoded)
+	}
+
+	data, err := hex.DecodeString(strings.TrimPrefix(log.Data, "0x"))
+	if err != nil {
+		return nil, nil, fmt.Errorf("Invalid log data: %v", err)
+	}
+
+	return topics, data, nil
+}
@@ -22,188 +22,268 @@
 package eth
 
 import (
-	"encoding/json"
-	"errors"
+	"context"
+	"encoding/hex"
 	"fmt"
-	"github.com/cellcycle/go-web3/complex/types"
-	"github.com/cellcycle/go-web3/dto"
 	"strings"
 
-	"github.com/cellcycle/go-web3/utils"
-	"math/big"
+	"github.com/cellcycle/go-web3/abi"
+	"github.com/cellcycle/go-web3/complex/types"
+	"github.com/cellcycle/go-web3/dto"
 )
 
 // Contract ...
 type Contract struct {
-	super     *Eth
-	abi       string
-	functions map[string][]string
+	super      *Eth
+	abi        string
+	definition *abi.ABI
+	address    string
 }
 
-// NewContract - Contract abstraction
-func (eth *Eth) NewContract(abi string) (*Contract, error) {
+// NewContract - Contract abstraction over the JSON ABI of the contract, or over
+// its human-readable ABI, the Solidity declarations parsed by abi.ParseHumanReadable
+func (eth *Eth) NewContract(jsonInterface string) (*Contract, error) {
 
-	contract := new(Contract)
-	var mockInterface interface{}
+	var definition *abi.ABI
+	var err error
 
-	err := json.Unmarshal([]byte(abi), &mockInterface)
+	if strings.HasPrefix(strings.TrimSpace(jsonInterface), "[") {
+		definition, err = abi.ParseJSON([]byte(jsonInterface))
+	} else {
+		definition, err = abi.ParseHumanReadable(jsonInterface)
+	}
 
 	if err != nil {
 		return nil, err
 	}
 
-	jsonInterface := mockInterface.([]interface{})
-	contract.functions = make(map[string][]string)
-	for index := 0; index < len(jsonInterface); index++ {
-		function := jsonInterface[index].(map[string]interface{})
+	contract := new(Contract)
+	contract.abi = jsonInterface
+	contract.definition = definition
+	contract.super = eth
 
-		if function["type"] == "constructor" || function["type"] == "fallback" {
-			function["name"] = function["type"]
-		}
+	return contract, nil
+}
 
-		functionName := function["name"].(string)
-		contract.functions[functionName] = make([]string, 0)
+// ABI - The functions, events and errors of the contract
+func (contract *Contract) ABI() *abi.ABI {
+	return contract.definition
+}
 
-		if function["inputs"] == nil {
-			continue
-		}
+// At - A copy of the contract bound to its deployed address, which FilterLogs searches
+func (contract *Contract) At(address string) *Contract {
+	bound := *contract
+	bound.address = address
+	return &bound
+}
 
-		inputs := function["inputs"].([]interface{})
-		for paramIndex := 0; paramIndex < len(inputs); paramIndex++ {
-			params := inputs[paramIndex].(map[string]interface{})
-			contract.functions[functionName] = append(contract.functions[functionName], params["type"].(string))
-		}
+// Address - The address the contract is bound to, empty when unbound
+func (contract *Contract) Address() string {
+	return contract.address
+}
 
+// method - The function called with args: functionName is either the name of
+// the function, the overloads being told apart by their number of inputs, or
+// its full signature such as transfer(address,uint256)
+func (contract *Contract) method(functionName string, args []interface{}) (*abi.Method, error) {
+
+	if strings.Contains(functionName, "(") {
+		return contract.definition.Method(functionName)
 	}
 
-	contract.abi = abi
-	contract.super = eth
+	methods := contract.definition.MethodsByName(functionName)
+	if len(methods) == 0 {
+		return nil, fmt.Errorf("Function %s not found in the ABI", functionName)
+	}
 
-	return contract, nil
+	var found []*abi.Method
+	for _, method := range methods {
+		if len(method.Inputs) == len(args) {
+			found = append(found, method)
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return nil, fmt.Errorf("Function %s takes %d arguments, %d given", functionName, len(methods[0].Inputs), len(args))
+	case 1:
+		return found[0], nil
+	}
+
+	return nil, fmt.Errorf("Function %s is overloaded with %d arguments, call it by its signature", functionName, len(args))
 }
 
 // prepareTransaction ...
-func (contract *Contract) prepareTransaction(transaction *dto.TransactionParameters, functionName string, args []interface{}) (*dto.TransactionParameters, error) {
+func (contract *Contract) prepareTransaction(transaction *dto.TransactionParameters, method *abi.Method, args []interface{}) (*dto.TransactionParameters, error) {
 
-	function, ok := contract.functions[functionName]
-	if !ok {
-		return nil, errors.New("Function not finded on passed abi")
+	data, err := method.Pack(args...)
+	if err != nil {
+		return nil, err
 	}
 
-	fullFunction := functionName + "("
+	transaction.Data = types.ComplexString("0x" + hex.EncodeToString(data))
 
-	comma := ""
-	for arg := range function {
-		fullFunction += comma + function[arg]
-		comma = ","
-	}
+	return transaction, nil
 
-	fullFunction += ")"
+}
+
+// Call - Calls the constant function functionName with args, and decodes the values
+// it returns as described by abi.Arguments.Unpack. The reverts are returned as a *ContractError.
+func (contract *Contract) Call(transaction *dto.TransactionParameters, functionName string, args ...interface{}) ([]interface{}, error) {
+	return contract.CallCtx(context.Background(), transaction, functionName, args...)
+}
 
-	utils := utils.NewUtils(contract.super.provider)
-	sha3Function, err := utils.Sha3(types.ComplexString(fullFunction))
+// CallCtx - Same as Call, using ctx to cancel the requests or set their deadline.
+func (contract *Contract) CallCtx(ctx context.Context, transaction *dto.TransactionParameters, functionName string, args ...interface{}) ([]interface{}, error) {
 
+	method, data, err := contract.call(ctx, transaction, functionName, args)
 	if err != nil {
 		return nil, err
 	}
 
-	var data string
+	values, err := method.Outputs.Unpack(data)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid result of %s: %v", method.Signature(), err)
+	}
 
-	for index := 0; index < len(function); index++ {
-		currentData, err := contract.getHexValue(function[index], args[index])
+	return values, nil
 
-		if err != nil {
-			return nil, err
-		}
+}
+
+// CallInto - Calls the constant function functionName with args, and decodes the
+// values it returns into out as described by abi.Arguments.UnpackInto: a pointer to
+// the single value returned, or to a struct with a field per value
+func (contract *Contract) CallInto(transaction *dto.TransactionParameters, out interface{}, functionName string, args ...interface{}) error {
+	return contract.CallIntoCtx(context.Background(), transaction, out, functionName, args...)
+}
+
+// CallIntoCtx - Same as CallInto, using ctx to cancel the requests or set their deadline.
+func (contract *Contract) CallIntoCtx(ctx context.Context, transaction *dto.TransactionParameters, out interface{}, functionName string, args ...interface{}) error {
 
-		data += currentData
+	method, data, err := contract.call(ctx, transaction, functionName, args)
+	if err != nil {
+		return err
 	}
 
-	transaction.Data = types.ComplexString(sha3Function[0:10] + data)
+	if err := method.Outputs.UnpackInto(out, data); err != nil {
+		return fmt.Errorf("Invalid result of %s: %v", method.Signature(), err)
+	}
 
-	return transaction, nil
+	return nil
 
 }
 
-func (contract *Contract) Call(transaction *dto.TransactionParameters, functionName string, args ...interface{}) (*dto.RequestResult, error) {
+// CallRaw - Calls the constant function functionName with args, the result holds
+// the encoded values it returns
+func (contract *Contract) CallRaw(transaction *dto.TransactionParameters, functionName string, args ...interface{}) (*dto.RequestResult, error) {
+	return contract.CallRawCtx(context.Background(), transaction, functionName, args...)
+}
+
+// CallRawCtx - Same as CallRaw, using ctx to cancel the requests or set their deadline.
+func (contract *Contract) CallRawCtx(ctx context.Context, transaction *dto.TransactionParameters, functionName string, args ...interface{}) (*dto.RequestResult, error) {
 
-	transaction, err := contract.prepareTransaction(transaction, functionName, args)
+	method, err := contract.method(functionName, args)
+	if err != nil {
+		return nil, err
+	}
 
+	transaction, err = contract.prepareTransaction(transaction, method, args)
 	if err != nil {
 		return nil, err
 	}
 
-	return contract.super.Call(transaction)
+	return contract.super.CallCtx(ctx, transaction)
 
 }
 
-func (contract *Contract) Send(transaction *dto.TransactionParameters, functionName string, args ...interface{}) (string, error) {
+// call - the function called and the data it returns
+func (contract *Contract) call(ctx context.Context, transaction *dto.TransactionParameters, functionName string, args []interface{}) (*abi.Method, []byte, error) {
 
-	transaction, err := contract.prepareTransaction(transaction, functionName, args)
+	method, err := contract.method(functionName, args)
+	if err != nil {
+		return nil, nil, err
+	}
 
+	result, err := contract.CallRawCtx(ctx, transaction, method.Signature(), args...)
 	if err != nil {
-		return "", err
+		return nil, nil, contract.DecodeError(err)
 	}
 
-	return contract.super.SendTransaction(transaction)
+	encoded, err := result.ToString()
+	if err != nil {
+		return nil, nil, contract.DecodeError(err)
+	}
 
-}
+	data, err := hex.DecodeString(strings.TrimPrefix(encoded, "0x"))
+	if err != nil {
+		return nil, nil, fmt.Errorf("Invalid result of %s: %v", method.Signature(), err)
+	}
 
-func (contract *Contract) Deploy(transaction *dto.TransactionParameters, bytecode string, args ...interface{}) (string, error) {
+	if len(data) == 0 && len(method.Outputs) > 0 {
+		return nil, nil, fmt.Errorf("%s returned no data, %s may not be a contract", method.Signature(), transaction.To)
+	}
 
-	constructor := contract.functions["constructor"]
+	return method, data, nil
 
-	for index := 0; index < len(constructor); index++ {
-		tmpBytes, err := contract.getHexValue(constructor[index], args[index])
+}
 
-		if err != nil {
-			return "", err
-		}
+func (contract *Contract) Send(transaction *dto.TransactionParameters, functionName string, args ...interface{}) (string, error) {
+	return contract.SendCtx(context.Background(), transaction, functionName, args...)
+}
 
-		bytecode += tmpBytes
-	}
+// SendCtx - Same as Send, using ctx to cancel the requests or set their deadline.
+func (contract *Contract) SendCtx(ctx context.Context, transaction *dto.TransactionParameters, functionName string, args ...interface{}) (string, error) {
 
-	transaction.Data = types.ComplexString(bytecode)
+	method, err := contract.method(functionName, args)
+	if err != nil {
+		return "", err
+	}
 
-	return contract.super.SendTransaction(transaction)
+	transaction, err = contract.prepareTransaction(transaction, method, args)
+	if err != nil {
+		return "", err
+	}
 
-}
+	hash, err := contract.super.SendTransactionCtx(ctx, transaction)
+	if err != nil {
+		return "", contract.DecodeError(err)
+	}
 
-func (contract *Contract) getHexValue(inputType string, value interface{}) (string, error) {
+	return hash, nil
 
-	var data string
+}
 
-	if strings.HasPrefix(inputType, "int") ||
-		strings.HasPrefix(inputType, "uint") ||
-		strings.HasPrefix(inputType, "fixed") ||
-		strings.HasPrefix(inputType, "ufixed") {
+func (contract *Contract) Deploy(transaction *dto.TransactionParameters, bytecode string, args ...interface{}) (string, error) {
+	return contract.DeployCtx(context.Background(), transaction, bytecode, args...)
+}
 
-		bigVal := value.(*big.Int)
+// DeployCtx - Same as Deploy, using ctx to cancel the request or set its deadline.
+func (contract *Contract) DeployCtx(ctx context.Context, transaction *dto.TransactionParameters, bytecode string, args ...interface{}) (string, error) {
 
-		// Checking that the string actually is the correct inputType
-		if strings.Contains(inputType, "128") {
-			// 128 bit
-			if bigVal.BitLen() > 128 {
-				return "", errors.New(fmt.Sprintf("Input type %s not met", inputType))
-			}
-		} else if strings.Contains(inputType, "256") {
-			// 256 bit
-			if bigVal.BitLen() > 256 {
-				return "", errors.New(fmt.Sprintf("Input type %s not met", inputType))
-			}
-		}
+	var inputs abi.Arguments
+	if contract.definition.Constructor != nil {
+		inputs = contract.definition.Constructor.Inputs
+	}
 
-		data += fmt.Sprintf("%064s", fmt.Sprintf("%x", bigVal.String()))
+	// Deploy(transaction, bytecode, nil) deploys a contract without constructor arguments
+	if len(inputs) == 0 && len(args) == 1 && args[0] == nil {
+		args = nil
 	}
 
-	if strings.Compare("address", inputType) == 0 {
-		data += fmt.Sprintf("%064s", value.(string)[2:])
+	data, err := inputs.Pack(args...)
+	if err != nil {
+		return "", err
 	}
 
-	if strings.Compare("string", inputType) == 0 {
-		data += fmt.Sprintf("%064s", fmt.Sprintf("%x", value.(string)))
+	bytecode += hex.EncodeToString(data)
+
+	transaction.Data = types.ComplexString(bytecode)
+
+	hash, err := contract.super.SendTransactionCtx(ctx, transaction)
+	if err != nil {
+		return "", contract.DecodeError(err)
 	}
 
-	return data, nil
+	return hash, nil
 
 }
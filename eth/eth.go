@@ -22,6 +22,7 @@
 package eth
 
 import (
+	"context"
 	"errors"
 	"github.com/cellcycle/go-web3/complex/types"
 	"github.com/cellcycle/go-web3/dto"
@@ -51,17 +52,23 @@ func (eth *Eth) Contract(jsonInterface string) (*Contract, error) {
 // IsSyncing - Returns an object with data about the sync status or false.
 // Reference: https://github.com/ethereum/wiki/wiki/JSON-RPC#eth_syncing
 // Parameters:
-//    - none
+//   - none
+//
 // Returns:
-// 	  - Object|Boolean, An object with sync status data or FALSE, when not syncing:
-//    	- startingBlock: 	QUANTITY - The block at which the import started (will only be reset, after the sync reached his head)
-//    	- currentBlock: 	QUANTITY - The current block, same as eth_blockNumber
-//    	- highestBlock: 	QUANTITY - The estimated highest block
+//   - Object|Boolean, An object with sync status data or FALSE, when not syncing:
+//   - startingBlock: 	QUANTITY - The block at which the import started (will only be reset, after the sync reached his head)
+//   - currentBlock: 	QUANTITY - The current block, same as eth_blockNumber
+//   - highestBlock: 	QUANTITY - The estimated highest block
 func (eth *Eth) IsSyncing() (*dto.SyncingResponse, error) {
+	return eth.IsSyncingCtx(context.Background())
+}
+
+// IsSyncingCtx - Same as IsSyncing, using ctx to cancel the request or set its deadline.
+func (eth *Eth) IsSyncingCtx(ctx context.Context) (*dto.SyncingResponse, error) {
 
 	pointer := &dto.RequestResult{}
 
-	err := eth.provider.SendRequest(pointer, "eth_syncing", nil)
+	err := eth.provider.SendRequestCtx(ctx, pointer, "eth_syncing", nil)
 
 	if err != nil {
 		return nil, err
@@ -74,14 +81,20 @@ func (eth *Eth) IsSyncing() (*dto.SyncingResponse, error) {
 // GetCoinbase - Returns the client coinbase address.
 // Reference: https://github.com/ethereum/wiki/wiki/JSON-RPC#eth_coinbase
 // Parameters:
-//    - none
+//   - none
+//
 // Returns:
-// 	  - DATA, 20 bytes - the current coinbase address.
+//   - DATA, 20 bytes - the current coinbase address.
 func (eth *Eth) GetCoinbase() (string, error) {
+	return eth.GetCoinbaseCtx(context.Background())
+}
+
+// GetCoinbaseCtx - Same as GetCoinbase, using ctx to cancel the request or set its deadline.
+func (eth *Eth) GetCoinbaseCtx(ctx context.Context) (string, error) {
 
 	pointer := &dto.RequestResult{}
 
-	err := eth.provider.SendRequest(pointer, "eth_coinbase", nil)
+	err := eth.provider.SendRequestCtx(ctx, pointer, "eth_coinbase", nil)
 
 	if err != nil {
 		return "", err
@@ -94,14 +107,20 @@ func (eth *Eth) GetCoinbase() (string, error) {
 // IsMining - Returns true if client is actively mining new blocks.
 // Reference: https://github.com/ethereum/wiki/wiki/JSON-RPC#eth_mining
 // Parameters:
-//    - none
+//   - none
+//
 // Returns:
-// 	  - Boolean - returns true of the client is mining, otherwise false.
+//   - Boolean - returns true of the client is mining, otherwise false.
 func (eth *Eth) IsMining() (bool, error) {
+	return eth.IsMiningCtx(context.Background())
+}
+
+// IsMiningCtx - Same as IsMining, using ctx to cancel the request or set its deadline.
+func (eth *Eth) IsMiningCtx(ctx context.Context) (bool, error) {
 
 	pointer := &dto.RequestResult{}
 
-	err := eth.provider.SendRequest(pointer, "eth_mining", nil)
+	err := eth.provider.SendRequestCtx(ctx, pointer, "eth_mining", nil)
 
 	if err != nil {
 		return false, err
@@ -114,14 +133,20 @@ func (eth *Eth) IsMining() (bool, error) {
 // GetHashRate - Returns the number of hashes per second that the node is mining with.
 // Reference: https://github.com/ethereum/wiki/wiki/JSON-RPC#eth_hashrate
 // Parameters:
-//    - none
+//   - none
+//
 // Returns:
-// 	  - QUANTITY - number of hashes per second.
+//   - QUANTITY - number of hashes per second.
 func (eth *Eth) GetHashRate() (*big.Int, error) {
+	return eth.GetHashRateCtx(context.Background())
+}
+
+// GetHashRateCtx - Same as GetHashRate, using ctx to cancel the request or set its deadline.
+func (eth *Eth) GetHashRateCtx(ctx context.Context) (*big.Int, error) {
 
 	pointer := &dto.RequestResult{}
 
-	err := eth.provider.SendRequest(pointer, "eth_hashrate", nil)
+	err := eth.provider.SendRequestCtx(ctx, pointer, "eth_hashrate", nil)
 
 	if err != nil {
 		return nil, err
@@ -133,14 +158,20 @@ func (eth *Eth) GetHashRate() (*big.Int, error) {
 // GetGasPrice - Returns the current price per gas in wei.
 // Reference: https://github.com/ethereum/wiki/wiki/JSON-RPC#eth_gasprice
 // Parameters:
-//    - none
+//   - none
+//
 // Returns:
-// 	  - QUANTITY - integer of the current gas price in wei.
+//   - QUANTITY - integer of the current gas price in wei.
 func (eth *Eth) GetGasPrice() (*big.Int, error) {
+	return eth.GetGasPriceCtx(context.Background())
+}
+
+// GetGasPriceCtx - Same as GetGasPrice, using ctx to cancel the request or set its deadline.
+func (eth *Eth) GetGasPriceCtx(ctx context.Context) (*big.Int, error) {
 
 	pointer := &dto.RequestResult{}
 
-	err := eth.provider.SendRequest(pointer, "eth_gasPrice", nil)
+	err := eth.provider.SendRequestCtx(ctx, pointer, "eth_gasPrice", nil)
 
 	if err != nil {
 		return nil, err
@@ -152,14 +183,20 @@ func (eth *Eth) GetGasPrice() (*big.Int, error) {
 // ListAccounts - Returns a list of addresses owned by client.
 // Reference: https://github.com/ethereum/wiki/wiki/JSON-RPC#eth_accounts
 // Parameters:
-//    - none
+//   - none
+//
 // Returns:
-//    - Array of DATA, 20 Bytes - addresses owned by the client.
+//   - Array of DATA, 20 Bytes - addresses owned by the client.
 func (eth *Eth) ListAccounts() ([]string, error) {
+	return eth.ListAccountsCtx(context.Background())
+}
+
+// ListAccountsCtx - Same as ListAccounts, using ctx to cancel the request or set its deadline.
+func (eth *Eth) ListAccountsCtx(ctx context.Context) ([]string, error) {
 
 	pointer := &dto.RequestResult{}
 
-	err := eth.provider.SendRequest(pointer, "eth_accounts", nil)
+	err := eth.provider.SendRequestCtx(ctx, pointer, "eth_accounts", nil)
 
 	if err != nil {
 		return nil, err
@@ -172,14 +209,20 @@ func (eth *Eth) ListAccounts() ([]string, error) {
 // GetBlockNumber - Returns the number of most recent block.
 // Reference: https://github.com/ethereum/wiki/wiki/JSON-RPC#eth_blocknumber
 // Parameters:
-//    - none
+//   - none
+//
 // Returns:
-// 	  - QUANTITY - integer of the current block number the client is on.
+//   - QUANTITY - integer of the current block number the client is on.
 func (eth *Eth) GetBlockNumber() (*big.Int, error) {
+	return eth.GetBlockNumberCtx(context.Background())
+}
+
+// GetBlockNumberCtx - Same as GetBlockNumber, using ctx to cancel the request or set its deadline.
+func (eth *Eth) GetBlockNumberCtx(ctx context.Context) (*big.Int, error) {
 
 	pointer := &dto.RequestResult{}
 
-	err := eth.provider.SendRequest(pointer, "eth_blockNumber", nil)
+	err := eth.provider.SendRequestCtx(ctx, pointer, "eth_blockNumber", nil)
 
 	if err != nil {
 		return nil, err
@@ -191,11 +234,17 @@ func (eth *Eth) GetBlockNumber() (*big.Int, error) {
 // GetBalance - Returns the balance of the account of given address.
 // Reference: https://github.com/ethereum/wiki/wiki/JSON-RPC#eth_getbalance
 // Parameters:
-//    - DATA, 20 Bytes - address to check for balance.
-//	  - QUANTITY|TAG - integer block number, or the string "latest", "earliest" or "pending", see the default block parameter: https://github.com/ethereum/wiki/wiki/JSON-RPC#the-default-block-parameter
+//   - DATA, 20 Bytes - address to check for balance.
+//   - QUANTITY|TAG - integer block number, or the string "latest", "earliest" or "pending", see the default block parameter: https://github.com/ethereum/wiki/wiki/JSON-RPC#the-default-block-parameter
+//
 // Returns:
-// 	  - QUANTITY - integer of the current balance in wei.
+//   - QUANTITY - integer of the current balance in wei.
 func (eth *Eth) GetBalance(address string, defaultBlockParameter string) (*big.Int, error) {
+	return eth.GetBalanceCtx(context.Background(), address, defaultBlockParameter)
+}
+
+// GetBalanceCtx - Same as GetBalance, using ctx to cancel the request or set its deadline.
+func (eth *Eth) GetBalanceCtx(ctx context.Context, address string, defaultBlockParameter string) (*big.Int, error) {
 
 	params := make([]string, 2)
 	params[0] = address
@@ -203,7 +252,7 @@ func (eth *Eth) GetBalance(address string, defaultBlockParameter string) (*big.I
 
 	pointer := &dto.RequestResult{}
 
-	err := eth.provider.SendRequest(pointer, "eth_getBalance", params)
+	err := eth.provider.SendRequestCtx(ctx, pointer, "eth_getBalance", params)
 
 	if err != nil {
 		return nil, err
@@ -215,11 +264,17 @@ func (eth *Eth) GetBalance(address string, defaultBlockParameter string) (*big.I
 // GetTransactionCount -  Returns the number of transactions sent from an address.
 // Reference: https://github.com/ethereum/wiki/wiki/JSON-RPC#eth_gettransactionaccount
 // Parameters:
-//    - DATA, 20 Bytes - address to check for balance.
-//	  - QUANTITY|TAG - integer block number, or the string "latest", "earliest" or "pending", see the default block parameter: https://github.com/ethereum/wiki/wiki/JSON-RPC#the-default-block-parameter
+//   - DATA, 20 Bytes - address to check for balance.
+//   - QUANTITY|TAG - integer block number, or the string "latest", "earliest" or "pending", see the default block parameter: https://github.com/ethereum/wiki/wiki/JSON-RPC#the-default-block-parameter
+//
 // Returns:
-// 	  - QUANTITY - integer of the number of transactions sent from this address
+//   - QUANTITY - integer of the number of transactions sent from this address
 func (eth *Eth) GetTransactionCount(address string, defaultBlockParameter string) (*big.Int, error) {
+	return eth.GetTransactionCountCtx(context.Background(), address, defaultBlockParameter)
+}
+
+// GetTransactionCountCtx - Same as GetTransactionCount, using ctx to cancel the request or set its deadline.
+func (eth *Eth) GetTransactionCountCtx(ctx context.Context, address string, defaultBlockParameter string) (*big.Int, error) {
 
 	params := make([]string, 2)
 	params[0] = address
@@ -227,7 +282,7 @@ func (eth *Eth) GetTransactionCount(address string, defaultBlockParameter string
 
 	pointer := &dto.RequestResult{}
 
-	err := eth.provider.SendRequest(pointer, "eth_getTransactionCount", params)
+	err := eth.provider.SendRequestCtx(ctx, pointer, "eth_getTransactionCount", params)
 
 	if err != nil {
 		return nil, err
@@ -239,12 +294,18 @@ func (eth *Eth) GetTransactionCount(address string, defaultBlockParameter string
 // GetStorageAt - Returns the value from a storage position at a given address.
 // Reference: https://github.com/ethereum/wiki/wiki/JSON-RPC#eth_getstorageat
 // Parameters:
-//    - DATA, 20 Bytes - address of the storage.
-//	  - QUANTITY - integer of the position in the storage.
-//	  - QUANTITY|TAG - integer block number, or the string "latest", "earliest" or "pending", see the default block parameter: https://github.com/ethereum/wiki/wiki/JSON-RPC#the-default-block-parameter.
+//   - DATA, 20 Bytes - address of the storage.
+//   - QUANTITY - integer of the position in the storage.
+//   - QUANTITY|TAG - integer block number, or the string "latest", "earliest" or "pending", see the default block parameter: https://github.com/ethereum/wiki/wiki/JSON-RPC#the-default-block-parameter.
+//
 // Returns:
-// 	  - DATA - the value at this storage position.
+//   - DATA - the value at this storage position.
 func (eth *Eth) GetStorageAt(address string, position *big.Int, defaultBlockParameter string) (string, error) {
+	return eth.GetStorageAtCtx(context.Background(), address, position, defaultBlockParameter)
+}
+
+// GetStorageAtCtx - Same as GetStorageAt, using ctx to cancel the request or set its deadline.
+func (eth *Eth) GetStorageAtCtx(ctx context.Context, address string, position *big.Int, defaultBlockParameter string) (string, error) {
 
 	params := make([]string, 3)
 	params[0] = address
@@ -253,7 +314,7 @@ func (eth *Eth) GetStorageAt(address string, position *big.Int, defaultBlockPara
 
 	pointer := &dto.RequestResult{}
 
-	err := eth.provider.SendRequest(pointer, "eth_getstorageat", params)
+	err := eth.provider.SendRequestCtx(ctx, pointer, "eth_getStorageAt", params)
 
 	if err != nil {
 		return "", err
@@ -265,11 +326,17 @@ func (eth *Eth) GetStorageAt(address string, position *big.Int, defaultBlockPara
 // EstimateGas - Makes a call or transaction, which won't be added to the blockchain and returns the used gas, which can be used for estimating the used gas.
 // Reference: https://github.com/ethereum/wiki/wiki/JSON-RPC#eth_estimategas
 // Parameters:
-//    - See eth_call parameters, expect that all properties are optional. If no gas limit is specified geth uses the block gas limit from the pending block as an
-// 		upper bound. As a result the returned estimate might not be enough to executed the call/transaction when the amount of gas is higher than the pending block gas limit.
+//   - See eth_call parameters, expect that all properties are optional. If no gas limit is specified geth uses the block gas limit from the pending block as an
+//     upper bound. As a result the returned estimate might not be enough to executed the call/transaction when the amount of gas is higher than the pending block gas limit.
+//
 // Returns:
-//    - QUANTITY - the amount of gas used.
+//   - QUANTITY - the amount of gas used.
 func (eth *Eth) EstimateGas(transaction *dto.TransactionParameters) (*big.Int, error) {
+	return eth.EstimateGasCtx(context.Background(), transaction)
+}
+
+// EstimateGasCtx - Same as EstimateGas, using ctx to cancel the request or set its deadline.
+func (eth *Eth) EstimateGasCtx(ctx context.Context, transaction *dto.TransactionParameters) (*big.Int, error) {
 
 	params := make([]*dto.RequestTransactionParameters, 1)
 
@@ -277,7 +344,7 @@ func (eth *Eth) EstimateGas(transaction *dto.TransactionParameters) (*big.Int, e
 
 	pointer := &dto.RequestResult{}
 
-	err := eth.provider.SendRequest(&pointer, "eth_estimateGas", params)
+	err := eth.provider.SendRequestCtx(ctx, &pointer, "eth_estimateGas", params)
 
 	if err != nil {
 		return nil, err
@@ -289,28 +356,34 @@ func (eth *Eth) EstimateGas(transaction *dto.TransactionParameters) (*big.Int, e
 // GetTransactionByHash - Returns the information about a transaction requested by transaction hash.
 // Reference: https://github.com/ethereum/wiki/wiki/JSON-RPC#eth_gettransactionbyhash
 // Parameters:
-//    - DATA, 32 Bytes - hash of a transaction
+//   - DATA, 32 Bytes - hash of a transaction
+//
 // Returns:
-//    1. Object - A transaction object, or null when no transaction was found
-//    - hash: DATA, 32 Bytes - hash of the transaction.
-//    - nonce: QUANTITY - the number of transactions made by the sender prior to this one.
-//    - blockHash: DATA, 32 Bytes - hash of the block where this transaction was in. null when its pending.
-//    - blockNumber: QUANTITY - block number where this transaction was in. null when its pending.
-//    - transactionIndex: QUANTITY - integer of the transactions index position in the block. null when its pending.
-//    - from: DATA, 20 Bytes - address of the sender.
-//    - to: DATA, 20 Bytes - address of the receiver. null when its a contract creation transaction.
-//    - value: QUANTITY - value transferred in Wei.
-//    - gasPrice: QUANTITY - gas price provided by the sender in Wei.
-//    - gas: QUANTITY - gas provided by the sender.
-//    - input: DATA - the data send along with the transaction.
+//  1. Object - A transaction object, or null when no transaction was found
+//     - hash: DATA, 32 Bytes - hash of the transaction.
+//     - nonce: QUANTITY - the number of transactions made by the sender prior to this one.
+//     - blockHash: DATA, 32 Bytes - hash of the block where this transaction was in. null when its pending.
+//     - blockNumber: QUANTITY - block number where this transaction was in. null when its pending.
+//     - transactionIndex: QUANTITY - integer of the transactions index position in the block. null when its pending.
+//     - from: DATA, 20 Bytes - address of the sender.
+//     - to: DATA, 20 Bytes - address of the receiver. null when its a contract creation transaction.
+//     - value: QUANTITY - value transferred in Wei.
+//     - gasPrice: QUANTITY - gas price provided by the sender in Wei.
+//     - gas: QUANTITY - gas provided by the sender.
+//     - input: DATA - the data send along with the transaction.
 func (eth *Eth) GetTransactionByHash(hash string) (*dto.TransactionResponse, error) {
+	return eth.GetTransactionByHashCtx(context.Background(), hash)
+}
+
+// GetTransactionByHashCtx - Same as GetTransactionByHash, using ctx to cancel the request or set its deadline.
+func (eth *Eth) GetTransactionByHashCtx(ctx context.Context, hash string) (*dto.TransactionResponse, error) {
 
 	params := make([]string, 1)
 	params[0] = hash
 
 	pointer := &dto.RequestResult{}
 
-	err := eth.provider.SendRequest(pointer, "eth_getTransactionByHash", params)
+	err := eth.provider.SendRequestCtx(ctx, pointer, "eth_getTransactionByHash", params)
 
 	if err != nil {
 		return nil, err
@@ -323,22 +396,28 @@ func (eth *Eth) GetTransactionByHash(hash string) (*dto.TransactionResponse, err
 // GetTransactionByBlockHashAndIndex - Returns the information about a transaction requested by block hash.
 // Reference: https://github.com/ethereum/wiki/wiki/JSON-RPC#eth_getTransactionByBlockNumberAndIndex
 // Parameters:
-//    - DATA, 32 Bytes - hash of a block
-//    - QUANTITY, number - index of the transaction position
+//   - DATA, 32 Bytes - hash of a block
+//   - QUANTITY, number - index of the transaction position
+//
 // Returns:
-//    1. Object - A transaction object, or null when no transaction was found
-//    - hash: DATA, 32 Bytes - hash of the transaction.
-//    - nonce: QUANTITY - the number of transactions made by the sender prior to this one.
-//    - blockHash: DATA, 32 Bytes - hash of the block where this transaction was in. null when its pending.
-//    - blockNumber: QUANTITY - block number where this transaction was in. null when its pending.
-//    - transactionIndex: QUANTITY - integer of the transactions index position in the block. null when its pending.
-//    - from: DATA, 20 Bytes - address of the sender.
-//    - to: DATA, 20 Bytes - address of the receiver. null when its a contract creation transaction.
-//    - value: QUANTITY - value transferred in Wei.
-//    - gasPrice: QUANTITY - gas price provided by the sender in Wei.
-//    - gas: QUANTITY - gas provided by the sender.
-//    - input: DATA - the data send along with the transaction.
+//  1. Object - A transaction object, or null when no transaction was found
+//     - hash: DATA, 32 Bytes - hash of the transaction.
+//     - nonce: QUANTITY - the number of transactions made by the sender prior to this one.
+//     - blockHash: DATA, 32 Bytes - hash of the block where this transaction was in. null when its pending.
+//     - blockNumber: QUANTITY - block number where this transaction was in. null when its pending.
+//     - transactionIndex: QUANTITY - integer of the transactions index position in the block. null when its pending.
+//     - from: DATA, 20 Bytes - address of the sender.
+//     - to: DATA, 20 Bytes - address of the receiver. null when its a contract creation transaction.
+//     - value: QUANTITY - value transferred in Wei.
+//     - gasPrice: QUANTITY - gas price provided by the sender in Wei.
+//     - gas: QUANTITY - gas provided by the sender.
+//     - input: DATA - the data send along with the transaction.
 func (eth *Eth) GetTransactionByBlockHashAndIndex(hash string, index *big.Int) (*dto.TransactionResponse, error) {
+	return eth.GetTransactionByBlockHashAndIndexCtx(context.Background(), hash, index)
+}
+
+// GetTransactionByBlockHashAndIndexCtx - Same as GetTransactionByBlockHashAndIndex, using ctx to cancel the request or set its deadline.
+func (eth *Eth) GetTransactionByBlockHashAndIndexCtx(ctx context.Context, hash string, index *big.Int) (*dto.TransactionResponse, error) {
 
 	// ensure that the hash is correctlyformatted
 	if strings.HasPrefix(hash, "0x") {
@@ -359,7 +438,7 @@ func (eth *Eth) GetTransactionByBlockHashAndIndex(hash string, index *big.Int) (
 
 	pointer := &dto.RequestResult{}
 
-	err := eth.provider.SendRequest(pointer, "eth_getTransactionByBlockHashAndIndex", params)
+	err := eth.provider.SendRequestCtx(ctx, pointer, "eth_getTransactionByBlockHashAndIndex", params)
 
 	if err != nil {
 		return nil, err
@@ -371,22 +450,28 @@ func (eth *Eth) GetTransactionByBlockHashAndIndex(hash string, index *big.Int) (
 // GetTransactionByBlockNumberAndIndex - Returns the information about a transaction requested by block index.
 // Reference: https://github.com/ethereum/wiki/wiki/JSON-RPC#eth_getTransactionByBlockNumberAndIndex
 // Parameters:
-//    - QUANTITY, number - block number
-//    - QUANTITY, number - transaction index in block
+//   - QUANTITY, number - block number
+//   - QUANTITY, number - transaction index in block
+//
 // Returns:
-//    1. Object - A transaction object, or null when no transaction was found
-//    - hash: DATA, 32 Bytes - hash of the transaction.
-//    - nonce: QUANTITY - the number of transactions made by the sender prior to this one.
-//    - blockHash: DATA, 32 Bytes - hash of the block where this transaction was in. null when its pending.
-//    - blockNumber: QUANTITY - block number where this transaction was in. null when its pending.
-//    - transactionIndex: QUANTITY - integer of the transactions index position in the block. null when its pending.
-//    - from: DATA, 20 Bytes - address of the sender.
-//    - to: DATA, 20 Bytes - address of the receiver. null when its a contract creation transaction.
-//    - value: QUANTITY - value transferred in Wei.
-//    - gasPrice: QUANTITY - gas price provided by the sender in Wei.
-//    - gas: QUANTITY - gas provided by the sender.
-//    - input: DATA - the data send along with the transaction.
+//  1. Object - A transaction object, or null when no transaction was found
+//     - hash: DATA, 32 Bytes - hash of the transaction.
+//     - nonce: QUANTITY - the number of transactions made by the sender prior to this one.
+//     - blockHash: DATA, 32 Bytes - hash of the block where this transaction was in. null when its pending.
+//     - blockNumber: QUANTITY - block number where this transaction was in. null when its pending.
+//     - transactionIndex: QUANTITY - integer of the transactions index position in the block. null when its pending.
+//     - from: DATA, 20 Bytes - address of the sender.
+//     - to: DATA, 20 Bytes - address of the receiver. null when its a contract creation transaction.
+//     - value: QUANTITY - value transferred in Wei.
+//     - gasPrice: QUANTITY - gas price provided by the sender in Wei.
+//     - gas: QUANTITY - gas provided by the sender.
+//     - input: DATA - the data send along with the transaction.
 func (eth *Eth) GetTransactionByBlockNumberAndIndex(blockIndex *big.Int, index *big.Int) (*dto.TransactionResponse, error) {
+	return eth.GetTransactionByBlockNumberAndIndexCtx(context.Background(), blockIndex, index)
+}
+
+// GetTransactionByBlockNumberAndIndexCtx - Same as GetTransactionByBlockNumberAndIndex, using ctx to cancel the request or set its deadline.
+func (eth *Eth) GetTransactionByBlockNumberAndIndexCtx(ctx context.Context, blockIndex *big.Int, index *big.Int) (*dto.TransactionResponse, error) {
 
 	params := make([]string, 2)
 	params[0] = utils.IntToHex(blockIndex)
@@ -394,7 +479,7 @@ func (eth *Eth) GetTransactionByBlockNumberAndIndex(blockIndex *big.Int, index *
 
 	pointer := &dto.RequestResult{}
 
-	err := eth.provider.SendRequest(pointer, "eth_getTransactionByBlockNumberAndIndex", params)
+	err := eth.provider.SendRequestCtx(ctx, pointer, "eth_getTransactionByBlockNumberAndIndex", params)
 
 	if err != nil {
 		return nil, err
@@ -407,25 +492,32 @@ func (eth *Eth) GetTransactionByBlockNumberAndIndex(blockIndex *big.Int, index *
 // SendTransaction - Creates new message call transaction or a contract creation, if the data field contains code.
 // Reference: https://github.com/ethereum/wiki/wiki/JSON-RPC#eth_sendtransaction
 // Parameters:
-//    1. Object - The transaction object
-//    - from: 		DATA, 20 Bytes - The address the transaction is send from.
-//    - to: 		DATA, 20 Bytes - (optional when creating new contract) The address the transaction is directed to.
-//    - gas: 		QUANTITY - (optional, default: 90000) Integer of the gas provided for the transaction execution. It will return unused gas.
-//    - gasPrice: 	QUANTITY - (optional, default: To-Be-Determined) Integer of the gasPrice used for each paid gas
-//    - value: 		QUANTITY - (optional) Integer of the value send with this transaction
-//    - data: 		DATA - The compiled code of a contract OR the hash of the invoked method signature and encoded parameters. For details see Ethereum Contract ABI (https://github.com/ethereum/wiki/wiki/Ethereum-Contract-ABI)
-//    - nonce: 		QUANTITY - (optional) Integer of a nonce. This allows to overwrite your own pending transactions that use the same nonce.
+//  1. Object - The transaction object
+//     - from: 		DATA, 20 Bytes - The address the transaction is send from.
+//     - to: 		DATA, 20 Bytes - (optional when creating new contract) The address the transaction is directed to.
+//     - gas: 		QUANTITY - (optional, default: 90000) Integer of the gas provided for the transaction execution. It will return unused gas.
+//     - gasPrice: 	QUANTITY - (optional, default: To-Be-Determined) Integer of the gasPrice used for each paid gas
+//     - value: 		QUANTITY - (optional) Integer of the value send with this transaction
+//     - data: 		DATA - The compiled code of a contract OR the hash of the invoked method signature and encoded parameters. For details see Ethereum Contract ABI (https://github.com/ethereum/wiki/wiki/Ethereum-Contract-ABI)
+//     - nonce: 		QUANTITY - (optional) Integer of a nonce. This allows to overwrite your own pending transactions that use the same nonce.
+//
 // Returns:
-//	  - DATA, 32 Bytes - the transaction hash, or the zero hash if the transaction is not yet available.
+//   - DATA, 32 Bytes - the transaction hash, or the zero hash if the transaction is not yet available.
+//
 // Use eth_getTransactionReceipt to get the contract address, after the transaction was mined, when you created a contract.
 func (eth *Eth) SendTransaction(transaction *dto.TransactionParameters) (string, error) {
+	return eth.SendTransactionCtx(context.Background(), transaction)
+}
+
+// SendTransactionCtx - Same as SendTransaction, using ctx to cancel the request or set its deadline.
+func (eth *Eth) SendTransactionCtx(ctx context.Context, transaction *dto.TransactionParameters) (string, error) {
 
 	params := make([]*dto.RequestTransactionParameters, 1)
 	params[0] = transaction.Transform()
 
 	pointer := &dto.RequestResult{}
 
-	err := eth.provider.SendRequest(&pointer, "eth_sendTransaction", params)
+	err := eth.provider.SendRequestCtx(ctx, &pointer, "eth_sendTransaction", params)
 
 	if err != nil {
 		return "", err
@@ -438,37 +530,44 @@ func (eth *Eth) SendTransaction(transaction *dto.TransactionParameters) (string,
 // SignTransaction - Signs transactions without dispatching it to the network. It can be later submitted using eth_sendRawTransaction.
 // Reference: https://wiki.parity.io/JSONRPC-eth-module.html#eth_signtransaction
 // Parameters:
-//    1. Object - The transaction call object
-//    - from: 		DATA, 20 Bytes - The address the transaction is send from.
-//    - to: 		DATA, 20 Bytes - (optional when creating new contract) The address the transaction is directed to.
-//    - gas: 		QUANTITY - (optional, default: 90000) Integer of the gas provided for the transaction execution. It will return unused gas.
-//    - gasPrice: 	QUANTITY - (optional, default: To-Be-Determined) Integer of the gasPrice used for each paid gas
-//    - value: 		QUANTITY - (optional) Integer of the value send with this transaction
-//    - data: 		DATA - The compiled code of a contract OR the hash of the invoked method signature and encoded parameters. For details see Ethereum Contract ABI (https://github.com/ethereum/wiki/wiki/Ethereum-Contract-ABI)
-//    - nonce: 		QUANTITY - (optional) Integer of a nonce. This allows to overwrite your own pending transactions that use the same nonce.
+//  1. Object - The transaction call object
+//     - from: 		DATA, 20 Bytes - The address the transaction is send from.
+//     - to: 		DATA, 20 Bytes - (optional when creating new contract) The address the transaction is directed to.
+//     - gas: 		QUANTITY - (optional, default: 90000) Integer of the gas provided for the transaction execution. It will return unused gas.
+//     - gasPrice: 	QUANTITY - (optional, default: To-Be-Determined) Integer of the gasPrice used for each paid gas
+//     - value: 		QUANTITY - (optional) Integer of the value send with this transaction
+//     - data: 		DATA - The compiled code of a contract OR the hash of the invoked method signature and encoded parameters. For details see Ethereum Contract ABI (https://github.com/ethereum/wiki/wiki/Ethereum-Contract-ABI)
+//     - nonce: 		QUANTITY - (optional) Integer of a nonce. This allows to overwrite your own pending transactions that use the same nonce.
+//
 // Returns:
-//    1. Object - A transaction sign result object
-//    - raw: DATA - The signed, RLP encoded transaction.
-//    - tx: Object - A transaction object
-//      - hash: DATA, 32 Bytes - hash of the transaction.
-//      - nonce: QUANTITY - the number of transactions made by the sender prior to this one.
-//      - blockHash: DATA, 32 Bytes - hash of the block where this transaction was in. null when its pending.
-//      - blockNumber: QUANTITY - block number where this transaction was in. null when its pending.
-//      - transactionIndex: QUANTITY - integer of the transactions index position in the block. null when its pending.
-//      - from: DATA, 20 Bytes - address of the sender.
-//      - to: DATA, 20 Bytes - address of the receiver. null when its a contract creation transaction.
-//      - value: QUANTITY - value transferred in Wei.
-//      - gasPrice: QUANTITY - gas price provided by the sender in Wei.
-//      - gas: QUANTITY - gas provided by the sender.
-//      - input: DATA - the data send along with the transaction.
+//  1. Object - A transaction sign result object
+//     - raw: DATA - The signed, RLP encoded transaction.
+//     - tx: Object - A transaction object
+//     - hash: DATA, 32 Bytes - hash of the transaction.
+//     - nonce: QUANTITY - the number of transactions made by the sender prior to this one.
+//     - blockHash: DATA, 32 Bytes - hash of the block where this transaction was in. null when its pending.
+//     - blockNumber: QUANTITY - block number where this transaction was in. null when its pending.
+//     - transactionIndex: QUANTITY - integer of the transactions index position in the block. null when its pending.
+//     - from: DATA, 20 Bytes - address of the sender.
+//     - to: DATA, 20 Bytes - address of the receiver. null when its a contract creation transaction.
+//     - value: QUANTITY - value transferred in Wei.
+//     - gasPrice: QUANTITY - gas price provided by the sender in Wei.
+//     - gas: QUANTITY - gas provided by the sender.
+//     - input: DATA - the data send along with the transaction.
+//
 // Use eth_sendRawTransaction to submit the transaction after it was signed.
 func (eth *Eth) SignTransaction(transaction *dto.TransactionParameters) (*dto.SignTransactionResponse, error) {
+	return eth.SignTransactionCtx(context.Background(), transaction)
+}
+
+// SignTransactionCtx - Same as SignTransaction, using ctx to cancel the request or set its deadline.
+func (eth *Eth) SignTransactionCtx(ctx context.Context, transaction *dto.TransactionParameters) (*dto.SignTransactionResponse, error) {
 	params := make([]*dto.RequestTransactionParameters, 1)
 	params[0] = transaction.Transform()
 
 	pointer := &dto.RequestResult{}
 
-	err := eth.provider.SendRequest(&pointer, "eth_signTransaction", params)
+	err := eth.provider.SendRequestCtx(ctx, &pointer, "eth_signTransaction", params)
 
 	if err != nil {
 		return &dto.SignTransactionResponse{}, err
@@ -480,17 +579,23 @@ func (eth *Eth) SignTransaction(transaction *dto.TransactionParameters) (*dto.Si
 // Call - Executes a new message call immediately without creating a transaction on the block chain.
 // Reference: https://github.com/ethereum/wiki/wiki/JSON-RPC#eth_call
 // Parameters:
-//    1. Object - The transaction call object
-//    - from: 		DATA, 20 Bytes - The address the transaction is send from.
-//    - to: 		DATA, 20 Bytes - (optional when creating new contract) The address the transaction is directed to.
-//    - gas: 		QUANTITY - (optional, default: 90000) Integer of the gas provided for the transaction execution. It will return unused gas.
-//    - gasPrice: 	QUANTITY - (optional, default: To-Be-Determined) Integer of the gasPrice used for each paid gas
-//    - value: 		QUANTITY - (optional) Integer of the value send with this transaction
-//    - data: 		DATA - The compiled code of a contract OR the hash of the invoked method signature and encoded parameters. For details see Ethereum Contract ABI (https://github.com/ethereum/wiki/wiki/Ethereum-Contract-ABI)
-//	  2. QUANTITY|TAG - integer block number, or the string "latest", "earliest" or "pending", see the default block parameter: https://github.com/ethereum/wiki/wiki/JSON-RPC#the-default-block-parameter
+//  1. Object - The transaction call object
+//     - from: 		DATA, 20 Bytes - The address the transaction is send from.
+//     - to: 		DATA, 20 Bytes - (optional when creating new contract) The address the transaction is directed to.
+//     - gas: 		QUANTITY - (optional, default: 90000) Integer of the gas provided for the transaction execution. It will return unused gas.
+//     - gasPrice: 	QUANTITY - (optional, default: To-Be-Determined) Integer of the gasPrice used for each paid gas
+//     - value: 		QUANTITY - (optional) Integer of the value send with this transaction
+//     - data: 		DATA - The compiled code of a contract OR the hash of the invoked method signature and encoded parameters. For details see Ethereum Contract ABI (https://github.com/ethereum/wiki/wiki/Ethereum-Contract-ABI)
+//  2. QUANTITY|TAG - integer block number, or the string "latest", "earliest" or "pending", see the default block parameter: https://github.com/ethereum/wiki/wiki/JSON-RPC#the-default-block-parameter
+//
 // Returns:
-//	  - DATA - the return value of executed contract.
+//   - DATA - the return value of executed contract.
 func (eth *Eth) Call(transaction *dto.TransactionParameters) (*dto.RequestResult, error) {
+	return eth.CallCtx(context.Background(), transaction)
+}
+
+// CallCtx - Same as Call, using ctx to cancel the request or set its deadline.
+func (eth *Eth) CallCtx(ctx context.Context, transaction *dto.TransactionParameters) (*dto.RequestResult, error) {
 
 	params := make([]interface{}, 2)
 	params[0] = transaction.Transform()
@@ -498,7 +603,7 @@ func (eth *Eth) Call(transaction *dto.TransactionParameters) (*dto.RequestResult
 
 	pointer := &dto.RequestResult{}
 
-	err := eth.provider.SendRequest(&pointer, "eth_call", params)
+	err := eth.provider.SendRequestCtx(ctx, &pointer, "eth_call", params)
 
 	if err != nil {
 		return nil, err
@@ -511,17 +616,23 @@ func (eth *Eth) Call(transaction *dto.TransactionParameters) (*dto.RequestResult
 // CompileSolidity - Returns compiled solidity code.
 // Reference: https://github.com/ethereum/wiki/wiki/JSON-RPC#eth_compilesolidity
 // Parameters:
-//    1. String - The source code.
+//  1. String - The source code.
+//
 // Returns:
-//	  - DATA - The compiled source code.
+//   - DATA - The compiled source code.
 func (eth *Eth) CompileSolidity(sourceCode string) (types.ComplexString, error) {
+	return eth.CompileSolidityCtx(context.Background(), sourceCode)
+}
+
+// CompileSolidityCtx - Same as CompileSolidity, using ctx to cancel the request or set its deadline.
+func (eth *Eth) CompileSolidityCtx(ctx context.Context, sourceCode string) (types.ComplexString, error) {
 
 	params := make([]string, 1)
 	params[0] = sourceCode
 
 	pointer := &dto.RequestResult{}
 
-	err := eth.provider.SendRequest(pointer, "eth_compileSolidity", params)
+	err := eth.provider.SendRequestCtx(ctx, pointer, "eth_compileSolidity", params)
 
 	if err != nil {
 		return "", err
@@ -534,25 +645,31 @@ func (eth *Eth) CompileSolidity(sourceCode string) (types.ComplexString, error)
 // GetTransactionReceipt - Returns compiled solidity code.
 // Reference: https://github.com/ethereum/wiki/wiki/JSON-RPC#eth_gettransactionreceipt
 // Parameters:
-//    1. DATA, 32 Bytes - hash of a transaction.
+//  1. DATA, 32 Bytes - hash of a transaction.
+//
 // Returns:
-//	  1. Object - A transaction receipt object, or null when no receipt was found:
-//    - transactionHash: 		DATA, 32 Bytes - hash of the transaction.
-//    - transactionIndex: 		QUANTITY - integer of the transactions index position in the block.
-//    - blockHash: 				DATA, 32 Bytes - hash of the block where this transaction was in.
-//    - blockNumber:			QUANTITY - block number where this transaction was in.
-//    - cumulativeGasUsed: 		QUANTITY - The total amount of gas used when this transaction was executed in the block.
-//    - gasUsed: 				QUANTITY - The amount of gas used by this specific transaction alone.
-//    - contractAddress: 		DATA, 20 Bytes - The contract address created, if the transaction was a contract creation, otherwise null.
-//    - logs: 					Array - Array of log objects, which this transaction generated.
+//  1. Object - A transaction receipt object, or null when no receipt was found:
+//     - transactionHash: 		DATA, 32 Bytes - hash of the transaction.
+//     - transactionIndex: 		QUANTITY - integer of the transactions index position in the block.
+//     - blockHash: 				DATA, 32 Bytes - hash of the block where this transaction was in.
+//     - blockNumber:			QUANTITY - block number where this transaction was in.
+//     - cumulativeGasUsed: 		QUANTITY - The total amount of gas used when this transaction was executed in the block.
+//     - gasUsed: 				QUANTITY - The amount of gas used by this specific transaction alone.
+//     - contractAddress: 		DATA, 20 Bytes - The contract address created, if the transaction was a contract creation, otherwise null.
+//     - logs: 					Array - Array of log objects, which this transaction generated.
 func (eth *Eth) GetTransactionReceipt(hash string) (*dto.TransactionReceipt, error) {
+	return eth.GetTransactionReceiptCtx(context.Background(), hash)
+}
+
+// GetTransactionReceiptCtx - Same as GetTransactionReceipt, using ctx to cancel the request or set its deadline.
+func (eth *Eth) GetTransactionReceiptCtx(ctx context.Context, hash string) (*dto.TransactionReceipt, error) {
 
 	params := make([]string, 1)
 	params[0] = hash
 
 	pointer := &dto.RequestResult{}
 
-	err := eth.provider.SendRequest(pointer, "eth_getTransactionReceipt", params)
+	err := eth.provider.SendRequestCtx(ctx, pointer, "eth_getTransactionReceipt", params)
 
 	if err != nil {
 		return nil, err
@@ -565,12 +682,18 @@ func (eth *Eth) GetTransactionReceipt(hash string) (*dto.TransactionReceipt, err
 // GetBlockByNumber - Returns the information about a block requested by number.
 // Reference: https://github.com/ethereum/wiki/wiki/JSON-RPC#eth_getblockbynumber
 // Parameters:
-//    - number, QUANTITY - number of block
-//    - transactionDetails, bool - indicate if we should have or not the details of the transactions of the block
+//   - number, QUANTITY - number of block
+//   - transactionDetails, bool - indicate if we should have or not the details of the transactions of the block
+//
 // Returns:
-//    1. Object - A block object, or null when no transaction was found
-//    2. error
+//  1. Object - A block object, or null when no transaction was found
+//  2. error
 func (eth *Eth) GetBlockByNumber(number *big.Int, transactionDetails bool) (*dto.Block, error) {
+	return eth.GetBlockByNumberCtx(context.Background(), number, transactionDetails)
+}
+
+// GetBlockByNumberCtx - Same as GetBlockByNumber, using ctx to cancel the request or set its deadline.
+func (eth *Eth) GetBlockByNumberCtx(ctx context.Context, number *big.Int, transactionDetails bool) (*dto.Block, error) {
 
 	params := make([]interface{}, 2)
 	params[0] = utils.IntToHex(number)
@@ -578,7 +701,7 @@ func (eth *Eth) GetBlockByNumber(number *big.Int, transactionDetails bool) (*dto
 
 	pointer := &dto.RequestResult{}
 
-	err := eth.provider.SendRequest(pointer, "eth_getBlockByNumber", params)
+	err := eth.provider.SendRequestCtx(ctx, pointer, "eth_getBlockByNumber", params)
 
 	if err != nil {
 		return nil, err
@@ -590,11 +713,17 @@ func (eth *Eth) GetBlockByNumber(number *big.Int, transactionDetails bool) (*dto
 // GetBlockTransactionCountByHash
 // Reference: https://github.com/ethereum/wiki/wiki/JSON-RPC#eth_getblocktransactioncountbyhash
 // Parameters:
-//    - DATA, 32 bytes - block hash
+//   - DATA, 32 bytes - block hash
+//
 // Returns:
-//    1. QUANTITY, number - number of transactions in the block
-//    2. error
+//  1. QUANTITY, number - number of transactions in the block
+//  2. error
 func (eth *Eth) GetBlockTransactionCountByHash(hash string) (*big.Int, error) {
+	return eth.GetBlockTransactionCountByHashCtx(context.Background(), hash)
+}
+
+// GetBlockTransactionCountByHashCtx - Same as GetBlockTransactionCountByHash, using ctx to cancel the request or set its deadline.
+func (eth *Eth) GetBlockTransactionCountByHashCtx(ctx context.Context, hash string) (*big.Int, error) {
 	// ensure that the hash is correctlyformatted
 	if strings.HasPrefix(hash, "0x") {
 		if len(hash) != 66 {
@@ -609,7 +738,7 @@ func (eth *Eth) GetBlockTransactionCountByHash(hash string) (*big.Int, error) {
 
 	pointer := &dto.RequestResult{}
 
-	err := eth.provider.SendRequest(pointer, "eth_getBlockTransactionCountByHash", []string{hash})
+	err := eth.provider.SendRequestCtx(ctx, pointer, "eth_getBlockTransactionCountByHash", []string{hash})
 
 	if err != nil {
 		return nil, err
@@ -621,17 +750,23 @@ func (eth *Eth) GetBlockTransactionCountByHash(hash string) (*big.Int, error) {
 // GetBlockTransactionCountByNumber - Returns the number of transactions in a block matching the given block number
 // Reference: https://github.com/ethereum/wiki/wiki/JSON-RPC#eth_getblocktransactioncountbynumber
 // Parameters:
-//    - QUANTITY|TAG - integer of a block number, or the string "earliest", "latest" or "pending", as in the default block parameter
+//   - QUANTITY|TAG - integer of a block number, or the string "earliest", "latest" or "pending", as in the default block parameter
+//
 // Returns:
-//    - QUANTITY - integer of the number of transactions in this block
+//   - QUANTITY - integer of the number of transactions in this block
 func (eth *Eth) GetBlockTransactionCountByNumber(defaultBlockParameter string) (*big.Int, error) {
+	return eth.GetBlockTransactionCountByNumberCtx(context.Background(), defaultBlockParameter)
+}
+
+// GetBlockTransactionCountByNumberCtx - Same as GetBlockTransactionCountByNumber, using ctx to cancel the request or set its deadline.
+func (eth *Eth) GetBlockTransactionCountByNumberCtx(ctx context.Context, defaultBlockParameter string) (*big.Int, error) {
 
 	params := make([]string, 1)
 	params[0] = defaultBlockParameter
 
 	pointer := &dto.RequestResult{}
 
-	err := eth.provider.SendRequest(pointer, "eth_getBlockTransactionCountByNumber", params)
+	err := eth.provider.SendRequestCtx(ctx, pointer, "eth_getBlockTransactionCountByNumber", params)
 
 	if err != nil {
 		return nil, err
@@ -643,12 +778,18 @@ func (eth *Eth) GetBlockTransactionCountByNumber(defaultBlockParameter string) (
 // GetBlockByHash - Returns information about a block by hash.
 // Reference: https://github.com/ethereum/wiki/wiki/JSON-RPC#eth_getblockbyhash
 // Parameters:
-//    - DATA, 32 bytes - Hash of a block
-//    - transactionDetails, bool - indicate if we should have or not the details of the transactions of the block
+//   - DATA, 32 bytes - Hash of a block
+//   - transactionDetails, bool - indicate if we should have or not the details of the transactions of the block
+//
 // Returns:
-//    1. Object - A block object, or null when no transaction was found
-//    2. error
+//  1. Object - A block object, or null when no transaction was found
+//  2. error
 func (eth *Eth) GetBlockByHash(hash string, transactionDetails bool) (*dto.Block, error) {
+	return eth.GetBlockByHashCtx(context.Background(), hash, transactionDetails)
+}
+
+// GetBlockByHashCtx - Same as GetBlockByHash, using ctx to cancel the request or set its deadline.
+func (eth *Eth) GetBlockByHashCtx(ctx context.Context, hash string, transactionDetails bool) (*dto.Block, error) {
 	// ensure that the hash is correctlyformatted
 	if strings.HasPrefix(hash, "0x") {
 		if len(hash) != 66 {
@@ -667,7 +808,7 @@ func (eth *Eth) GetBlockByHash(hash string, transactionDetails bool) (*dto.Block
 
 	pointer := &dto.RequestResult{}
 
-	err := eth.provider.SendRequest(pointer, "eth_getBlockByHash", params)
+	err := eth.provider.SendRequestCtx(ctx, pointer, "eth_getBlockByHash", params)
 
 	if err != nil {
 		return nil, err
@@ -679,11 +820,17 @@ func (eth *Eth) GetBlockByHash(hash string, transactionDetails bool) (*dto.Block
 // GetUncleCountByBlockHash - Returns the number of uncles in a block from a block matching the given block hash.
 // Reference: https://github.com/ethereum/wiki/wiki/JSON-RPC#eth_getunclecountbyblockhash
 // Parameters:
-//    - DATA, 32 bytes - Hash of a block
+//   - DATA, 32 bytes - Hash of a block
+//
 // Returns:
-//    - QUANTITY, number - integer of the number of uncles in this block
-//    - error
+//   - QUANTITY, number - integer of the number of uncles in this block
+//   - error
 func (eth *Eth) GetUncleCountByBlockHash(hash string) (*big.Int, error) {
+	return eth.GetUncleCountByBlockHashCtx(context.Background(), hash)
+}
+
+// GetUncleCountByBlockHashCtx - Same as GetUncleCountByBlockHash, using ctx to cancel the request or set its deadline.
+func (eth *Eth) GetUncleCountByBlockHashCtx(ctx context.Context, hash string) (*big.Int, error) {
 	// ensure that the hash has been correctly formatted
 	if strings.HasPrefix(hash, "0x") {
 		if len(hash) != 66 {
@@ -701,7 +848,7 @@ func (eth *Eth) GetUncleCountByBlockHash(hash string) (*big.Int, error) {
 
 	pointer := &dto.RequestResult{}
 
-	err := eth.provider.SendRequest(pointer, "eth_getUncleCountByBlockHash", params)
+	err := eth.provider.SendRequestCtx(ctx, pointer, "eth_getUncleCountByBlockHash", params)
 
 	if err != nil {
 		return nil, err
@@ -713,11 +860,17 @@ func (eth *Eth) GetUncleCountByBlockHash(hash string) (*big.Int, error) {
 // GetUncleCountByBlockNumber - Returns the number of uncles in a block from a block matching the given block number.
 // Reference: https://github.com/ethereum/wiki/wiki/JSON-RPC#eth_getunclecountbyblocknumber
 // Parameters:
-//    - QUANTITY, number - integer of a block number
+//   - QUANTITY, number - integer of a block number
+//
 // Returns:
-//    - QUANTITY, number - integer of the number of uncles in this block
-//    - error
+//   - QUANTITY, number - integer of the number of uncles in this block
+//   - error
 func (eth *Eth) GetUncleCountByBlockNumber(quantity *big.Int) (*big.Int, error) {
+	return eth.GetUncleCountByBlockNumberCtx(context.Background(), quantity)
+}
+
+// GetUncleCountByBlockNumberCtx - Same as GetUncleCountByBlockNumber, using ctx to cancel the request or set its deadline.
+func (eth *Eth) GetUncleCountByBlockNumberCtx(ctx context.Context, quantity *big.Int) (*big.Int, error) {
 	// ensure that the hash has been correctly formatted
 
 	params := make([]string, 1)
@@ -725,7 +878,7 @@ func (eth *Eth) GetUncleCountByBlockNumber(quantity *big.Int) (*big.Int, error)
 
 	pointer := &dto.RequestResult{}
 
-	err := eth.provider.SendRequest(pointer, "eth_getUncleCountByBlockNumber", params)
+	err := eth.provider.SendRequestCtx(ctx, pointer, "eth_getUncleCountByBlockNumber", params)
 
 	if err != nil {
 		return nil, err
@@ -737,11 +890,17 @@ func (eth *Eth) GetUncleCountByBlockNumber(quantity *big.Int) (*big.Int, error)
 // GetCode - Returns code at a given address
 // Reference: https://github.com/ethereum/wiki/wiki/JSON-RPC#eth_getcode
 // Parameters:
-//    - DATA, 20 Bytes - address
-//	  - QUANTITY|TAG - integer block number, or the string "latest", "earliest" or "pending", see the default block parameter: https://github.com/ethereum/wiki/wiki/JSON-RPC#the-default-block-parameter
+//   - DATA, 20 Bytes - address
+//   - QUANTITY|TAG - integer block number, or the string "latest", "earliest" or "pending", see the default block parameter: https://github.com/ethereum/wiki/wiki/JSON-RPC#the-default-block-parameter
+//
 // Returns:
-//    - DATA - the code from the given address.
+//   - DATA - the code from the given address.
 func (eth *Eth) GetCode(address string, defaultBlockParameter string) (string, error) {
+	return eth.GetCodeCtx(context.Background(), address, defaultBlockParameter)
+}
+
+// GetCodeCtx - Same as GetCode, using ctx to cancel the request or set its deadline.
+func (eth *Eth) GetCodeCtx(ctx context.Context, address string, defaultBlockParameter string) (string, error) {
 
 	params := make([]string, 2)
 	params[0] = address
@@ -749,7 +908,7 @@ func (eth *Eth) GetCode(address string, defaultBlockParameter string) (string, e
 
 	pointer := &dto.RequestResult{}
 
-	err := eth.provider.SendRequest(pointer, "eth_getCode", params)
+	err := eth.provider.SendRequestCtx(ctx, pointer, "eth_getCode", params)
 
 	if err != nil {
 		return "", err
@@ -757,3 +916,40 @@ func (eth *Eth) GetCode(address string, defaultBlockParameter string) (string, e
 
 	return pointer.ToString()
 }
+
+// GetLogs - Returns the logs matching the filter.
+// Reference: https://github.com/ethereum/wiki/wiki/JSON-RPC#eth_getlogs
+// Parameters:
+//   - Object - the filter:
+//   - fromBlock: 	QUANTITY|TAG - (optional, default: "latest") the first block searched
+//   - toBlock: 	QUANTITY|TAG - (optional, default: "latest") the last block searched
+//   - blockHash: 	DATA, 32 Bytes - (optional) the only block searched, instead of fromBlock and toBlock
+//   - address: 	DATA|Array, 20 Bytes - (optional) the contract addresses emitting the logs
+//   - topics: 	Array of DATA - (optional) the accepted topics of each position
+//
+// Returns:
+//   - Array - the log objects
+func (eth *Eth) GetLogs(filter *dto.FilterParameters) ([]dto.TransactionLogs, error) {
+	return eth.GetLogsCtx(context.Background(), filter)
+}
+
+// GetLogsCtx - Same as GetLogs, using ctx to cancel the request or set its deadline.
+func (eth *Eth) GetLogsCtx(ctx context.Context, filter *dto.FilterParameters) ([]dto.TransactionLogs, error) {
+
+	if filter == nil {
+		filter = &dto.FilterParameters{}
+	}
+
+	params := make([]interface{}, 1)
+	params[0] = filter
+
+	pointer := &dto.RequestResult{}
+
+	err := eth.provider.SendRequestCtx(ctx, pointer, "eth_getLogs", params)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return pointer.ToTransactionLogs()
+}
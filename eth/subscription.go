@@ -0,0 +1,332 @@
+/********************************************************************************
+   This file is part of go-web3.
+   go-web3 is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-web3 is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-web3.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file subscription.go
+ */
+
+package eth
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	"github.com/cellcycle/go-web3/constants"
+	"github.com/cellcycle/go-web3/dto"
+	"github.com/cellcycle/go-web3/providers"
+)
+
+// subscription - Decodes the notifications of a provider subscription.
+// Decoding errors are reported on the error channel without ending it.
+type subscription struct {
+	raw    *providers.Subscription
+	errors chan error
+	quit   chan struct{}
+	once   sync.Once
+}
+
+// Err - Receives the decoding errors, the *providers.GapError of the blocks not
+// backfilled after a reconnection and the error that ended the subscription.
+// It is closed when the subscription ends.
+func (sub *subscription) Err() <-chan error {
+	return sub.errors
+}
+
+// ID - The id the node gave to the subscription
+func (sub *subscription) ID() string {
+	return sub.raw.ID()
+}
+
+// Unsubscribe - Cancels the subscription on the node
+func (sub *subscription) Unsubscribe() error {
+	return sub.UnsubscribeCtx(context.Background())
+}
+
+// UnsubscribeCtx - Same as Unsubscribe, using ctx to cancel the request or set its deadline.
+func (sub *subscription) UnsubscribeCtx(ctx context.Context) error {
+	sub.once.Do(func() { close(sub.quit) })
+	return sub.raw.UnsubscribeCtx(ctx)
+}
+
+func (eth *Eth) subscribe(ctx context.Context, sub *subscription, args ...interface{}) error {
+
+	provider, ok := eth.provider.(providers.SubscriptionProvider)
+	if !ok {
+		return customerror.SUBSCRIPTIONSUNSUPPORTED
+	}
+
+	raw, err := provider.SubscribeCtx(ctx, "eth", args...)
+	if err != nil {
+		return err
+	}
+
+	sub.raw = raw
+	sub.errors = make(chan error, 1)
+	sub.quit = make(chan struct{})
+
+	return nil
+}
+
+// run passes every notification to handle until the subscription ends, then
+// calls done and forwards the error that ended it
+func (sub *subscription) run(handle func(json.RawMessage) error, done func()) {
+
+	var err error
+
+	defer func() {
+		done()
+		if err != nil {
+			sub.report(err)
+		}
+		close(sub.errors)
+	}()
+
+	rawErrors := sub.raw.Err()
+
+	for {
+		select {
+		case message, ok := <-sub.raw.Notifications():
+			if !ok {
+				// the gaps still unread come before the error that ended the subscription
+				for rawErrors != nil {
+					rawErr, open := <-rawErrors
+					if !open {
+						break
+					}
+					if _, isGap := rawErr.(*providers.GapError); isGap {
+						sub.report(rawErr)
+						continue
+					}
+					err = rawErr
+				}
+				return
+			}
+			if decodeErr := handle(message); decodeErr != nil {
+				sub.report(decodeErr)
+			}
+		case rawErr, ok := <-rawErrors:
+			if !ok {
+				rawErrors = nil
+				continue
+			}
+			if _, isGap := rawErr.(*providers.GapError); isGap {
+				sub.report(rawErr)
+				continue
+			}
+			// the subscription ended, Notifications is closed next
+			err = rawErr
+			rawErrors = nil
+		case <-sub.quit:
+			return
+		}
+	}
+}
+
+func (sub *subscription) report(err error) {
+	select {
+	case sub.errors <- err:
+	case <-sub.quit:
+	}
+}
+
+// NewHeadsSubscription - Receives a header every time a new block is appended to the chain
+type NewHeadsSubscription struct {
+	subscription
+	headers chan *dto.Block
+}
+
+// Headers - The new headers, closed when the subscription ends
+func (sub *NewHeadsSubscription) Headers() <-chan *dto.Block {
+	return sub.headers
+}
+
+// SubscribeNewHeads - Subscribes to the headers of the new blocks, reorganizations included.
+// Reference: https://geth.ethereum.org/docs/rpc/pubsub#newheads
+// Parameters:
+//   - none
+//
+// Returns:
+//   - NewHeadsSubscription - delivers a block object without transactions for each new header
+func (eth *Eth) SubscribeNewHeads() (*NewHeadsSubscription, error) {
+	return eth.SubscribeNewHeadsCtx(context.Background())
+}
+
+// SubscribeNewHeadsCtx - Same as SubscribeNewHeads, using ctx to cancel the subscribe request.
+func (eth *Eth) SubscribeNewHeadsCtx(ctx context.Context) (*NewHeadsSubscription, error) {
+
+	sub := &NewHeadsSubscription{headers: make(chan *dto.Block)}
+
+	if err := eth.subscribe(ctx, &sub.subscription, "newHeads"); err != nil {
+		return nil, err
+	}
+
+	go sub.run(func(message json.RawMessage) error {
+		header := &dto.Block{}
+		if err := json.Unmarshal(message, header); err != nil {
+			return err
+		}
+		select {
+		case sub.headers <- header:
+		case <-sub.quit:
+		}
+		return nil
+	}, func() { close(sub.headers) })
+
+	return sub, nil
+}
+
+// LogsSubscription - Receives the logs matching a filter as they are included in new blocks
+type LogsSubscription struct {
+	subscription
+	logs chan *dto.TransactionLogs
+}
+
+// Logs - The matching logs, closed when the subscription ends
+func (sub *LogsSubscription) Logs() <-chan *dto.TransactionLogs {
+	return sub.logs
+}
+
+// SubscribeLogs - Subscribes to the logs included in new blocks that match the filter.
+// Logs of blocks dropped by a reorganization are sent again with removed set to true.
+// Reference: https://geth.ethereum.org/docs/rpc/pubsub#logs
+// Parameters:
+//   - Object - the filter, only address and topics are used
+//
+// Returns:
+//   - LogsSubscription - delivers a log object for each matching log
+func (eth *Eth) SubscribeLogs(filter *dto.FilterParameters) (*LogsSubscription, error) {
+	return eth.SubscribeLogsCtx(context.Background(), filter)
+}
+
+// SubscribeLogsCtx - Same as SubscribeLogs, using ctx to cancel the subscribe request.
+func (eth *Eth) SubscribeLogsCtx(ctx context.Context, filter *dto.FilterParameters) (*LogsSubscription, error) {
+
+	if filter == nil {
+		filter = &dto.FilterParameters{}
+	}
+
+	sub := &LogsSubscription{logs: make(chan *dto.TransactionLogs)}
+
+	if err := eth.subscribe(ctx, &sub.subscription, "logs", filter); err != nil {
+		return nil, err
+	}
+
+	go sub.run(func(message json.RawMessage) error {
+		log := &dto.TransactionLogs{}
+		if err := json.Unmarshal(message, log); err != nil {
+			return err
+		}
+		select {
+		case sub.logs <- log:
+		case <-sub.quit:
+		}
+		return nil
+	}, func() { close(sub.logs) })
+
+	return sub, nil
+}
+
+// PendingTransactionsSubscription - Receives the hash of the transactions entering the pending state
+type PendingTransactionsSubscription struct {
+	subscription
+	hashes chan string
+}
+
+// Hashes - The transaction hashes, closed when the subscription ends
+func (sub *PendingTransactionsSubscription) Hashes() <-chan string {
+	return sub.hashes
+}
+
+// SubscribeNewPendingTransactions - Subscribes to the transactions added to the pending state
+// and signed with a key available in the node.
+// Reference: https://geth.ethereum.org/docs/rpc/pubsub#newpendingtransactions
+// Parameters:
+//   - none
+//
+// Returns:
+//   - PendingTransactionsSubscription - delivers DATA, 32 Bytes - the hash of each transaction
+func (eth *Eth) SubscribeNewPendingTransactions() (*PendingTransactionsSubscription, error) {
+	return eth.SubscribeNewPendingTransactionsCtx(context.Background())
+}
+
+// SubscribeNewPendingTransactionsCtx - Same as SubscribeNewPendingTransactions, using ctx to cancel the subscribe request.
+func (eth *Eth) SubscribeNewPendingTransactionsCtx(ctx context.Context) (*PendingTransactionsSubscription, error) {
+
+	sub := &PendingTransactionsSubscription{hashes: make(chan string)}
+
+	if err := eth.subscribe(ctx, &sub.subscription, "newPendingTransactions"); err != nil {
+		return nil, err
+	}
+
+	go sub.run(func(message json.RawMessage) error {
+		var hash string
+		if err := json.Unmarshal(message, &hash); err != nil {
+			return err
+		}
+		select {
+		case sub.hashes <- hash:
+		case <-sub.quit:
+		}
+		return nil
+	}, func() { close(sub.hashes) })
+
+	return sub, nil
+}
+
+// SyncingSubscription - Receives the changes of the synchronization status
+type SyncingSubscription struct {
+	subscription
+	status chan *dto.SyncingNotification
+}
+
+// Status - The synchronization changes, closed when the subscription ends
+func (sub *SyncingSubscription) Status() <-chan *dto.SyncingNotification {
+	return sub.status
+}
+
+// SubscribeSyncing - Subscribes to the start, progress and end of the synchronization.
+// Reference: https://geth.ethereum.org/docs/rpc/pubsub#syncing
+// Parameters:
+//   - none
+//
+// Returns:
+//   - SyncingSubscription - delivers the syncing flag with the sync status data while syncing
+func (eth *Eth) SubscribeSyncing() (*SyncingSubscription, error) {
+	return eth.SubscribeSyncingCtx(context.Background())
+}
+
+// SubscribeSyncingCtx - Same as SubscribeSyncing, using ctx to cancel the subscribe request.
+func (eth *Eth) SubscribeSyncingCtx(ctx context.Context) (*SyncingSubscription, error) {
+
+	sub := &SyncingSubscription{status: make(chan *dto.SyncingNotification)}
+
+	if err := eth.subscribe(ctx, &sub.subscription, "syncing"); err != nil {
+		return nil, err
+	}
+
+	go sub.run(func(message json.RawMessage) error {
+		status := &dto.SyncingNotification{}
+		if err := json.Unmarshal(message, status); err != nil {
+			return err
+		}
+		select {
+		case sub.status <- status:
+		case <-sub.quit:
+		}
+		return nil
+	}, func() { close(sub.status) })
+
+	return sub, nil
+}
@@ -0,0 +1,145 @@
+/********************************************************************************
+   This file is part of go-web3.
+   go-web3 is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-web3 is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-web3.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file typed.go
+ */
+
+package eth
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/cellcycle/go-web3/complex/types"
+	"github.com/cellcycle/go-web3/dto"
+)
+
+// GetCoinbaseTyped - Same as GetCoinbase, the address parsed with types.ParseAddress
+func (eth *Eth) GetCoinbaseTyped() (types.Address, error) {
+	return eth.GetCoinbaseTypedCtx(context.Background())
+}
+
+// GetCoinbaseTypedCtx - Same as GetCoinbaseTyped, using ctx to cancel the request or set its deadline.
+func (eth *Eth) GetCoinbaseTypedCtx(ctx context.Context) (types.Address, error) {
+
+	address, err := eth.GetCoinbaseCtx(ctx)
+	if err != nil {
+		return types.Address{}, err
+	}
+
+	return types.ParseAddress(address)
+}
+
+// ListAccountsTyped - Same as ListAccounts, the addresses parsed with types.ParseAddress
+func (eth *Eth) ListAccountsTyped() ([]types.Address, error) {
+	return eth.ListAccountsTypedCtx(context.Background())
+}
+
+// ListAccountsTypedCtx - Same as ListAccountsTyped, using ctx to cancel the request or set its deadline.
+func (eth *Eth) ListAccountsTypedCtx(ctx context.Context) ([]types.Address, error) {
+
+	accounts, err := eth.ListAccountsCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return types.ParseAddresses(accounts)
+}
+
+// GetBalanceTyped - Same as GetBalance, with a typed address
+func (eth *Eth) GetBalanceTyped(address types.Address, defaultBlockParameter string) (*big.Int, error) {
+	return eth.GetBalanceTypedCtx(context.Background(), address, defaultBlockParameter)
+}
+
+// GetBalanceTypedCtx - Same as GetBalanceTyped, using ctx to cancel the request or set its deadline.
+func (eth *Eth) GetBalanceTypedCtx(ctx context.Context, address types.Address, defaultBlockParameter string) (*big.Int, error) {
+	return eth.GetBalanceCtx(ctx, address.Hex(), defaultBlockParameter)
+}
+
+// GetTransactionCountTyped - Same as GetTransactionCount, with a typed address
+func (eth *Eth) GetTransactionCountTyped(address types.Address, defaultBlockParameter string) (*big.Int, error) {
+	return eth.GetTransactionCountTypedCtx(context.Background(), address, defaultBlockParameter)
+}
+
+// GetTransactionCountTypedCtx - Same as GetTransactionCountTyped, using ctx to cancel the request or set its deadline.
+func (eth *Eth) GetTransactionCountTypedCtx(ctx context.Context, address types.Address, defaultBlockParameter string) (*big.Int, error) {
+	return eth.GetTransactionCountCtx(ctx, address.Hex(), defaultBlockParameter)
+}
+
+// GetStorageAtTyped - Same as GetStorageAt, with a typed address
+func (eth *Eth) GetStorageAtTyped(address types.Address, position *big.Int, defaultBlockParameter string) (string, error) {
+	return eth.GetStorageAtTypedCtx(context.Background(), address, position, defaultBlockParameter)
+}
+
+// GetStorageAtTypedCtx - Same as GetStorageAtTyped, using ctx to cancel the request or set its deadline.
+func (eth *Eth) GetStorageAtTypedCtx(ctx context.Context, address types.Address, position *big.Int, defaultBlockParameter string) (string, error) {
+	return eth.GetStorageAtCtx(ctx, address.Hex(), position, defaultBlockParameter)
+}
+
+// GetCodeTyped - Same as GetCode, with a typed address
+func (eth *Eth) GetCodeTyped(address types.Address, defaultBlockParameter string) (string, error) {
+	return eth.GetCodeTypedCtx(context.Background(), address, defaultBlockParameter)
+}
+
+// GetCodeTypedCtx - Same as GetCodeTyped, using ctx to cancel the request or set its deadline.
+func (eth *Eth) GetCodeTypedCtx(ctx context.Context, address types.Address, defaultBlockParameter string) (string, error) {
+	return eth.GetCodeCtx(ctx, address.Hex(), defaultBlockParameter)
+}
+
+// GetTransactionByHashTyped - Same as GetTransactionByHash, with a typed hash
+func (eth *Eth) GetTransactionByHashTyped(hash types.Hash) (*dto.TransactionResponse, error) {
+	return eth.GetTransactionByHashTypedCtx(context.Background(), hash)
+}
+
+// GetTransactionByHashTypedCtx - Same as GetTransactionByHashTyped, using ctx to cancel the request or set its deadline.
+func (eth *Eth) GetTransactionByHashTypedCtx(ctx context.Context, hash types.Hash) (*dto.TransactionResponse, error) {
+	return eth.GetTransactionByHashCtx(ctx, hash.Hex())
+}
+
+// GetTransactionReceiptTyped - Same as GetTransactionReceipt, with a typed hash
+func (eth *Eth) GetTransactionReceiptTyped(hash types.Hash) (*dto.TransactionReceipt, error) {
+	return eth.GetTransactionReceiptTypedCtx(context.Background(), hash)
+}
+
+// GetTransactionReceiptTypedCtx - Same as GetTransactionReceiptTyped, using ctx to cancel the request or set its deadline.
+func (eth *Eth) GetTransactionReceiptTypedCtx(ctx context.Context, hash types.Hash) (*dto.TransactionReceipt, error) {
+	return eth.GetTransactionReceiptCtx(ctx, hash.Hex())
+}
+
+// GetBlockByHashTyped - Same as GetBlockByHash, with a typed hash
+func (eth *Eth) GetBlockByHashTyped(hash types.Hash, transactionDetails bool) (*dto.Block, error) {
+	return eth.GetBlockByHashTypedCtx(context.Background(), hash, transactionDetails)
+}
+
+// GetBlockByHashTypedCtx - Same as GetBlockByHashTyped, using ctx to cancel the request or set its deadline.
+func (eth *Eth) GetBlockByHashTypedCtx(ctx context.Context, hash types.Hash, transactionDetails bool) (*dto.Block, error) {
+	return eth.GetBlockByHashCtx(ctx, hash.Hex(), transactionDetails)
+}
+
+// SendTransactionTyped - Same as SendTransaction, the hash of the transaction parsed with types.ParseHash
+func (eth *Eth) SendTransactionTyped(transaction *dto.TransactionParameters) (types.Hash, error) {
+	return eth.SendTransactionTypedCtx(context.Background(), transaction)
+}
+
+// SendTransactionTypedCtx - Same as SendTransactionTyped, using ctx to cancel the request or set its deadline.
+func (eth *Eth) SendTransactionTypedCtx(ctx context.Context, transaction *dto.TransactionParameters) (types.Hash, error) {
+
+	hash, err := eth.SendTransactionCtx(ctx, transaction)
+	if err != nil {
+		return types.Hash{}, err
+	}
+
+	return types.ParseHash(hash)
+}
@@ -0,0 +1,117 @@
+/********************************************************************************
+   This file is part of go-web3.
+   go-web3 is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-web3 is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-web3.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file keccak.go
+ */
+
+// Package keccak computes the Keccak-256 hash of Ethereum, shared by the packages
+// which can't import utils.
+package keccak
+
+import "math/bits"
+
+// keccakRate - the bytes absorbed by each permutation of Keccak-256
+const keccakRate = 136
+
+// keccakRoundConstants - the iota constants of the 24 rounds
+var keccakRoundConstants = [24]uint64{
+	0x0000000000000001, 0x0000000000008082, 0x800000000000808A, 0x8000000080008000,
+	0x000000000000808B, 0x0000000080000001, 0x8000000080008081, 0x8000000000008009,
+	0x000000000000008A, 0x0000000000000088, 0x0000000080008009, 0x000000008000000A,
+	0x000000008000808B, 0x800000000000008B, 0x8000000000008089, 0x8000000000008003,
+	0x8000000000008002, 0x8000000000000080, 0x000000000000800A, 0x800000008000000A,
+	0x8000000080008081, 0x8000000000008080, 0x0000000080000001, 0x8000000080008008,
+}
+
+// keccakRotations and keccakLanes - the rho offsets and the pi order of the lanes
+var keccakRotations = [24]int{1, 3, 6, 10, 15, 21, 28, 36, 45, 55, 2, 14, 27, 41, 56, 8, 25, 43, 62, 18, 39, 61, 20, 44}
+var keccakLanes = [24]int{10, 7, 11, 17, 18, 3, 5, 16, 8, 21, 24, 4, 15, 23, 19, 13, 12, 2, 20, 14, 22, 9, 6, 1}
+
+// keccakF runs the Keccak-f[1600] permutation on state
+func keccakF(state *[25]uint64) {
+
+	var columns [5]uint64
+
+	for round := 0; round < 24; round++ {
+
+		// theta
+		for x := 0; x < 5; x++ {
+			columns[x] = state[x] ^ state[x+5] ^ state[x+10] ^ state[x+15] ^ state[x+20]
+		}
+		for x := 0; x < 5; x++ {
+			parity := columns[(x+4)%5] ^ bits.RotateLeft64(columns[(x+1)%5], 1)
+			for y := 0; y < 25; y += 5 {
+				state[y+x] ^= parity
+			}
+		}
+
+		// rho and pi
+		current := state[1]
+		for index := 0; index < 24; index++ {
+			lane := keccakLanes[index]
+			next := state[lane]
+			state[lane] = bits.RotateLeft64(current, keccakRotations[index])
+			current = next
+		}
+
+		// chi
+		for y := 0; y < 25; y += 5 {
+			for x := 0; x < 5; x++ {
+				columns[x] = state[y+x]
+			}
+			for x := 0; x < 5; x++ {
+				state[y+x] ^= ^columns[(x+1)%5] & columns[(x+2)%5]
+			}
+		}
+
+		// iota
+		state[0] ^= keccakRoundConstants[round]
+	}
+}
+
+// Sum256 - The Keccak-256 hash of the concatenation of data, with the original padding
+// of Keccak rather than the one of SHA3-256
+func Sum256(data ...[]byte) [32]byte {
+
+	var message []byte
+	for _, part := range data {
+		message = append(message, part...)
+	}
+
+	message = append(message, 0x01)
+	for len(message)%keccakRate != 0 {
+		message = append(message, 0)
+	}
+	message[len(message)-1] |= 0x80
+
+	var state [25]uint64
+	for offset := 0; offset < len(message); offset += keccakRate {
+		for lane := 0; lane < keccakRate/8; lane++ {
+			for index := 0; index < 8; index++ {
+				state[lane] ^= uint64(message[offset+lane*8+index]) << (8 * index)
+			}
+		}
+		keccakF(&state)
+	}
+
+	var hash [32]byte
+	for lane := 0; lane < 4; lane++ {
+		for index := 0; index < 8; index++ {
+			hash[lane*8+index] = byte(state[lane] >> (8 * index))
+		}
+	}
+
+	return hash
+}
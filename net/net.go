@@ -22,6 +22,7 @@
 package net
 
 import (
+	"context"
 	"github.com/cellcycle/go-web3/dto"
 	"github.com/cellcycle/go-web3/providers"
 	"math/big"
@@ -42,14 +43,20 @@ func NewNet(provider providers.ProviderInterface) *Net {
 // IsListening - Returns true if client is actively listening for network connections.
 // Reference: https://github.com/ethereum/wiki/wiki/JSON-RPC#net_listening
 // Parameters:
-//    - none
+//   - none
+//
 // Returns:
-// 	  - Boolean - true when listening, otherwise false.
+//   - Boolean - true when listening, otherwise false.
 func (net *Net) IsListening() (bool, error) {
+	return net.IsListeningCtx(context.Background())
+}
+
+// IsListeningCtx - Same as IsListening, using ctx to cancel the request or set its deadline.
+func (net *Net) IsListeningCtx(ctx context.Context) (bool, error) {
 
 	pointer := &dto.RequestResult{}
 
-	err := net.provider.SendRequest(pointer, "net_listening", nil)
+	err := net.provider.SendRequestCtx(ctx, pointer, "net_listening", nil)
 
 	if err != nil {
 		return false, err
@@ -62,14 +69,20 @@ func (net *Net) IsListening() (bool, error) {
 // GetPeerCount - Returns number of peers currently connected to the client.
 // Reference: https://github.com/ethereum/wiki/wiki/JSON-RPC#net_peercount
 // Parameters:
-//    - none
+//   - none
+//
 // Returns:
-// 	  - QUANTITY - integer of the number of connected peers.
+//   - QUANTITY - integer of the number of connected peers.
 func (net *Net) GetPeerCount() (*big.Int, error) {
+	return net.GetPeerCountCtx(context.Background())
+}
+
+// GetPeerCountCtx - Same as GetPeerCount, using ctx to cancel the request or set its deadline.
+func (net *Net) GetPeerCountCtx(ctx context.Context) (*big.Int, error) {
 
 	pointer := &dto.RequestResult{}
 
-	err := net.provider.SendRequest(pointer, "net_peerCount", nil)
+	err := net.provider.SendRequestCtx(ctx, pointer, "net_peerCount", nil)
 
 	if err != nil {
 		return nil, err
@@ -82,19 +95,25 @@ func (net *Net) GetPeerCount() (*big.Int, error) {
 // GetVersion - Returns the current network id.
 // Reference: https://github.com/ethereum/wiki/wiki/JSON-RPC#net_version
 // Parameters:
-//    - none
+//   - none
+//
 // Returns:
-//    - String - The current network id.
-//    "1": Ethereum Mainnet
-//    "2": Morden Testnet (deprecated)
-//    "3": Ropsten Testnet
-//    "4": Rinkeby Testnet
-//    "42": Kovan Testnet
+//   - String - The current network id.
+//     "1": Ethereum Mainnet
+//     "2": Morden Testnet (deprecated)
+//     "3": Ropsten Testnet
+//     "4": Rinkeby Testnet
+//     "42": Kovan Testnet
 func (net *Net) GetVersion() (string, error) {
+	return net.GetVersionCtx(context.Background())
+}
+
+// GetVersionCtx - Same as GetVersion, using ctx to cancel the request or set its deadline.
+func (net *Net) GetVersionCtx(ctx context.Context) (string, error) {
 
 	pointer := &dto.RequestResult{}
 
-	err := net.provider.SendRequest(pointer, "net_version", nil)
+	err := net.provider.SendRequestCtx(ctx, pointer, "net_version", nil)
 
 	if err != nil {
 		return "", err
@@ -22,6 +22,7 @@
 package personal
 
 import (
+	"context"
 	"github.com/cellcycle/go-web3/dto"
 	"github.com/cellcycle/go-web3/providers"
 )
@@ -41,14 +42,20 @@ func NewPersonal(provider providers.ProviderInterface) *Personal {
 // ListAccounts - Lists all stored accounts.
 // Reference: https://github.com/paritytech/parity/wiki/JSONRPC-personal-module#personal_listaccounts
 // Parameters:
-//    - none
+//   - none
+//
 // Returns:
-//    - Array - A list of 20 byte account identifiers.
+//   - Array - A list of 20 byte account identifiers.
 func (personal *Personal) ListAccounts() ([]string, error) {
+	return personal.ListAccountsCtx(context.Background())
+}
+
+// ListAccountsCtx - Same as ListAccounts, using ctx to cancel the request or set its deadline.
+func (personal *Personal) ListAccountsCtx(ctx context.Context) ([]string, error) {
 
 	pointer := &dto.RequestResult{}
 
-	err := personal.provider.SendRequest(pointer, "personal_listAccounts", nil)
+	err := personal.provider.SendRequestCtx(ctx, pointer, "personal_listAccounts", nil)
 
 	if err != nil {
 		return nil, err
@@ -62,17 +69,23 @@ func (personal *Personal) ListAccounts() ([]string, error) {
 // Note: it becomes the new current unlocked account. There can only be one unlocked account at a time.
 // Reference: https://github.com/paritytech/parity/wiki/JSONRPC-personal-module#personal_newaccount
 // Parameters:
-//    - String - Password for the new account.
+//   - String - Password for the new account.
+//
 // Returns:
-//	  - Address - 20 Bytes - The identifier of the new account.
+//   - Address - 20 Bytes - The identifier of the new account.
 func (personal *Personal) NewAccount(password string) (string, error) {
+	return personal.NewAccountCtx(context.Background(), password)
+}
+
+// NewAccountCtx - Same as NewAccount, using ctx to cancel the request or set its deadline.
+func (personal *Personal) NewAccountCtx(ctx context.Context, password string) (string, error) {
 
 	params := make([]string, 1)
 	params[0] = password
 
 	pointer := &dto.RequestResult{}
 
-	err := personal.provider.SendRequest(&pointer, "personal_newAccount", params)
+	err := personal.provider.SendRequestCtx(ctx, &pointer, "personal_newAccount", params)
 
 	if err != nil {
 		return "", err
@@ -87,19 +100,25 @@ func (personal *Personal) NewAccount(password string) (string, error) {
 // SendTransaction - Sends transaction and signs it in a single call. The account does not need to be unlocked to make this call, and will not be left unlocked after.
 // Reference: https://github.com/paritytech/parity/wiki/JSONRPC-personal-module#personal_sendtransaction
 // Parameters:
-//    1. Object - The transaction object
-//        - from: Address - 20 Bytes - The address the transaction is send from.
-//        - to: Address - (optional) 20 Bytes - The address the transaction is directed to.
-//        - gas: Quantity - (optional) Integer of the gas provided for the transaction execution. eth_call consumes zero gas, but this parameter may be needed by some executions.
-//        - gasPrice: Quantity - (optional) Integer of the gas price used for each paid gas.
-//        - value: Quantity - (optional) Integer of the value sent with this transaction.
-//        - data: Data - (optional) 4 byte hash of the method signature followed by encoded parameters. For details see Ethereum Contract ABI.
-//        - nonce: Quantity - (optional) Integer of a nonce. This allows to overwrite your own pending transactions that use the same nonce.
-//        - condition: Object - (optional) Conditional submission of the transaction. Can be either an integer block number { block: 1 } or UTC timestamp (in seconds) { time: 1491290692 } or null.
-//    2. String - Passphrase to unlock the from account.
+//  1. Object - The transaction object
+//     - from: Address - 20 Bytes - The address the transaction is send from.
+//     - to: Address - (optional) 20 Bytes - The address the transaction is directed to.
+//     - gas: Quantity - (optional) Integer of the gas provided for the transaction execution. eth_call consumes zero gas, but this parameter may be needed by some executions.
+//     - gasPrice: Quantity - (optional) Integer of the gas price used for each paid gas.
+//     - value: Quantity - (optional) Integer of the value sent with this transaction.
+//     - data: Data - (optional) 4 byte hash of the method signature followed by encoded parameters. For details see Ethereum Contract ABI.
+//     - nonce: Quantity - (optional) Integer of a nonce. This allows to overwrite your own pending transactions that use the same nonce.
+//     - condition: Object - (optional) Conditional submission of the transaction. Can be either an integer block number { block: 1 } or UTC timestamp (in seconds) { time: 1491290692 } or null.
+//  2. String - Passphrase to unlock the from account.
+//
 // Returns:
-//    - Data - 32 Bytes - the transaction hash, or the zero hash if the transaction is not yet available
+//   - Data - 32 Bytes - the transaction hash, or the zero hash if the transaction is not yet available
 func (personal *Personal) SendTransaction(transaction *dto.TransactionParameters, password string) (string, error) {
+	return personal.SendTransactionCtx(context.Background(), transaction, password)
+}
+
+// SendTransactionCtx - Same as SendTransaction, using ctx to cancel the request or set its deadline.
+func (personal *Personal) SendTransactionCtx(ctx context.Context, transaction *dto.TransactionParameters, password string) (string, error) {
 
 	params := make([]interface{}, 2)
 
@@ -110,7 +129,7 @@ func (personal *Personal) SendTransaction(transaction *dto.TransactionParameters
 
 	pointer := &dto.RequestResult{}
 
-	err := personal.provider.SendRequest(pointer, "personal_sendTransaction", params)
+	err := personal.provider.SendRequestCtx(ctx, pointer, "personal_sendTransaction", params)
 
 	if err != nil {
 		return "", err
@@ -128,12 +147,18 @@ func (personal *Personal) SendTransaction(transaction *dto.TransactionParameters
 // Passing 0 unlocks the account indefinitely.
 // There can only be one unlocked account at a time.
 // Parameters:
-//    - Address - 20 Bytes - The address of the account to unlock.
-//    - String - Passphrase to unlock the account.
-//    - Quantity - (default: 300) Integer or null - Duration in seconds how long the account should remain unlocked for.
+//   - Address - 20 Bytes - The address of the account to unlock.
+//   - String - Passphrase to unlock the account.
+//   - Quantity - (default: 300) Integer or null - Duration in seconds how long the account should remain unlocked for.
+//
 // Returns:
-// 	   - Boolean - whether the call was successful
+//   - Boolean - whether the call was successful
 func (personal *Personal) UnlockAccount(address string, password string, duration uint64) (bool, error) {
+	return personal.UnlockAccountCtx(context.Background(), address, password, duration)
+}
+
+// UnlockAccountCtx - Same as UnlockAccount, using ctx to cancel the request or set its deadline.
+func (personal *Personal) UnlockAccountCtx(ctx context.Context, address string, password string, duration uint64) (bool, error) {
 
 	params := make([]interface{}, 3)
 	params[0] = address
@@ -142,7 +167,7 @@ func (personal *Personal) UnlockAccount(address string, password string, duratio
 
 	pointer := &dto.RequestResult{}
 
-	err := personal.provider.SendRequest(pointer, "personal_unlockAccount", params)
+	err := personal.provider.SendRequestCtx(ctx, pointer, "personal_unlockAccount", params)
 
 	if err != nil {
 		return false, err
@@ -0,0 +1,84 @@
+/********************************************************************************
+   This file is part of go-web3.
+   go-web3 is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-web3 is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-web3.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file typed.go
+ */
+
+package personal
+
+import (
+	"context"
+
+	"github.com/cellcycle/go-web3/complex/types"
+	"github.com/cellcycle/go-web3/dto"
+)
+
+// ListAccountsTyped - Same as ListAccounts, the addresses parsed with types.ParseAddress
+func (personal *Personal) ListAccountsTyped() ([]types.Address, error) {
+	return personal.ListAccountsTypedCtx(context.Background())
+}
+
+// ListAccountsTypedCtx - Same as ListAccountsTyped, using ctx to cancel the request or set its deadline.
+func (personal *Personal) ListAccountsTypedCtx(ctx context.Context) ([]types.Address, error) {
+
+	accounts, err := personal.ListAccountsCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return types.ParseAddresses(accounts)
+}
+
+// NewAccountTyped - Same as NewAccount, the address parsed with types.ParseAddress
+func (personal *Personal) NewAccountTyped(password string) (types.Address, error) {
+	return personal.NewAccountTypedCtx(context.Background(), password)
+}
+
+// NewAccountTypedCtx - Same as NewAccountTyped, using ctx to cancel the request or set its deadline.
+func (personal *Personal) NewAccountTypedCtx(ctx context.Context, password string) (types.Address, error) {
+
+	address, err := personal.NewAccountCtx(ctx, password)
+	if err != nil {
+		return types.Address{}, err
+	}
+
+	return types.ParseAddress(address)
+}
+
+// SendTransactionTyped - Same as SendTransaction, the hash of the transaction parsed with types.ParseHash
+func (personal *Personal) SendTransactionTyped(transaction *dto.TransactionParameters, password string) (types.Hash, error) {
+	return personal.SendTransactionTypedCtx(context.Background(), transaction, password)
+}
+
+// SendTransactionTypedCtx - Same as SendTransactionTyped, using ctx to cancel the request or set its deadline.
+func (personal *Personal) SendTransactionTypedCtx(ctx context.Context, transaction *dto.TransactionParameters, password string) (types.Hash, error) {
+
+	hash, err := personal.SendTransactionCtx(ctx, transaction, password)
+	if err != nil {
+		return types.Hash{}, err
+	}
+
+	return types.ParseHash(hash)
+}
+
+// UnlockAccountTyped - Same as UnlockAccount, with a typed address
+func (personal *Personal) UnlockAccountTyped(address types.Address, password string, duration uint64) (bool, error) {
+	return personal.UnlockAccountTypedCtx(context.Background(), address, password, duration)
+}
+
+// UnlockAccountTypedCtx - Same as UnlockAccountTyped, using ctx to cancel the request or set its deadline.
+func (personal *Personal) UnlockAccountTypedCtx(ctx context.Context, address types.Address, password string, duration uint64) (bool, error) {
+	return personal.UnlockAccountCtx(ctx, address.Hex(), password, duration)
+}
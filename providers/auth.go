@@ -0,0 +1,162 @@
+/********************************************************************************
+   This file is part of go-web3.
+   go-web3 is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-web3 is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-web3.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file auth.go
+ */
+
+package providers
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Authenticator - Sets the credentials of the requests sent to the node. It is called
+// before each HTTP request and before each websocket handshake, reconnections included.
+type Authenticator interface {
+	Authenticate(header http.Header) error
+}
+
+// AuthenticatorFunc - A function used as an Authenticator
+type AuthenticatorFunc func(header http.Header) error
+
+// Authenticate - Calls function with header
+func (function AuthenticatorFunc) Authenticate(header http.Header) error {
+	return function(header)
+}
+
+// HeaderAuth - Sets the values of headers on every request, such as an API key
+func HeaderAuth(headers http.Header) Authenticator {
+	return AuthenticatorFunc(func(header http.Header) error {
+		for key, values := range headers {
+			header[http.CanonicalHeaderKey(key)] = append([]string(nil), values...)
+		}
+		return nil
+	})
+}
+
+// BasicAuth - Authenticates with the basic scheme
+func BasicAuth(username, password string) Authenticator {
+	credentials := base64.StdEncoding.EncodeToString([]byte(username + ":" + password))
+	return HeaderAuth(http.Header{"Authorization": {"Basic " + credentials}})
+}
+
+// BearerToken - Authenticates with the bearer scheme
+func BearerToken(token string) Authenticator {
+	return HeaderAuth(http.Header{"Authorization": {"Bearer " + token}})
+}
+
+// MultiAuth - Applies the authenticators in order, a later one overriding the headers of an earlier one
+func MultiAuth(authenticators ...Authenticator) Authenticator {
+	return AuthenticatorFunc(func(header http.Header) error {
+		for _, authenticator := range authenticators {
+			if authenticator == nil {
+				continue
+			}
+			if err := authenticator.Authenticate(header); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// jwtSecretLength - the size of the secret shared with the authrpc port of the node
+const jwtSecretLength = 32
+
+// jwtHeader - the encoded {"alg":"HS256","typ":"JWT"}, the same for every token
+var jwtHeader = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+
+// JWTAuth - Authenticates with the HS256 tokens of the Engine API. A new token, issued
+// at the current time, is signed for every request since the node refuses the tokens
+// issued more than a minute before.
+type JWTAuth struct {
+	secret []byte
+	// Now returns the issue time of the tokens, time.Now when nil
+	Now func() time.Time
+}
+
+// NewJWTAuth - A JWTAuth signing with the 32 bytes secret of the node
+func NewJWTAuth(secret []byte) (*JWTAuth, error) {
+
+	if len(secret) != jwtSecretLength {
+		return nil, fmt.Errorf("Invalid JWT secret: %d bytes instead of %d", len(secret), jwtSecretLength)
+	}
+
+	return &JWTAuth{secret: append([]byte(nil), secret...)}, nil
+}
+
+// NewJWTAuthFromFile - A JWTAuth signing with the hex secret of the file at path,
+// the jwtsecret file given to the node with --authrpc.jwtsecret
+func NewJWTAuthFromFile(path string) (*JWTAuth, error) {
+
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	encoded := strings.TrimSpace(string(content))
+	encoded = strings.TrimPrefix(strings.TrimPrefix(encoded, "0x"), "0X")
+
+	secret, err := hex.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid JWT secret in %s: %v", path, err)
+	}
+
+	return NewJWTAuth(secret)
+}
+
+// Token - A token issued at issuedAt
+func (auth *JWTAuth) Token(issuedAt time.Time) (string, error) {
+
+	claims, err := json.Marshal(struct {
+		IssuedAt int64 `json:"iat"`
+	}{issuedAt.Unix()})
+	if err != nil {
+		return "", err
+	}
+
+	unsigned := jwtHeader + "." + base64.RawURLEncoding.EncodeToString(claims)
+
+	mac := hmac.New(sha256.New, auth.secret)
+	mac.Write([]byte(unsigned))
+
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil)), nil
+}
+
+// Authenticate - Sets a bearer token issued now
+func (auth *JWTAuth) Authenticate(header http.Header) error {
+
+	now := time.Now
+	if auth.Now != nil {
+		now = auth.Now
+	}
+
+	token, err := auth.Token(now())
+	if err != nil {
+		return err
+	}
+
+	header.Set("Authorization", "Bearer "+token)
+	return nil
+}
@@ -0,0 +1,149 @@
+/********************************************************************************
+   This file is part of go-web3.
+   go-web3 is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-web3 is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-web3.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file batch.go
+ */
+
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/cellcycle/go-web3/dto"
+	"github.com/cellcycle/go-web3/providers/util"
+)
+
+// BatchElem - A single call inside a JSON-RPC batch.
+// Result receives the response of the call, Error is set when the call got
+// no usable response (the node error, if any, stays in Result.Error).
+type BatchElem struct {
+	Method string
+	Params interface{}
+	Result *dto.RequestResult
+	Error  error
+}
+
+// BatchProvider - A provider able to send several calls in one JSON-RPC batch
+type BatchProvider interface {
+	ProviderInterface
+	SendBatch(batch []BatchElem) error
+	SendBatchCtx(ctx context.Context, batch []BatchElem) error
+}
+
+// SendBatchCtx - Sends batch through provider, as a single JSON-RPC batch
+// when the provider supports it or one request after the other otherwise.
+// The returned error is only set when the batch as a whole failed.
+func SendBatchCtx(ctx context.Context, provider ProviderInterface, batch []BatchElem) error {
+
+	if batchProvider, ok := provider.(BatchProvider); ok {
+		return batchProvider.SendBatchCtx(ctx, batch)
+	}
+
+	return sendSequentially(ctx, provider.SendRequestCtx, batch)
+}
+
+// sendSequentially sends the calls of batch one after the other with send
+func sendSequentially(ctx context.Context, send func(ctx context.Context, v interface{}, method string, params interface{}) error, batch []BatchElem) error {
+
+	for index := range batch {
+		if batch[index].Result == nil {
+			batch[index].Result = &dto.RequestResult{}
+		}
+		batch[index].Error = send(ctx, batch[index].Result, batch[index].Method, batch[index].Params)
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// newBatchRequest builds the JSON-RPC objects of batch, taking the ids from
+// nextID. The returned map points every id back to its position in batch.
+func newBatchRequest(batch []BatchElem, nextID func() int) ([]util.JSONRPCObject, map[int]int) {
+
+	request := make([]util.JSONRPCObject, len(batch))
+	positions := make(map[int]int, len(batch))
+
+	for index := range batch {
+		id := nextID()
+		request[index] = util.JSONRPCObject{Version: "2.0", Method: batch[index].Method, Params: batch[index].Params, ID: id}
+		positions[id] = index
+
+		if batch[index].Result == nil {
+			batch[index].Result = &dto.RequestResult{}
+		}
+		batch[index].Error = nil
+	}
+
+	return request, positions
+}
+
+// sequentialIDs numbers the calls of a batch from 1, which is enough for the
+// transports that do not share the connection between requests.
+func sequentialIDs() func() int {
+	id := 0
+	return func() int {
+		id++
+		return id
+	}
+}
+
+// dispatchBatchResponse decodes the raw batch response and stores every
+// element in the call carrying the same id, whatever the order of the array.
+func dispatchBatchResponse(batch []BatchElem, positions map[int]int, raw []byte) error {
+
+	var responses []json.RawMessage
+
+	if err := json.Unmarshal(raw, &responses); err != nil {
+		// a node rejecting the whole batch answers with a single error object
+		single := &dto.RequestResult{}
+		if json.Unmarshal(raw, single) == nil && single.Error != nil {
+			return dto.NewRPCError(single.Error)
+		}
+		return err
+	}
+
+	received := make(map[int]bool, len(responses))
+
+	for _, response := range responses {
+
+		var header struct {
+			ID *int `json:"id"`
+		}
+
+		if err := json.Unmarshal(response, &header); err != nil || header.ID == nil {
+			continue
+		}
+
+		index, ok := positions[*header.ID]
+		if !ok || received[index] {
+			continue
+		}
+
+		received[index] = true
+		batch[index].Error = json.Unmarshal(response, batch[index].Result)
+	}
+
+	for index := range batch {
+		if !received[index] {
+			batch[index].Error = fmt.Errorf("no response for batch call %s", batch[index].Method)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,482 @@
+/********************************************************************************
+   This file is part of go-web3.
+   go-web3 is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-web3 is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-web3.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file cache-provider.go
+ */
+
+package providers
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cellcycle/go-web3/dto"
+)
+
+// cacheRule - when the answer to a method is cached
+type cacheRule int
+
+const (
+	// cacheNever - the answer may change
+	cacheNever cacheRule = iota
+	// cacheFound - the answer is cached unless null, the params name the data by hash
+	cacheFound
+	// cacheFinalParam - the answer is cached when the block param is a number below the finalized head
+	cacheFinalParam
+	// cacheFinalResult - the answer is cached when the block it was mined in is below the finalized head
+	cacheFinalResult
+)
+
+// cacheRules - the cacheable methods, the others are always sent
+var cacheRules = map[string]cacheRule{
+	"eth_chainId":                             cacheFound,
+	"web3_sha3":                               cacheFound,
+	"eth_getBlockByHash":                      cacheFound,
+	"eth_getBlockTransactionCountByHash":      cacheFound,
+	"eth_getUncleCountByBlockHash":            cacheFound,
+	"eth_getTransactionByBlockHashAndIndex":   cacheFound,
+	"eth_getUncleByBlockHashAndIndex":         cacheFound,
+	"eth_getBlockByNumber":                    cacheFinalParam,
+	"eth_getBlockTransactionCountByNumber":    cacheFinalParam,
+	"eth_getUncleCountByBlockNumber":          cacheFinalParam,
+	"eth_getTransactionByBlockNumberAndIndex": cacheFinalParam,
+	"eth_getUncleByBlockNumberAndIndex":       cacheFinalParam,
+	"eth_getBalance":                          cacheFinalParam,
+	"eth_getCode":                             cacheFinalParam,
+	"eth_getStorageAt":                        cacheFinalParam,
+	"eth_getTransactionCount":                 cacheFinalParam,
+	"eth_call":                                cacheFinalParam,
+	"eth_getLogs":                             cacheFinalParam,
+	"eth_getTransactionByHash":                cacheFinalResult,
+	"eth_getTransactionReceipt":               cacheFinalResult,
+}
+
+// blockParams - the position of the block param of the cacheFinalParam methods
+var blockParams = map[string]int{
+	"eth_getBlockByNumber":                    0,
+	"eth_getBlockTransactionCountByNumber":    0,
+	"eth_getUncleCountByBlockNumber":          0,
+	"eth_getTransactionByBlockNumberAndIndex": 0,
+	"eth_getUncleByBlockNumberAndIndex":       0,
+	"eth_getBalance":                          1,
+	"eth_getCode":                             1,
+	"eth_getTransactionCount":                 1,
+	"eth_call":                                1,
+	"eth_getStorageAt":                        2,
+}
+
+// CacheOptions - How a CacheProvider keeps the answers.
+// The zero value of a field takes the value of DefaultCacheOptions.
+type CacheOptions struct {
+	// Size is the number of answers kept, the least recently used one is dropped first
+	Size int
+	// FinalityDepth is the number of blocks under the head after which a block
+	// is final and can't be replaced by a reorganization
+	FinalityDepth uint64
+	// HeadRefresh is how long the head of the node is used before being asked again
+	HeadRefresh time.Duration
+}
+
+// DefaultCacheOptions - 1024 answers, blocks final 64 blocks under a head refreshed every 5s
+func DefaultCacheOptions() CacheOptions {
+	return CacheOptions{
+		Size:          1024,
+		FinalityDepth: 64,
+		HeadRefresh:   5 * time.Second,
+	}
+}
+
+func (options CacheOptions) withDefaults() CacheOptions {
+
+	defaults := DefaultCacheOptions()
+
+	if options.Size <= 0 {
+		options.Size = defaults.Size
+	}
+	if options.FinalityDepth == 0 {
+		options.FinalityDepth = defaults.FinalityDepth
+	}
+	if options.HeadRefresh <= 0 {
+		options.HeadRefresh = defaults.HeadRefresh
+	}
+
+	return options
+}
+
+// CacheStats - The counters of a CacheProvider
+type CacheStats struct {
+	// Hits counts the cacheable calls answered from the cache
+	Hits uint64
+	// Misses counts the cacheable calls sent to the node
+	Misses uint64
+	// Bypassed counts the calls which can't be cached, such as the ones at
+	// the latest or the pending block
+	Bypassed uint64
+	// Evictions counts the answers dropped to make room for newer ones
+	Evictions uint64
+	// Entries is the number of answers kept
+	Entries int
+}
+
+// cacheEntry - an answer kept in the cache
+type cacheEntry struct {
+	key    string
+	result json.RawMessage
+}
+
+// CacheProvider - Keeps the answers which can't change any more, the calls
+// addressing a block by hash, at a block number under the finalized head, and
+// the transactions and receipts mined in a final block. The calls at latest,
+// pending or any other tag are always sent to the node.
+type CacheProvider struct {
+	*middlewareProvider
+
+	options CacheOptions
+
+	mutex   sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List
+	stats   CacheStats
+
+	headMutex sync.Mutex
+	head      uint64
+	headAt    time.Time
+}
+
+// NewCacheProvider - A cache of the answers of next
+func NewCacheProvider(next ProviderInterface, options CacheOptions) *CacheProvider {
+
+	provider := new(CacheProvider)
+	provider.middlewareProvider = newMiddlewareProvider(next)
+	provider.options = options.withDefaults()
+	provider.entries = make(map[string]*list.Element)
+	provider.order = list.New()
+
+	provider.send = provider.sendCached
+
+	if batchProvider, ok := next.(BatchProvider); ok {
+		provider.sendBatch = func(ctx context.Context, batch []BatchElem) error {
+			return provider.sendBatchCached(ctx, batchProvider, batch)
+		}
+	}
+
+	return provider
+}
+
+// Stats - The counters of the cache
+func (provider *CacheProvider) Stats() CacheStats {
+
+	provider.mutex.Lock()
+	defer provider.mutex.Unlock()
+
+	stats := provider.stats
+	stats.Entries = provider.order.Len()
+
+	return stats
+}
+
+// Purge - Drops every answer, after a reorganization deeper than FinalityDepth
+func (provider *CacheProvider) Purge() {
+	provider.mutex.Lock()
+	defer provider.mutex.Unlock()
+	provider.entries = make(map[string]*list.Element)
+	provider.order.Init()
+}
+
+func (provider *CacheProvider) sendCached(ctx context.Context, v interface{}, method string, params interface{}) error {
+
+	key, rule := provider.cacheKey(ctx, method, params)
+	if rule == cacheNever {
+		return provider.next.SendRequestCtx(ctx, v, method, params)
+	}
+
+	if result, ok := provider.lookup(key); ok {
+		return decodeCached(result, v)
+	}
+
+	var response json.RawMessage
+	if err := provider.next.SendRequestCtx(ctx, &response, method, params); err != nil {
+		return err
+	}
+
+	var answer struct {
+		Result json.RawMessage `json:"result"`
+		Error  *dto.Error      `json:"error"`
+	}
+	if err := json.Unmarshal(response, &answer); err == nil && answer.Error == nil {
+		provider.store(ctx, key, rule, answer.Result)
+	}
+
+	return json.Unmarshal(response, v)
+}
+
+// sendBatchCached answers the calls of batch found in the cache and sends the
+// other ones in a single batch
+func (provider *CacheProvider) sendBatchCached(ctx context.Context, next BatchProvider, batch []BatchElem) error {
+
+	var missing []BatchElem
+	var positions []int
+	keys := make([]string, len(batch))
+	rules := make([]cacheRule, len(batch))
+
+	for index := range batch {
+
+		if batch[index].Result == nil {
+			batch[index].Result = &dto.RequestResult{}
+		}
+		batch[index].Error = nil
+
+		keys[index], rules[index] = provider.cacheKey(ctx, batch[index].Method, batch[index].Params)
+
+		if rules[index] != cacheNever {
+			if result, ok := provider.lookup(keys[index]); ok {
+				batch[index].Error = decodeCached(result, batch[index].Result)
+				continue
+			}
+		}
+
+		missing = append(missing, batch[index])
+		positions = append(positions, index)
+	}
+
+	if len(missing) == 0 {
+		return nil
+	}
+
+	if err := next.SendBatchCtx(ctx, missing); err != nil {
+		return err
+	}
+
+	for position, index := range positions {
+
+		batch[index] = missing[position]
+
+		result := batch[index].Result
+		if rules[index] == cacheNever || batch[index].Error != nil || result.Error != nil {
+			continue
+		}
+
+		// the results of the JSON-RPC methods hold strings, booleans and
+		// objects of them, they are encoded back as received
+		if encoded, err := json.Marshal(result.Result); err == nil {
+			provider.store(ctx, keys[index], rules[index], encoded)
+		}
+	}
+
+	return nil
+}
+
+// cacheKey returns the key of the call and how its answer can be cached
+func (provider *CacheProvider) cacheKey(ctx context.Context, method string, params interface{}) (string, cacheRule) {
+
+	rule := cacheRules[method]
+	if rule == cacheNever {
+		provider.count(&provider.stats.Bypassed)
+		return "", cacheNever
+	}
+
+	encoded, err := canonicalParams(params)
+	if err != nil {
+		provider.count(&provider.stats.Bypassed)
+		return "", cacheNever
+	}
+
+	if rule == cacheFinalParam && !provider.finalParams(ctx, method, encoded) {
+		provider.count(&provider.stats.Bypassed)
+		return "", cacheNever
+	}
+
+	return method + " " + string(encoded), rule
+}
+
+// finalParams reports whether the block param of the call names a final block
+func (provider *CacheProvider) finalParams(ctx context.Context, method string, encoded json.RawMessage) bool {
+
+	var params []json.RawMessage
+	if err := json.Unmarshal(encoded, &params); err != nil {
+		return false
+	}
+
+	if method == "eth_getLogs" {
+		if len(params) != 1 {
+			return false
+		}
+		var filter struct {
+			FromBlock string `json:"fromBlock"`
+			ToBlock   string `json:"toBlock"`
+			BlockHash string `json:"blockHash"`
+		}
+		if err := json.Unmarshal(params[0], &filter); err != nil {
+			return false
+		}
+		if filter.BlockHash != "" {
+			return true
+		}
+		_, fromOk := blockNumberParam(filter.FromBlock)
+		to, toOk := blockNumberParam(filter.ToBlock)
+		return fromOk && toOk && provider.isFinal(ctx, to)
+	}
+
+	position := blockParams[method]
+	if position >= len(params) {
+		// the block param defaults to latest
+		return false
+	}
+
+	var block string
+	if err := json.Unmarshal(params[position], &block); err != nil {
+		// EIP-1898 names the block by hash with {"blockHash": ...}
+		var object struct {
+			BlockHash string `json:"blockHash"`
+		}
+		return json.Unmarshal(params[position], &object) == nil && object.BlockHash != ""
+	}
+
+	number, ok := blockNumberParam(block)
+	return ok && provider.isFinal(ctx, number)
+}
+
+// blockNumberParam decodes a block param given as a number, the tags such as
+// latest or pending are not numbers
+func blockNumberParam(block string) (uint64, bool) {
+
+	if !strings.HasPrefix(block, "0x") {
+		return 0, false
+	}
+
+	number, ok := new(big.Int).SetString(block[2:], 16)
+	if !ok || !number.IsUint64() {
+		return 0, false
+	}
+
+	return number.Uint64(), true
+}
+
+// isFinal reports whether the block number is FinalityDepth blocks under the head
+func (provider *CacheProvider) isFinal(ctx context.Context, number uint64) bool {
+
+	head, ok := provider.currentHead(ctx)
+
+	return ok && head >= provider.options.FinalityDepth && number <= head-provider.options.FinalityDepth
+}
+
+// currentHead returns the head of the node, asked again every HeadRefresh
+func (provider *CacheProvider) currentHead(ctx context.Context) (uint64, bool) {
+
+	provider.headMutex.Lock()
+	defer provider.headMutex.Unlock()
+
+	if !provider.headAt.IsZero() && time.Since(provider.headAt) < provider.options.HeadRefresh {
+		return provider.head, true
+	}
+
+	pointer := &dto.RequestResult{}
+	if err := provider.next.SendRequestCtx(ctx, pointer, "eth_blockNumber", nil); err != nil {
+		return 0, false
+	}
+
+	number, err := pointer.ToBigInt()
+	if err != nil || !number.IsUint64() {
+		return 0, false
+	}
+
+	provider.head = number.Uint64()
+	provider.headAt = time.Now()
+
+	return provider.head, true
+}
+
+func (provider *CacheProvider) lookup(key string) (json.RawMessage, bool) {
+
+	provider.mutex.Lock()
+	defer provider.mutex.Unlock()
+
+	element, ok := provider.entries[key]
+	if !ok {
+		provider.stats.Misses++
+		return nil, false
+	}
+
+	provider.stats.Hits++
+	provider.order.MoveToFront(element)
+
+	return element.Value.(*cacheEntry).result, true
+}
+
+// store keeps the result of the call when its rule allows it
+func (provider *CacheProvider) store(ctx context.Context, key string, rule cacheRule, result json.RawMessage) {
+
+	if len(result) == 0 || string(result) == "null" {
+		// not found yet, it may be found later
+		return
+	}
+
+	if rule == cacheFinalResult {
+		var mined struct {
+			BlockNumber string `json:"blockNumber"`
+		}
+		if json.Unmarshal(result, &mined) != nil {
+			return
+		}
+		number, ok := blockNumberParam(mined.BlockNumber)
+		if !ok || !provider.isFinal(ctx, number) {
+			// pending, or in a block which may still be replaced
+			return
+		}
+	}
+
+	provider.mutex.Lock()
+	defer provider.mutex.Unlock()
+
+	if element, ok := provider.entries[key]; ok {
+		provider.order.MoveToFront(element)
+		return
+	}
+
+	provider.entries[key] = provider.order.PushFront(&cacheEntry{key: key, result: result})
+
+	for provider.order.Len() > provider.options.Size {
+		oldest := provider.order.Back()
+		provider.order.Remove(oldest)
+		delete(provider.entries, oldest.Value.(*cacheEntry).key)
+		provider.stats.Evictions++
+	}
+}
+
+func (provider *CacheProvider) count(counter *uint64) {
+	provider.mutex.Lock()
+	*counter++
+	provider.mutex.Unlock()
+}
+
+// decodeCached decodes a cached result into v as a response of the node
+func decodeCached(result json.RawMessage, v interface{}) error {
+
+	response, err := json.Marshal(struct {
+		ID      int             `json:"id"`
+		Version string          `json:"jsonrpc"`
+		Result  json.RawMessage `json:"result"`
+	}{1, "2.0", result})
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(response, v)
+}
@@ -0,0 +1,299 @@
+/********************************************************************************
+   This file is part of go-web3.
+   go-web3 is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-web3 is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-web3.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file failover-provider.go
+ */
+
+package providers
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cellcycle/go-web3/dto"
+)
+
+// FailoverOptions - How a FailoverProvider watches its endpoints.
+// The zero value of a field takes the value of DefaultFailoverOptions.
+type FailoverOptions struct {
+	// HealthCheckInterval is the time between two eth_blockNumber polls of
+	// every endpoint, a negative value disables the polling
+	HealthCheckInterval time.Duration
+	// HealthCheckTimeout bounds each poll
+	HealthCheckTimeout time.Duration
+	// Cooldown is the time an endpoint which failed is only used as a last resort
+	Cooldown time.Duration
+}
+
+// DefaultFailoverOptions - Polls every 15s with a 5s timeout, endpoints failing are set aside for 30s
+func DefaultFailoverOptions() FailoverOptions {
+	return FailoverOptions{
+		HealthCheckInterval: 15 * time.Second,
+		HealthCheckTimeout:  5 * time.Second,
+		Cooldown:            30 * time.Second,
+	}
+}
+
+func (options FailoverOptions) withDefaults() FailoverOptions {
+
+	defaults := DefaultFailoverOptions()
+
+	if options.HealthCheckInterval == 0 {
+		options.HealthCheckInterval = defaults.HealthCheckInterval
+	}
+	if options.HealthCheckTimeout <= 0 {
+		options.HealthCheckTimeout = defaults.HealthCheckTimeout
+	}
+	if options.Cooldown <= 0 {
+		options.Cooldown = defaults.Cooldown
+	}
+
+	return options
+}
+
+// EndpointStatus - What a FailoverProvider knows about one of its endpoints
+type EndpointStatus struct {
+	// Index is the position of the endpoint in the providers given
+	Index   int
+	Healthy bool
+	// BlockNumber is the last head reported by the endpoint
+	BlockNumber uint64
+	// LastError is the last transport error of the endpoint
+	LastError error
+	// FailedAt is the time of LastError
+	FailedAt time.Time
+}
+
+// FailoverProvider - Sends every request to the healthy endpoint furthest ahead,
+// moving to the next one when an endpoint can't be reached. Only the read methods
+// and eth_sendRawTransaction, which the nodes deduplicate, are sent to a second
+// endpoint; the other methods return the transport error.
+type FailoverProvider struct {
+	providers []ProviderInterface
+	options   FailoverOptions
+
+	mutex    sync.Mutex
+	statuses []EndpointStatus
+
+	quit chan struct{}
+	once sync.Once
+}
+
+// NewFailoverProvider - A failover provider over providers, in order of preference when they are level
+func NewFailoverProvider(providers []ProviderInterface, options FailoverOptions) *FailoverProvider {
+
+	provider := new(FailoverProvider)
+	provider.providers = providers
+	provider.options = options.withDefaults()
+	provider.quit = make(chan struct{})
+
+	provider.statuses = make([]EndpointStatus, len(providers))
+	for index := range provider.statuses {
+		provider.statuses[index] = EndpointStatus{Index: index, Healthy: true}
+	}
+
+	if provider.options.HealthCheckInterval > 0 {
+		go provider.watch()
+	}
+
+	return provider
+}
+
+func (provider *FailoverProvider) SendRequest(v interface{}, method string, params interface{}) error {
+	return provider.SendRequestCtx(context.Background(), v, method, params)
+}
+
+func (provider *FailoverProvider) SendRequestCtx(ctx context.Context, v interface{}, method string, params interface{}) error {
+
+	if len(provider.providers) == 0 {
+		return errNoEndpoint
+	}
+
+	var err error
+
+	for _, index := range provider.order() {
+
+		resetResult(v)
+
+		err = provider.providers[index].SendRequestCtx(ctx, v, method, params)
+		if err == nil || !isEndpointFailure(err) {
+			provider.succeeded(index)
+			return err
+		}
+
+		provider.failed(index, err)
+
+		if ctx.Err() != nil || !(IsIdempotent(method) || method == "eth_sendRawTransaction") {
+			return err
+		}
+	}
+
+	return err
+}
+
+// Status - The current view of the endpoints, in the order they are tried
+func (provider *FailoverProvider) Status() []EndpointStatus {
+
+	order := provider.order()
+
+	provider.mutex.Lock()
+	defer provider.mutex.Unlock()
+
+	statuses := make([]EndpointStatus, len(order))
+	for position, index := range order {
+		statuses[position] = provider.statuses[index]
+	}
+
+	return statuses
+}
+
+// CheckHealth - Polls eth_blockNumber on every endpoint now
+func (provider *FailoverProvider) CheckHealth(ctx context.Context) {
+
+	var wait sync.WaitGroup
+
+	for index := range provider.providers {
+		wait.Add(1)
+		go func(index int) {
+			defer wait.Done()
+
+			pointer := &dto.RequestResult{}
+			err := provider.providers[index].SendRequestCtx(ctx, pointer, "eth_blockNumber", nil)
+			if err != nil {
+				provider.failed(index, err)
+				return
+			}
+
+			number, err := pointer.ToBigInt()
+			if err != nil || !number.IsUint64() {
+				provider.failed(index, errors.New("unreadable block number"))
+				return
+			}
+
+			provider.mutex.Lock()
+			provider.statuses[index].Healthy = true
+			provider.statuses[index].BlockNumber = number.Uint64()
+			provider.mutex.Unlock()
+		}(index)
+	}
+
+	wait.Wait()
+}
+
+// Close - Stops the health checks and closes every endpoint
+func (provider *FailoverProvider) Close() error {
+
+	provider.once.Do(func() { close(provider.quit) })
+
+	var err error
+	for _, endpoint := range provider.providers {
+		if closeErr := endpoint.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}
+
+	return err
+}
+
+func (provider *FailoverProvider) watch() {
+
+	ticker := time.NewTicker(provider.options.HealthCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), provider.options.HealthCheckTimeout)
+		provider.CheckHealth(ctx)
+		cancel()
+
+		select {
+		case <-ticker.C:
+		case <-provider.quit:
+			return
+		}
+	}
+}
+
+// order lists the healthy endpoints from the highest block, then the
+// failing ones from the one which failed first
+func (provider *FailoverProvider) order() []int {
+
+	provider.mutex.Lock()
+	defer provider.mutex.Unlock()
+
+	now := time.Now()
+	for index := range provider.statuses {
+		status := &provider.statuses[index]
+		if !status.Healthy && now.Sub(status.FailedAt) >= provider.options.Cooldown {
+			// the cooldown is over, give it another chance
+			status.Healthy = true
+		}
+	}
+
+	order := make([]int, len(provider.statuses))
+	for index := range order {
+		order[index] = index
+	}
+
+	statuses := provider.statuses
+	sort.SliceStable(order, func(i, j int) bool {
+		left, right := statuses[order[i]], statuses[order[j]]
+		if left.Healthy != right.Healthy {
+			return left.Healthy
+		}
+		if !left.Healthy {
+			return left.FailedAt.Before(right.FailedAt)
+		}
+		return left.BlockNumber > right.BlockNumber
+	})
+
+	return order
+}
+
+func (provider *FailoverProvider) failed(index int, err error) {
+	provider.mutex.Lock()
+	defer provider.mutex.Unlock()
+	provider.statuses[index].Healthy = false
+	provider.statuses[index].LastError = err
+	provider.statuses[index].FailedAt = time.Now()
+}
+
+func (provider *FailoverProvider) succeeded(index int) {
+	provider.mutex.Lock()
+	defer provider.mutex.Unlock()
+	provider.statuses[index].Healthy = true
+}
+
+// errNoEndpoint - a multi-endpoint provider built without endpoint
+var errNoEndpoint = errors.New("No endpoint configured")
+
+// isEndpointFailure reports whether err comes from the endpoint rather than
+// from the request, which another endpoint would answer the same way
+func isEndpointFailure(err error) bool {
+
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var httpError *HTTPError
+	if errors.As(err, &httpError) {
+		return httpError.StatusCode >= 500 || httpError.StatusCode == 429 ||
+			httpError.StatusCode == 401 || httpError.StatusCode == 403
+	}
+
+	return isTransient(err)
+}
@@ -0,0 +1,107 @@
+/********************************************************************************
+   This file is part of go-web3.
+   go-web3 is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-web3 is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-web3.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file http-error.go
+ */
+
+package providers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cellcycle/go-web3/constants"
+	"github.com/cellcycle/go-web3/dto"
+)
+
+// maxHTTPErrorBody - the part of an error response kept in HTTPError.Body
+const maxHTTPErrorBody = 4096
+
+// HTTPError - A response of the node with a status other than 2xx.
+// When the body is a JSON-RPC error, errors.As reaches it as a *dto.RPCError.
+type HTTPError struct {
+	StatusCode int
+	Status     string
+	Header     http.Header
+	// Body holds the first 4KB of the response body
+	Body []byte
+	// RetryAfter is the wait asked by Retry-After, 0 when it is missing
+	RetryAfter time.Duration
+
+	rpcError *dto.RPCError
+}
+
+func newHTTPError(resp *http.Response, body io.Reader) *HTTPError {
+
+	httpError := &HTTPError{StatusCode: resp.StatusCode, Status: resp.Status, Header: resp.Header}
+
+	httpError.Body, _ = ioutil.ReadAll(io.LimitReader(body, maxHTTPErrorBody))
+	httpError.RetryAfter = parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+
+	pointer := &dto.RequestResult{}
+	if json.Unmarshal(httpError.Body, pointer) == nil && pointer.Error != nil {
+		httpError.rpcError = dto.NewRPCError(pointer.Error)
+	}
+
+	return httpError
+}
+
+func (err *HTTPError) Error() string {
+
+	body := strings.TrimSpace(string(err.Body))
+	if body == "" {
+		return fmt.Sprintf("HTTP %s", err.Status)
+	}
+
+	return fmt.Sprintf("HTTP %s: %s", err.Status, body)
+}
+
+// Unwrap - The JSON-RPC error found in the body, if any
+func (err *HTTPError) Unwrap() error {
+	if err.rpcError == nil {
+		return nil
+	}
+	return err.rpcError
+}
+
+// Is - Matches customerror.RATELIMITED on a 429 status
+func (err *HTTPError) Is(target error) bool {
+	return target == customerror.RATELIMITED && err.StatusCode == http.StatusTooManyRequests
+}
+
+// parseRetryAfter reads a Retry-After given in seconds or as an HTTP date
+func parseRetryAfter(value string, now time.Time) time.Duration {
+
+	value = strings.TrimSpace(value)
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		if date.Before(now) {
+			return 0
+		}
+		return date.Sub(now)
+	}
+
+	return 0
+}
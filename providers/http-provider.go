@@ -22,6 +22,9 @@
 package providers
 
 import (
+	"compress/gzip"
+	"context"
+	"io"
 	"io/ioutil"
 	"math/rand"
 	"net/http"
@@ -34,10 +37,10 @@ import (
 )
 
 type HTTPProvider struct {
-	address string
+	url     string
 	timeout int32
-	secure  bool
 	client  *http.Client
+	auth    Authenticator
 }
 
 func NewHTTPProvider(address string, timeout int32, secure bool) *HTTPProvider {
@@ -47,51 +50,132 @@ func NewHTTPProvider(address string, timeout int32, secure bool) *HTTPProvider {
 }
 
 func NewHTTPProviderWithClient(address string, timeout int32, secure bool, client *http.Client) *HTTPProvider {
-	provider := new(HTTPProvider)
-	provider.address = address
+
+	prefix := "http://"
+	if secure {
+		prefix = "https://"
+	}
+
+	provider := NewHTTPProviderURLWithClient(prefix+address, client)
+	provider.timeout = timeout
+
+	return provider
+}
+
+// NewHTTPProviderURL - A provider posting to the full url of the node, path and query included,
+// such as https://mainnet.example.org/v3/key. timeout is in seconds.
+func NewHTTPProviderURL(url string, timeout int32) *HTTPProvider {
+	provider := NewHTTPProviderURLWithClient(url, &http.Client{
+		Timeout: time.Second * time.Duration(timeout),
+	})
 	provider.timeout = timeout
-	provider.secure = secure
+	return provider
+}
+
+// NewHTTPProviderURLWithClient - Same as NewHTTPProviderURL, sending the requests with client
+func NewHTTPProviderURLWithClient(url string, client *http.Client) *HTTPProvider {
+	provider := new(HTTPProvider)
+	provider.url = url
 	provider.client = client
 
 	return provider
 }
 
+// WithAuth - A copy of the provider authenticating each of its requests with auth
+func (provider HTTPProvider) WithAuth(auth Authenticator) *HTTPProvider {
+	provider.auth = auth
+	return &provider
+}
+
+// Endpoint - The url the requests are posted to
+func (provider HTTPProvider) Endpoint() string {
+	return provider.url
+}
+
 func (provider HTTPProvider) SendRequest(v interface{}, method string, params interface{}) error {
+	return provider.SendRequestCtx(context.Background(), v, method, params)
+}
+
+func (provider HTTPProvider) SendRequestCtx(ctx context.Context, v interface{}, method string, params interface{}) error {
 
 	bodyString := util.JSONRPCObject{Version: "2.0", Method: method, Params: params, ID: rand.Intn(100)}
 
-	prefix := "http://"
-	if provider.secure {
-		prefix = "https://"
+	bodyBytes, err := provider.post(ctx, bodyString.AsJsonString())
+	if err != nil {
+		return err
 	}
 
-	body := strings.NewReader(bodyString.AsJsonString())
-	req, err := http.NewRequest("POST", prefix+provider.address, body)
+	return json.Unmarshal(bodyBytes, v)
+
+}
+
+func (provider HTTPProvider) SendBatch(batch []BatchElem) error {
+	return provider.SendBatchCtx(context.Background(), batch)
+}
+
+func (provider HTTPProvider) SendBatchCtx(ctx context.Context, batch []BatchElem) error {
+
+	request, positions := newBatchRequest(batch, sequentialIDs())
+
+	message, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	bodyBytes, err := provider.post(ctx, string(message))
 	if err != nil {
 		return err
 	}
 
+	return dispatchBatchResponse(batch, positions, bodyBytes)
+
+}
+
+// post sends message and returns the body of the response. A response other than 2xx
+// is an *HTTPError, the request is never sent again: the Retry middleware honors the
+// Retry-After of the 429 and 503 responses for the methods it may repeat.
+func (provider HTTPProvider) post(ctx context.Context, message string) ([]byte, error) {
+
+	body := strings.NewReader(message)
+	req, err := http.NewRequestWithContext(ctx, "POST", provider.url, body)
+	if err != nil {
+		return nil, err
+	}
+
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Add("Accept", "application/json")
+	req.Header.Set("Accept-Encoding", "gzip")
+
+	if provider.auth != nil {
+		if err := provider.auth.Authenticate(req.Header); err != nil {
+			return nil, err
+		}
+	}
 
 	resp, err := provider.client.Do(req)
 
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	defer resp.Body.Close()
 
-	var bodyBytes []byte
+	var reader io.Reader = resp.Body
 
-	if resp.StatusCode == 200 {
-		bodyBytes, err = ioutil.ReadAll(resp.Body)
+	if strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		gzipReader, err := gzip.NewReader(resp.Body)
 		if err != nil {
-			return err
+			return nil, err
 		}
+		defer gzipReader.Close()
+		reader = gzipReader
 	}
 
-	return json.Unmarshal(bodyBytes, v)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, newHTTPError(resp, reader)
+	}
+
+	return ioutil.ReadAll(reader)
 
 }
 
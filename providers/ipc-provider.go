@@ -22,54 +22,109 @@
 package providers
 
 import (
+	"context"
 	"encoding/json"
-	"math/rand"
 	"net"
 	"path/filepath"
-
-	"log"
-
-	"github.com/cellcycle/go-web3/providers/util"
+	"time"
 )
 
 type IPCProvider struct {
 	endpoint string
+	options  IPCOptions
+	client   *streamClient
+}
+
+// IPCOptions - How an IPC provider connects to the node
+type IPCOptions struct {
+	// DialTimeout bounds the connection, in addition to the context of the call
+	DialTimeout time.Duration
+	// Reconnect restores the connection when it is lost, as NewWebSocketProviderWithReconnect does
+	Reconnect *ReconnectPolicy
 }
 
+// NewIPCProvider - A provider over the unix socket of the node. A single connection is dialed
+// on the first call and shared by the concurrent calls, it is dialed again after it breaks.
 func NewIPCProvider(endpoint string) *IPCProvider {
+	return NewIPCProviderWithOptions(endpoint, IPCOptions{})
+}
+
+// NewIPCProviderWithOptions - An IPC provider connecting to the node with options
+func NewIPCProviderWithOptions(endpoint string, options IPCOptions) *IPCProvider {
 	provider := new(IPCProvider)
 	provider.endpoint, _ = filepath.Abs(endpoint)
+	provider.options = options
+	provider.client = newStreamClient(provider.dial)
+	if options.Reconnect != nil {
+		policy := options.Reconnect.withDefaults()
+		provider.client.policy = &policy
+	}
 	return provider
 }
 
-func (provider IPCProvider) SendRequest(v interface{}, method string, params interface{}) error {
+// Endpoint - The path of the unix socket of the node
+func (provider *IPCProvider) Endpoint() string {
+	return provider.endpoint
+}
+
+func (provider *IPCProvider) SendRequest(v interface{}, method string, params interface{}) error {
+	return provider.SendRequestCtx(context.Background(), v, method, params)
+}
+
+func (provider *IPCProvider) SendRequestCtx(ctx context.Context, v interface{}, method string, params interface{}) error {
+	return provider.client.sendRequest(ctx, v, method, params)
+}
+
+func (provider *IPCProvider) SendBatch(batch []BatchElem) error {
+	return provider.SendBatchCtx(context.Background(), batch)
+}
 
-	bodyString := util.JSONRPCObject{Version: "2.0", Method: method, Params: params, ID: rand.Intn(100)}
+func (provider *IPCProvider) SendBatchCtx(ctx context.Context, batch []BatchElem) error {
+	return provider.client.sendBatch(ctx, batch)
+}
 
-	client, err := net.DialUnix("unix", nil, &net.UnixAddr{Name: provider.endpoint, Net: "unix"})
+func (provider *IPCProvider) SubscribeCtx(ctx context.Context, namespace string, args ...interface{}) (*Subscription, error) {
+	return provider.client.subscribe(ctx, namespace, args)
+}
 
+func (provider *IPCProvider) Close() error {
+	return provider.client.close()
+}
+
+func (provider *IPCProvider) dial(ctx context.Context) (streamCodec, error) {
+
+	dialer := net.Dialer{Timeout: provider.options.DialTimeout}
+	conn, err := dialer.DialContext(ctx, "unix", provider.endpoint)
 	if err != nil {
-		log.Println(err)
-		return err
+		return nil, err
 	}
 
-	defer client.Close()
+	return &ipcCodec{conn: conn, decoder: json.NewDecoder(conn)}, nil
+}
 
-	encoder := json.NewEncoder(client)
-	decoder := json.NewDecoder(client)
+// ipcCodec - A stream of JSON values, the messages are not delimited
+type ipcCodec struct {
+	conn    net.Conn
+	decoder *json.Decoder
+}
 
-	if err := encoder.Encode(bodyString); err != nil {
-		log.Println(err)
-		return err
-	}
+func (codec *ipcCodec) ReadMessage() (json.RawMessage, error) {
+	var message json.RawMessage
+	err := codec.decoder.Decode(&message)
+	return message, err
+}
 
-	if err := decoder.Decode(v); err != nil {
-		log.Println(err)
-		return err
-	}
+func (codec *ipcCodec) WriteMessage(ctx context.Context, message []byte) error {
 
-	return nil
+	if deadline, ok := ctx.Deadline(); ok {
+		codec.conn.SetWriteDeadline(deadline)
+		defer codec.conn.SetWriteDeadline(time.Time{})
+	}
 
+	_, err := codec.conn.Write(message)
+	return err
 }
 
-func (provider IPCProvider) Close() error { return nil }
+func (codec *ipcCodec) Close() error {
+	return codec.conn.Close()
+}
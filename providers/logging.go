@@ -0,0 +1,143 @@
+/********************************************************************************
+   This file is part of go-web3.
+   go-web3 is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-web3 is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-web3.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file logging.go
+ */
+
+package providers
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Redacted - What the secrets of the logged params are replaced with
+const Redacted = "[REDACTED]"
+
+// secretParams - the position of the passwords and keys in the personal_* params
+var secretParams = map[string][]int{
+	"personal_newAccount":      {0},
+	"personal_importRawKey":    {0, 1},
+	"personal_unlockAccount":   {1},
+	"personal_sendTransaction": {1},
+	"personal_signTransaction": {1},
+	"personal_sign":            {2},
+	"personal_lockAccount":     {},
+	"personal_listAccounts":    {},
+	"personal_listWallets":     {},
+	"personal_ecRecover":       {},
+}
+
+// LogEntry - A request and its outcome, as passed to the Logging sink
+type LogEntry struct {
+	Method string
+	// Params are the params of the request, with the passwords redacted
+	Params interface{}
+	// Result is the value the response was decoded into
+	Result interface{}
+	// Err is the error returned to the caller
+	Err      error
+	Duration time.Duration
+	// Batch is true for the calls sent in a batch, which share the duration
+	Batch bool
+}
+
+// Logging - Passes every request to sink once it is answered. The passwords
+// and the private keys sent to the personal_* methods are redacted, all the
+// params of an unknown personal_* method are.
+func Logging(sink func(entry LogEntry)) Middleware {
+
+	return func(next ProviderInterface) ProviderInterface {
+
+		provider := newMiddlewareProvider(next)
+
+		provider.send = func(ctx context.Context, v interface{}, method string, params interface{}) error {
+
+			start := time.Now()
+			err := next.SendRequestCtx(ctx, v, method, params)
+
+			sink(LogEntry{
+				Method:   method,
+				Params:   RedactParams(method, params),
+				Result:   v,
+				Err:      err,
+				Duration: time.Since(start),
+			})
+
+			return err
+		}
+
+		if batchProvider, ok := next.(BatchProvider); ok {
+			provider.sendBatch = func(ctx context.Context, batch []BatchElem) error {
+
+				start := time.Now()
+				err := batchProvider.SendBatchCtx(ctx, batch)
+				duration := time.Since(start)
+
+				for index := range batch {
+					entry := LogEntry{
+						Method:   batch[index].Method,
+						Params:   RedactParams(batch[index].Method, batch[index].Params),
+						Result:   batch[index].Result,
+						Err:      batch[index].Error,
+						Duration: duration,
+						Batch:    true,
+					}
+					if err != nil {
+						entry.Err = err
+					}
+					sink(entry)
+				}
+
+				return err
+			}
+		}
+
+		return provider
+	}
+}
+
+// RedactParams - A copy of params with the secrets of method replaced by Redacted
+func RedactParams(method string, params interface{}) interface{} {
+
+	if !strings.HasPrefix(method, "personal_") || params == nil {
+		return params
+	}
+
+	value := reflect.ValueOf(params)
+	if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+		return Redacted
+	}
+
+	positions, known := secretParams[method]
+
+	redacted := make([]interface{}, value.Len())
+	for index := range redacted {
+		redacted[index] = value.Index(index).Interface()
+		if !known {
+			redacted[index] = Redacted
+		}
+	}
+
+	for _, position := range positions {
+		if position < len(redacted) {
+			redacted[position] = Redacted
+		}
+	}
+
+	return redacted
+}
@@ -0,0 +1,229 @@
+/********************************************************************************
+   This file is part of go-web3.
+   go-web3 is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-web3 is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-web3.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file metrics.go
+ */
+
+package providers
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// RequestLabels - The labels of the Requests counter of MetricsOptions, in order
+var RequestLabels = []string{"method", "endpoint", "error_class"}
+
+// LatencyLabels - The labels of the Latency and ParamsSize histograms of MetricsOptions, in order
+var LatencyLabels = []string{"method", "endpoint"}
+
+// Counter - A counter with labels, such as a prometheus.CounterVec:
+//
+//	providers.CounterFunc(func(labels ...string) { vec.WithLabelValues(labels...).Inc() })
+type Counter interface {
+	Inc(labels ...string)
+}
+
+// Histogram - A histogram with labels, such as a prometheus.HistogramVec:
+//
+//	providers.HistogramFunc(func(value float64, labels ...string) { vec.WithLabelValues(labels...).Observe(value) })
+type Histogram interface {
+	Observe(value float64, labels ...string)
+}
+
+// CounterFunc - A function used as a Counter
+type CounterFunc func(labels ...string)
+
+// Inc - Calls function with labels
+func (function CounterFunc) Inc(labels ...string) {
+	function(labels...)
+}
+
+// HistogramFunc - A function used as a Histogram
+type HistogramFunc func(value float64, labels ...string)
+
+// Observe - Calls function with value and labels
+func (function HistogramFunc) Observe(value float64, labels ...string) {
+	function(value, labels...)
+}
+
+// MetricsOptions - The metrics updated by a metrics observer, the nil ones are left out
+type MetricsOptions struct {
+	// Requests counts the requests by RequestLabels
+	Requests Counter
+	// Latency observes the seconds each request took by LatencyLabels
+	Latency Histogram
+	// ParamsSize observes the size in bytes of the params by LatencyLabels
+	ParamsSize Histogram
+}
+
+type metricsObserver struct {
+	options MetricsOptions
+}
+
+// NewMetricsObserver - An Observer counting the requests and observing their latency
+func NewMetricsObserver(options MetricsOptions) Observer {
+	return &metricsObserver{options: options}
+}
+
+func (observer *metricsObserver) RequestStarted(ctx context.Context, request RequestInfo) context.Context {
+	return ctx
+}
+
+func (observer *metricsObserver) RequestFinished(ctx context.Context, request RequestInfo, outcome RequestOutcome) {
+
+	if observer.options.Requests != nil {
+		observer.options.Requests.Inc(request.Method, request.Endpoint, string(outcome.Class))
+	}
+	if observer.options.Latency != nil {
+		observer.options.Latency.Observe(outcome.Latency.Seconds(), request.Method, request.Endpoint)
+	}
+	if observer.options.ParamsSize != nil {
+		observer.options.ParamsSize.Observe(float64(request.ParamsSize), request.Method, request.Endpoint)
+	}
+}
+
+// DefaultLatencyBuckets - The upper bounds in seconds of the buckets of a MemoryHistogram,
+// the default buckets of the Prometheus client
+var DefaultLatencyBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// labelKey joins labels into a map key, the labels never hold a NUL
+func labelKey(labels []string) string {
+	return strings.Join(labels, "\x00")
+}
+
+// MemoryCounter - A Counter kept in memory, for the tests and the services
+// without a metrics backend
+type MemoryCounter struct {
+	mutex  sync.Mutex
+	values map[string]uint64
+}
+
+// NewMemoryCounter - An empty MemoryCounter
+func NewMemoryCounter() *MemoryCounter {
+	return &MemoryCounter{values: make(map[string]uint64)}
+}
+
+// Inc - Adds one to the value of labels
+func (counter *MemoryCounter) Inc(labels ...string) {
+	counter.mutex.Lock()
+	counter.values[labelKey(labels)]++
+	counter.mutex.Unlock()
+}
+
+// Value - The value of labels
+func (counter *MemoryCounter) Value(labels ...string) uint64 {
+	counter.mutex.Lock()
+	defer counter.mutex.Unlock()
+	return counter.values[labelKey(labels)]
+}
+
+// Labels - Every set of labels counted, sorted
+func (counter *MemoryCounter) Labels() [][]string {
+
+	counter.mutex.Lock()
+	defer counter.mutex.Unlock()
+
+	keys := make([]string, 0, len(counter.values))
+	for key := range counter.values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	labels := make([][]string, len(keys))
+	for index, key := range keys {
+		labels[index] = strings.Split(key, "\x00")
+	}
+
+	return labels
+}
+
+// HistogramSnapshot - The observations of a MemoryHistogram for a set of labels
+type HistogramSnapshot struct {
+	Count uint64
+	Sum   float64
+	// Buckets holds the number of observations lower or equal to each upper
+	// bound of the histogram, cumulated as in Prometheus
+	Buckets []uint64
+}
+
+// MemoryHistogram - A Histogram kept in memory, for the tests and the services
+// without a metrics backend
+type MemoryHistogram struct {
+	bounds []float64
+
+	mutex  sync.Mutex
+	values map[string]*HistogramSnapshot
+}
+
+// NewMemoryHistogram - An empty MemoryHistogram with the given bucket upper bounds,
+// DefaultLatencyBuckets when none
+func NewMemoryHistogram(bounds ...float64) *MemoryHistogram {
+
+	if len(bounds) == 0 {
+		bounds = DefaultLatencyBuckets
+	}
+
+	sorted := append([]float64(nil), bounds...)
+	sort.Float64s(sorted)
+
+	return &MemoryHistogram{bounds: sorted, values: make(map[string]*HistogramSnapshot)}
+}
+
+// Observe - Adds value to the observations of labels
+func (histogram *MemoryHistogram) Observe(value float64, labels ...string) {
+
+	histogram.mutex.Lock()
+	defer histogram.mutex.Unlock()
+
+	key := labelKey(labels)
+	snapshot := histogram.values[key]
+	if snapshot == nil {
+		snapshot = &HistogramSnapshot{Buckets: make([]uint64, len(histogram.bounds))}
+		histogram.values[key] = snapshot
+	}
+
+	snapshot.Count++
+	snapshot.Sum += value
+	for index, bound := range histogram.bounds {
+		if value <= bound {
+			snapshot.Buckets[index]++
+		}
+	}
+}
+
+// Bounds - The upper bounds of the buckets
+func (histogram *MemoryHistogram) Bounds() []float64 {
+	return append([]float64(nil), histogram.bounds...)
+}
+
+// Snapshot - A copy of the observations of labels
+func (histogram *MemoryHistogram) Snapshot(labels ...string) HistogramSnapshot {
+
+	histogram.mutex.Lock()
+	defer histogram.mutex.Unlock()
+
+	snapshot := histogram.values[labelKey(labels)]
+	if snapshot == nil {
+		return HistogramSnapshot{Buckets: make([]uint64, len(histogram.bounds))}
+	}
+
+	copied := *snapshot
+	copied.Buckets = append([]uint64(nil), snapshot.Buckets...)
+
+	return copied
+}
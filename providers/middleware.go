@@ -0,0 +1,94 @@
+/********************************************************************************
+   This file is part of go-web3.
+   go-web3 is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-web3 is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-web3.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file middleware.go
+ */
+
+package providers
+
+import (
+	"context"
+
+	"github.com/cellcycle/go-web3/constants"
+)
+
+// Middleware - Wraps a provider to act on every request going through it
+type Middleware func(next ProviderInterface) ProviderInterface
+
+// Chain - Wraps provider with middlewares, the first one sees the requests first.
+// The wrapped provider keeps the batches and the subscriptions of provider.
+func Chain(provider ProviderInterface, middlewares ...Middleware) ProviderInterface {
+	for index := len(middlewares) - 1; index >= 0; index-- {
+		provider = middlewares[index](provider)
+	}
+	return provider
+}
+
+// middlewareProvider - The provider returned by a middleware. send and sendBatch
+// replace the calls of next, the other methods are passed through.
+type middlewareProvider struct {
+	next      ProviderInterface
+	send      func(ctx context.Context, v interface{}, method string, params interface{}) error
+	sendBatch func(ctx context.Context, batch []BatchElem) error
+}
+
+func newMiddlewareProvider(next ProviderInterface) *middlewareProvider {
+	provider := new(middlewareProvider)
+	provider.next = next
+	provider.send = next.SendRequestCtx
+	provider.sendBatch = func(ctx context.Context, batch []BatchElem) error {
+		if batchProvider, ok := next.(BatchProvider); ok {
+			return batchProvider.SendBatchCtx(ctx, batch)
+		}
+		// one call at a time, through the middleware
+		return sendSequentially(ctx, provider.SendRequestCtx, batch)
+	}
+	return provider
+}
+
+func (provider *middlewareProvider) SendRequest(v interface{}, method string, params interface{}) error {
+	return provider.SendRequestCtx(context.Background(), v, method, params)
+}
+
+func (provider *middlewareProvider) SendRequestCtx(ctx context.Context, v interface{}, method string, params interface{}) error {
+	return provider.send(ctx, v, method, params)
+}
+
+func (provider *middlewareProvider) SendBatch(batch []BatchElem) error {
+	return provider.SendBatchCtx(context.Background(), batch)
+}
+
+func (provider *middlewareProvider) SendBatchCtx(ctx context.Context, batch []BatchElem) error {
+	return provider.sendBatch(ctx, batch)
+}
+
+func (provider *middlewareProvider) SubscribeCtx(ctx context.Context, namespace string, args ...interface{}) (*Subscription, error) {
+
+	subscriber, ok := provider.next.(SubscriptionProvider)
+	if !ok {
+		return nil, customerror.SUBSCRIPTIONSUNSUPPORTED
+	}
+
+	return subscriber.SubscribeCtx(ctx, namespace, args...)
+}
+
+// Endpoint - The endpoint of the wrapped provider, empty when it has none
+func (provider *middlewareProvider) Endpoint() string {
+	return endpointOf(provider.next)
+}
+
+func (provider *middlewareProvider) Close() error {
+	return provider.next.Close()
+}
@@ -0,0 +1,371 @@
+/********************************************************************************
+   This file is part of go-web3.
+   go-web3 is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-web3 is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-web3.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file mock-provider.go
+ */
+
+package providers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sync"
+
+	"github.com/cellcycle/go-web3/dto"
+)
+
+// MockRequest - A request received by a MockProvider
+type MockRequest struct {
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+}
+
+// String - The method followed by the JSON params, as in `eth_getBalance ["0x01","latest"]`
+func (request MockRequest) String() string {
+	return request.Method + " " + string(request.Params)
+}
+
+// MockResponse - The scripted answer to the requests of a method.
+// It is set up before the requests are sent.
+type MockResponse struct {
+	method string
+	// params matches any params when nil
+	params   json.RawMessage
+	result   json.RawMessage
+	rpcError *dto.Error
+	err      error
+	once     bool
+	used     bool
+}
+
+// Return - Answers with result, encoded to JSON
+func (response *MockResponse) Return(result interface{}) *MockResponse {
+	response.result, response.err = json.Marshal(result)
+	return response
+}
+
+// ReturnError - Answers with a JSON-RPC error object
+func (response *MockResponse) ReturnError(code int, message string, data interface{}) *MockResponse {
+	response.rpcError = &dto.Error{Code: code, Message: message, Data: data}
+	return response
+}
+
+// Fail - Returns err from SendRequest, as a transport failure would
+func (response *MockResponse) Fail(err error) *MockResponse {
+	response.err = err
+	return response
+}
+
+// Once - Answers a single request, the next matching response answers the following ones
+func (response *MockResponse) Once() *MockResponse {
+	response.once = true
+	return response
+}
+
+// mockEntry - a request and its answer, as stored in a fixture file
+type mockEntry struct {
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  *dto.Error      `json:"error,omitempty"`
+}
+
+// MockProvider - An in-memory provider for the tests. It answers with the
+// responses scripted with On, replays a fixture file written by a recording
+// provider, or records the traffic of a real provider.
+type MockProvider struct {
+	mutex     sync.Mutex
+	responses []*MockResponse
+	requests  []MockRequest
+	lastID    int
+
+	// next and path are set when recording
+	next    ProviderInterface
+	path    string
+	entries []mockEntry
+}
+
+// NewMockProvider - A provider without any response, every request fails until scripted with On
+func NewMockProvider() *MockProvider {
+	return new(MockProvider)
+}
+
+// NewRecordingProvider - Sends the requests to next and writes them, with
+// their responses, to the fixture file at path on Close
+func NewRecordingProvider(next ProviderInterface, path string) *MockProvider {
+
+	provider := new(MockProvider)
+	provider.next = next
+	provider.path = path
+
+	return provider
+}
+
+// NewReplayProvider - Answers the requests of the fixture file at path, each
+// recorded response once and in the recorded order
+func NewReplayProvider(path string) (*MockProvider, error) {
+
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []mockEntry
+	if err := json.Unmarshal(content, &entries); err != nil {
+		return nil, fmt.Errorf("Invalid fixture %s: %v", path, err)
+	}
+
+	provider := new(MockProvider)
+
+	for _, entry := range entries {
+
+		params, err := compactJSON(entry.Params)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid fixture %s: %v", path, err)
+		}
+
+		result := entry.Result
+		if result == nil && entry.Error == nil {
+			result = json.RawMessage("null")
+		}
+
+		provider.responses = append(provider.responses, &MockResponse{
+			method:   entry.Method,
+			params:   params,
+			result:   result,
+			rpcError: entry.Error,
+			once:     true,
+		})
+	}
+
+	return provider, nil
+}
+
+// On - Scripts the response to the requests of method. When params are given
+// only the requests with the same JSON params match. The first matching
+// response answers, a response set with Once only answers one request.
+func (provider *MockProvider) On(method string, params ...interface{}) *MockResponse {
+
+	response := &MockResponse{method: method}
+
+	if len(params) > 0 {
+		response.params, response.err = canonicalParams(params)
+	}
+
+	provider.mutex.Lock()
+	provider.responses = append(provider.responses, response)
+	provider.mutex.Unlock()
+
+	return response
+}
+
+// Requests - The requests received so far, in order
+func (provider *MockProvider) Requests() []MockRequest {
+
+	provider.mutex.Lock()
+	defer provider.mutex.Unlock()
+
+	requests := make([]MockRequest, len(provider.requests))
+	copy(requests, provider.requests)
+
+	return requests
+}
+
+// Unused - The requests of the responses set with Once, or replayed, which were not sent
+func (provider *MockProvider) Unused() []MockRequest {
+
+	provider.mutex.Lock()
+	defer provider.mutex.Unlock()
+
+	var unused []MockRequest
+	for _, response := range provider.responses {
+		if response.once && !response.used {
+			unused = append(unused, MockRequest{Method: response.method, Params: response.params})
+		}
+	}
+
+	return unused
+}
+
+func (provider *MockProvider) SendRequest(v interface{}, method string, params interface{}) error {
+	return provider.SendRequestCtx(context.Background(), v, method, params)
+}
+
+func (provider *MockProvider) SendRequestCtx(ctx context.Context, v interface{}, method string, params interface{}) error {
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	encoded, err := canonicalParams(params)
+	if err != nil {
+		return err
+	}
+
+	if provider.next != nil {
+		return provider.record(ctx, v, method, params, encoded)
+	}
+
+	provider.mutex.Lock()
+
+	provider.requests = append(provider.requests, MockRequest{Method: method, Params: encoded})
+
+	var response *MockResponse
+	for _, candidate := range provider.responses {
+		if candidate.method != method || (candidate.once && candidate.used) {
+			continue
+		}
+		if candidate.params != nil && !bytes.Equal(candidate.params, encoded) {
+			continue
+		}
+		response = candidate
+		break
+	}
+
+	if response == nil {
+		provider.mutex.Unlock()
+		return fmt.Errorf("Unexpected request %s %s", method, encoded)
+	}
+
+	response.used = true
+	provider.lastID++
+	id := provider.lastID
+
+	provider.mutex.Unlock()
+
+	if response.err != nil {
+		return response.err
+	}
+
+	message := struct {
+		ID      int             `json:"id"`
+		Version string          `json:"jsonrpc"`
+		Result  json.RawMessage `json:"result,omitempty"`
+		Error   *dto.Error      `json:"error,omitempty"`
+	}{id, "2.0", response.result, response.rpcError}
+
+	if message.Result == nil && message.Error == nil {
+		message.Result = json.RawMessage("null")
+	}
+
+	body, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(body, v)
+}
+
+// SendBatch - Answers the calls of batch one after the other, as they would be one by one
+func (provider *MockProvider) SendBatch(batch []BatchElem) error {
+	return provider.SendBatchCtx(context.Background(), batch)
+}
+
+// SendBatchCtx - Same as SendBatch, using ctx to cancel the requests or set their deadline
+func (provider *MockProvider) SendBatchCtx(ctx context.Context, batch []BatchElem) error {
+	return sendSequentially(ctx, provider.SendRequestCtx, batch)
+}
+
+// record sends the request to the recorded provider and keeps its answer
+func (provider *MockProvider) record(ctx context.Context, v interface{}, method string, params interface{}, encoded json.RawMessage) error {
+
+	provider.mutex.Lock()
+	provider.requests = append(provider.requests, MockRequest{Method: method, Params: encoded})
+	provider.mutex.Unlock()
+
+	var response json.RawMessage
+	if err := provider.next.SendRequestCtx(ctx, &response, method, params); err != nil {
+		return err
+	}
+
+	entry := mockEntry{Method: method, Params: encoded}
+	if err := json.Unmarshal(response, &entry); err != nil {
+		return err
+	}
+	// the method and the params are the ones sent, not the ones of the response
+	entry.Method, entry.Params = method, encoded
+
+	provider.mutex.Lock()
+	provider.entries = append(provider.entries, entry)
+	provider.mutex.Unlock()
+
+	return json.Unmarshal(response, v)
+}
+
+// Save - Writes the requests recorded so far to the fixture file
+func (provider *MockProvider) Save() error {
+
+	if provider.next == nil {
+		return nil
+	}
+
+	provider.mutex.Lock()
+	entries := provider.entries
+	provider.mutex.Unlock()
+
+	if entries == nil {
+		entries = []mockEntry{}
+	}
+
+	content, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(provider.path, append(content, '\n'), 0644)
+}
+
+// Close - Writes the fixture file and closes the recorded provider when recording
+func (provider *MockProvider) Close() error {
+
+	if provider.next == nil {
+		return nil
+	}
+
+	err := provider.Save()
+	if closeErr := provider.next.Close(); err == nil {
+		err = closeErr
+	}
+
+	return err
+}
+
+// canonicalParams encodes params the way they are compared, without spaces
+func canonicalParams(params interface{}) (json.RawMessage, error) {
+
+	encoded, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+
+	return compactJSON(encoded)
+}
+
+func compactJSON(value json.RawMessage) (json.RawMessage, error) {
+
+	if len(value) == 0 {
+		return json.RawMessage("null"), nil
+	}
+
+	var buffer bytes.Buffer
+	if err := json.Compact(&buffer, value); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
@@ -0,0 +1,231 @@
+/********************************************************************************
+   This file is part of go-web3.
+   go-web3 is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-web3 is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-web3.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file observer.go
+ */
+
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"time"
+
+	"github.com/cellcycle/go-web3/constants"
+	"github.com/cellcycle/go-web3/dto"
+)
+
+// ErrorClass - The kind of failure of a request, suited to a metric label
+type ErrorClass string
+
+const (
+	// ErrorNone - the request succeeded
+	ErrorNone ErrorClass = "none"
+	// ErrorCanceled - the context of the request was canceled
+	ErrorCanceled ErrorClass = "canceled"
+	// ErrorTimeout - the deadline of the request or the timeout of the transport passed
+	ErrorTimeout ErrorClass = "timeout"
+	// ErrorRateLimited - the node or the HTTP gateway asked to slow down
+	ErrorRateLimited ErrorClass = "rate_limited"
+	// ErrorHTTP - the node answered with a non-2xx status
+	ErrorHTTP ErrorClass = "http"
+	// ErrorNode - the node answered with a JSON-RPC error object
+	ErrorNode ErrorClass = "rpc"
+	// ErrorDecode - the response could not be decoded
+	ErrorDecode ErrorClass = "decode"
+	// ErrorTransport - the node could not be reached or the connection broke
+	ErrorTransport ErrorClass = "transport"
+)
+
+// ClassifyError - The ErrorClass of an error returned by a provider
+func ClassifyError(err error) ErrorClass {
+
+	if err == nil {
+		return ErrorNone
+	}
+
+	var timeout interface{ Timeout() bool }
+	var httpError *HTTPError
+	var rpcError *dto.RPCError
+	var syntaxError *json.SyntaxError
+	var typeError *json.UnmarshalTypeError
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return ErrorCanceled
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &timeout) && timeout.Timeout():
+		return ErrorTimeout
+	case errors.Is(err, customerror.RATELIMITED):
+		return ErrorRateLimited
+	case errors.As(err, &httpError):
+		return ErrorHTTP
+	case errors.As(err, &rpcError):
+		return ErrorNode
+	case errors.As(err, &syntaxError), errors.As(err, &typeError):
+		return ErrorDecode
+	}
+
+	return ErrorTransport
+}
+
+// RequestInfo - A request, as passed to an Observer
+type RequestInfo struct {
+	Method string
+	// ParamsSize is the length in bytes of the JSON encoded params
+	ParamsSize int
+	// Endpoint is the scheme and the host of the node, or the path of its
+	// socket, empty when the provider does not tell it
+	Endpoint string
+	// Batch is true for the calls sent in a batch
+	Batch bool
+}
+
+// RequestOutcome - How a request ended, as passed to an Observer
+type RequestOutcome struct {
+	Latency time.Duration
+	Class   ErrorClass
+	// Err is the error returned to the caller, or the error answered by the
+	// node when the caller reads it from its RequestResult
+	Err error
+}
+
+// Observer - Watches the requests going through the Observe middleware
+type Observer interface {
+	// RequestStarted is called before the request is sent, the request is
+	// sent with the returned context
+	RequestStarted(ctx context.Context, request RequestInfo) context.Context
+	// RequestFinished is called with the context returned by RequestStarted
+	// once the request is answered or failed
+	RequestFinished(ctx context.Context, request RequestInfo, outcome RequestOutcome)
+}
+
+// Observe - Passes every request going through the provider to observers.
+// Wrap each endpoint of a failover or quorum provider to know which one answered.
+func Observe(observers ...Observer) Middleware {
+
+	return func(next ProviderInterface) ProviderInterface {
+
+		endpoint := observedEndpoint(endpointOf(next))
+		provider := newMiddlewareProvider(next)
+
+		provider.send = func(ctx context.Context, v interface{}, method string, params interface{}) error {
+
+			request := RequestInfo{Method: method, ParamsSize: paramsSize(params), Endpoint: endpoint}
+
+			ctx, contexts := startObservers(ctx, observers, request)
+			start := time.Now()
+
+			err := next.SendRequestCtx(ctx, v, method, params)
+
+			finishObservers(contexts, observers, request, time.Since(start), err, v)
+
+			return err
+		}
+
+		if batchProvider, ok := next.(BatchProvider); ok {
+			provider.sendBatch = func(ctx context.Context, batch []BatchElem) error {
+
+				requests := make([]RequestInfo, len(batch))
+				contexts := make([][]context.Context, len(batch))
+
+				for index := range batch {
+					requests[index] = RequestInfo{
+						Method:     batch[index].Method,
+						ParamsSize: paramsSize(batch[index].Params),
+						Endpoint:   endpoint,
+						Batch:      true,
+					}
+					_, contexts[index] = startObservers(ctx, observers, requests[index])
+				}
+
+				start := time.Now()
+				err := batchProvider.SendBatchCtx(ctx, batch)
+				latency := time.Since(start)
+
+				for index := range batch {
+					callErr := batch[index].Error
+					if err != nil {
+						callErr = err
+					}
+					finishObservers(contexts[index], observers, requests[index], latency, callErr, batch[index].Result)
+				}
+
+				return err
+			}
+		}
+
+		return provider
+	}
+}
+
+// startObservers returns the context the request is sent with, and the one
+// returned by each observer to be passed back to it once the request ends
+func startObservers(ctx context.Context, observers []Observer, request RequestInfo) (context.Context, []context.Context) {
+	contexts := make([]context.Context, len(observers))
+	for index, observer := range observers {
+		ctx = observer.RequestStarted(ctx, request)
+		contexts[index] = ctx
+	}
+	return ctx, contexts
+}
+
+func finishObservers(contexts []context.Context, observers []Observer, request RequestInfo, latency time.Duration, err error, v interface{}) {
+
+	if err == nil {
+		// a node error is left in the result for the caller to read
+		if result := requestResult(v); result != nil && result.Error != nil {
+			err = dto.NewRPCError(result.Error)
+		}
+	}
+
+	outcome := RequestOutcome{Latency: latency, Class: ClassifyError(err), Err: err}
+
+	for index := len(observers) - 1; index >= 0; index-- {
+		observers[index].RequestFinished(contexts[index], request, outcome)
+	}
+}
+
+func paramsSize(params interface{}) int {
+	if params == nil {
+		return 0
+	}
+	encoded, err := json.Marshal(params)
+	if err != nil {
+		return 0
+	}
+	return len(encoded)
+}
+
+// endpointOf returns the endpoint of provider when it tells it
+func endpointOf(provider ProviderInterface) string {
+	if endpointer, ok := provider.(interface{ Endpoint() string }); ok {
+		return endpointer.Endpoint()
+	}
+	return ""
+}
+
+// observedEndpoint leaves the credentials and the path out of an endpoint
+// url, they often hold an API key
+func observedEndpoint(endpoint string) string {
+
+	parsed, err := url.Parse(endpoint)
+	if err != nil || parsed.Host == "" {
+		return endpoint
+	}
+
+	return parsed.Scheme + "://" + parsed.Host
+}
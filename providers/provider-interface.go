@@ -21,7 +21,10 @@
 
 package providers
 
+import "context"
+
 type ProviderInterface interface {
 	SendRequest(v interface{}, method string, params interface{}) error
+	SendRequestCtx(ctx context.Context, v interface{}, method string, params interface{}) error
 	Close() error
 }
@@ -0,0 +1,345 @@
+/********************************************************************************
+   This file is part of go-web3.
+   go-web3 is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-web3 is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-web3.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file quorum-provider.go
+ */
+
+package providers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/cellcycle/go-web3/dto"
+)
+
+// quorumMethods - the reads answered the same by every node in sync
+var quorumMethods = map[string]bool{
+	"net_version":               true,
+	"eth_chainId":               true,
+	"eth_getBalance":            true,
+	"eth_getStorageAt":          true,
+	"eth_getTransactionCount":   true,
+	"eth_getCode":               true,
+	"eth_call":                  true,
+	"eth_getBlockByHash":        true,
+	"eth_getBlockByNumber":      true,
+	"eth_getTransactionByHash":  true,
+	"eth_getTransactionReceipt": true,
+	"eth_getLogs":               true,
+}
+
+// IsQuorumMethod - Reports whether method is a read every node in sync answers identically
+func IsQuorumMethod(method string) bool {
+	return quorumMethods[method]
+}
+
+// headParams returns the params of the call and the position of its block param
+// when the call reads the state at a block relative to the head of the node, such
+// as latest or pending, which differs between nodes a block apart. The block param
+// defaults to latest when it is left out.
+func headParams(method string, params interface{}) ([]json.RawMessage, int, bool) {
+
+	position, ok := blockParams[method]
+	if !ok {
+		return nil, 0, false
+	}
+
+	encoded, err := canonicalParams(params)
+	if err != nil {
+		return nil, 0, false
+	}
+
+	var decoded []json.RawMessage
+	if err := json.Unmarshal(encoded, &decoded); err != nil || position > len(decoded) {
+		return nil, 0, false
+	}
+
+	if position == len(decoded) {
+		return decoded, position, true
+	}
+
+	var block string
+	if err := json.Unmarshal(decoded[position], &block); err != nil {
+		// EIP-1898 names the block by hash or by number with an object
+		return nil, 0, false
+	}
+
+	_, isNumber := blockNumberParam(block)
+
+	return decoded, position, !isNumber && block != "earliest"
+}
+
+// QuorumOptions - How a QuorumProvider compares the answers of its endpoints
+type QuorumOptions struct {
+	// Threshold is the number of identical answers needed, a majority when 0
+	Threshold int
+	// Methods selects the methods sent to every endpoint, IsQuorumMethod when nil.
+	// The other methods are sent to the endpoints in order until one answers.
+	// The reads at a block relative to the head, such as latest or pending, are
+	// pinned to the lowest head of the endpoints, a block every one of them has.
+	Methods func(method string) bool
+	// OnDisagreement is called, once every endpoint answered, with the
+	// answers of the endpoints which did not agree with the quorum
+	OnDisagreement func(method string, disagreeing []QuorumAnswer)
+}
+
+// QuorumAnswer - The answer of an endpoint to a quorum read
+type QuorumAnswer struct {
+	// Index is the position of the endpoint in the providers given
+	Index int
+	// Response is the JSON-RPC response, nil when Err is set
+	Response json.RawMessage
+	Err      error
+}
+
+// QuorumError - No answer was given by enough endpoints
+type QuorumError struct {
+	Method    string
+	Threshold int
+	Answers   []QuorumAnswer
+}
+
+func (err *QuorumError) Error() string {
+	return fmt.Sprintf("No quorum of %d for %s among %d answers", err.Threshold, err.Method, len(err.Answers))
+}
+
+// QuorumProvider - Sends the quorum reads to every endpoint and returns the
+// answer given by at least Threshold of them
+type QuorumProvider struct {
+	providers []ProviderInterface
+	options   QuorumOptions
+}
+
+// NewQuorumProvider - A quorum provider over providers
+func NewQuorumProvider(providers []ProviderInterface, options QuorumOptions) *QuorumProvider {
+
+	if options.Threshold <= 0 {
+		options.Threshold = len(providers)/2 + 1
+	}
+	if options.Methods == nil {
+		options.Methods = IsQuorumMethod
+	}
+
+	provider := new(QuorumProvider)
+	provider.providers = providers
+	provider.options = options
+
+	return provider
+}
+
+func (provider *QuorumProvider) SendRequest(v interface{}, method string, params interface{}) error {
+	return provider.SendRequestCtx(context.Background(), v, method, params)
+}
+
+func (provider *QuorumProvider) SendRequestCtx(ctx context.Context, v interface{}, method string, params interface{}) error {
+
+	if len(provider.providers) == 0 {
+		return errNoEndpoint
+	}
+
+	if !provider.options.Methods(method) {
+		return provider.first(ctx, v, method, params)
+	}
+
+	if decoded, position, relative := headParams(method, params); relative {
+		head, err := provider.lowestHead(ctx)
+		if err != nil {
+			return err
+		}
+		pinned := json.RawMessage(fmt.Sprintf(`"0x%x"`, head))
+		if position == len(decoded) {
+			decoded = append(decoded, pinned)
+		} else {
+			decoded[position] = pinned
+		}
+		params = decoded
+	}
+
+	answers := make(chan QuorumAnswer, len(provider.providers))
+
+	for index := range provider.providers {
+		go func(index int) {
+			var response json.RawMessage
+			err := provider.providers[index].SendRequestCtx(ctx, &response, method, params)
+			answers <- QuorumAnswer{Index: index, Response: response, Err: err}
+		}(index)
+	}
+
+	var received []QuorumAnswer
+	groups := make(map[string][]int)
+
+	for len(received) < len(provider.providers) {
+
+		var answer QuorumAnswer
+		select {
+		case answer = <-answers:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		received = append(received, answer)
+
+		if answer.Err != nil {
+			continue
+		}
+
+		key, err := answerKey(answer.Response)
+		if err != nil {
+			received[len(received)-1].Err = err
+			continue
+		}
+
+		groups[key] = append(groups[key], len(received)-1)
+
+		if len(groups[key]) == provider.options.Threshold {
+			go provider.report(method, answers, received, key)
+			return json.Unmarshal(answer.Response, v)
+		}
+	}
+
+	sort.Slice(received, func(i, j int) bool { return received[i].Index < received[j].Index })
+
+	return &QuorumError{Method: method, Threshold: provider.options.Threshold, Answers: received}
+}
+
+// lowestHead asks every endpoint for its head and returns the lowest one, a
+// block every endpoint which answered has
+func (provider *QuorumProvider) lowestHead(ctx context.Context) (uint64, error) {
+
+	heads := make([]*big.Int, len(provider.providers))
+	errs := make([]error, len(provider.providers))
+
+	var wait sync.WaitGroup
+	for index := range provider.providers {
+		wait.Add(1)
+		go func(index int) {
+			defer wait.Done()
+			pointer := &dto.RequestResult{}
+			if errs[index] = provider.providers[index].SendRequestCtx(ctx, pointer, "eth_blockNumber", nil); errs[index] == nil {
+				heads[index], errs[index] = pointer.ToBigInt()
+			}
+		}(index)
+	}
+	wait.Wait()
+
+	var lowest uint64
+	var answered int
+	var failed []QuorumAnswer
+
+	for index, head := range heads {
+		if errs[index] != nil || !head.IsUint64() {
+			failed = append(failed, QuorumAnswer{Index: index, Err: errs[index]})
+			continue
+		}
+		if answered == 0 || head.Uint64() < lowest {
+			lowest = head.Uint64()
+		}
+		answered++
+	}
+
+	if answered < provider.options.Threshold {
+		return 0, &QuorumError{Method: "eth_blockNumber", Threshold: provider.options.Threshold, Answers: failed}
+	}
+
+	return lowest, nil
+}
+
+// report waits for the answers still running then passes the ones that
+// differ from the quorum answer to OnDisagreement. The answers cut by the
+// context of the caller, done once the quorum is returned, are left out.
+func (provider *QuorumProvider) report(method string, answers chan QuorumAnswer, received []QuorumAnswer, quorum string) {
+
+	if provider.options.OnDisagreement == nil {
+		return
+	}
+
+	for len(received) < len(provider.providers) {
+		received = append(received, <-answers)
+	}
+
+	var disagreeing []QuorumAnswer
+	for _, answer := range received {
+		if errors.Is(answer.Err, context.Canceled) || errors.Is(answer.Err, context.DeadlineExceeded) {
+			continue
+		}
+		if answer.Err == nil {
+			if key, err := answerKey(answer.Response); err == nil && key == quorum {
+				continue
+			}
+		}
+		disagreeing = append(disagreeing, answer)
+	}
+
+	if len(disagreeing) > 0 {
+		sort.Slice(disagreeing, func(i, j int) bool { return disagreeing[i].Index < disagreeing[j].Index })
+		provider.options.OnDisagreement(method, disagreeing)
+	}
+}
+
+// first sends the request to the endpoints in order until one can be reached
+func (provider *QuorumProvider) first(ctx context.Context, v interface{}, method string, params interface{}) error {
+
+	var err error
+
+	for _, endpoint := range provider.providers {
+		resetResult(v)
+		err = endpoint.SendRequestCtx(ctx, v, method, params)
+		if err == nil || !isEndpointFailure(err) || !IsIdempotent(method) {
+			return err
+		}
+	}
+
+	return err
+}
+
+// Close - Closes every endpoint
+func (provider *QuorumProvider) Close() error {
+
+	var err error
+	for _, endpoint := range provider.providers {
+		if closeErr := endpoint.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}
+
+	return err
+}
+
+// answerKey is the canonical form of the result, or the error, of a response,
+// the id and the formatting of the response are left out
+func answerKey(response json.RawMessage) (string, error) {
+
+	var answer struct {
+		Result interface{} `json:"result"`
+		Error  *dto.Error  `json:"error,omitempty"`
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(response))
+	decoder.UseNumber()
+	if err := decoder.Decode(&answer); err != nil {
+		return "", err
+	}
+
+	key, err := json.Marshal(answer)
+
+	return string(key), err
+}
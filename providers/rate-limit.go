@@ -0,0 +1,199 @@
+/********************************************************************************
+   This file is part of go-web3.
+   go-web3 is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-web3 is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-web3.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file rate-limit.go
+ */
+
+package providers
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimit - A token bucket: Rate requests per second on average, with bursts
+// of up to Burst requests. A zero Rate does not limit the requests.
+type RateLimit struct {
+	Rate  float64
+	Burst int
+}
+
+// tokenBucket - The tokens left to a method, refilled at the rate of the limit
+type tokenBucket struct {
+	limit  RateLimit
+	mutex  sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(limit RateLimit) *tokenBucket {
+	if limit.Burst < 1 {
+		limit.Burst = 1
+	}
+	return &tokenBucket{limit: limit, tokens: float64(limit.Burst), last: time.Now()}
+}
+
+// refill adds the tokens earned since the last call, the caller holds the mutex
+func (bucket *tokenBucket) refill() {
+
+	now := time.Now()
+	bucket.tokens += now.Sub(bucket.last).Seconds() * bucket.limit.Rate
+	if bucket.tokens > float64(bucket.limit.Burst) {
+		bucket.tokens = float64(bucket.limit.Burst)
+	}
+	bucket.last = now
+}
+
+// take takes a token when one is left, without waiting
+func (bucket *tokenBucket) take() bool {
+
+	bucket.mutex.Lock()
+	defer bucket.mutex.Unlock()
+
+	bucket.refill()
+
+	if bucket.tokens < 1 {
+		return false
+	}
+
+	bucket.tokens--
+	return true
+}
+
+// wait takes a token, waiting for it when the bucket is empty
+func (bucket *tokenBucket) wait(ctx context.Context) error {
+
+	bucket.mutex.Lock()
+
+	bucket.refill()
+
+	// the token is reserved now, the caller waits until it is refilled
+	bucket.tokens--
+	delay := time.Duration(-bucket.tokens / bucket.limit.Rate * float64(time.Second))
+
+	bucket.mutex.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		bucket.mutex.Lock()
+		bucket.tokens++
+		bucket.mutex.Unlock()
+		return ctx.Err()
+	}
+}
+
+// Limiter - The token buckets of RateLimiter, one for each method, created on its
+// first call. It rate limits calls other than the requests of a provider.
+type Limiter struct {
+	fallback RateLimit
+	limits   map[string]RateLimit
+
+	mutex   sync.Mutex
+	buckets map[string]*tokenBucket
+}
+
+// NewLimiter - A Limiter taking the limit of a method in limits, or fallback
+func NewLimiter(fallback RateLimit, limits map[string]RateLimit) *Limiter {
+	return &Limiter{fallback: fallback, limits: limits, buckets: make(map[string]*tokenBucket)}
+}
+
+// Wait - Takes a token of method, waiting until one is refilled or ctx is done
+func (limiter *Limiter) Wait(ctx context.Context, method string) error {
+
+	bucket := limiter.bucket(method)
+	if bucket == nil {
+		return nil
+	}
+
+	return bucket.wait(ctx)
+}
+
+// Allow - Takes a token of method if one is left, it returns false otherwise
+func (limiter *Limiter) Allow(method string) bool {
+
+	bucket := limiter.bucket(method)
+	if bucket == nil {
+		return true
+	}
+
+	return bucket.take()
+}
+
+// bucket returns the bucket of method, nil when the method is not limited
+func (limiter *Limiter) bucket(method string) *tokenBucket {
+
+	limit, ok := limiter.limits[method]
+	if !ok {
+		limit = limiter.fallback
+	}
+
+	if limit.Rate <= 0 {
+		return nil
+	}
+
+	limiter.mutex.Lock()
+	defer limiter.mutex.Unlock()
+
+	bucket, ok := limiter.buckets[method]
+	if !ok {
+		bucket = newTokenBucket(limit)
+		limiter.buckets[method] = bucket
+	}
+
+	return bucket
+}
+
+// RateLimiter - Delays the requests so each method stays within its limit.
+// Every method has its own bucket, with the limit found in limits or
+// fallback otherwise. A batch waits for a token for each of its calls.
+func RateLimiter(fallback RateLimit, limits map[string]RateLimit) Middleware {
+
+	return func(next ProviderInterface) ProviderInterface {
+
+		limiter := NewLimiter(fallback, limits)
+
+		provider := newMiddlewareProvider(next)
+
+		provider.send = func(ctx context.Context, v interface{}, method string, params interface{}) error {
+			if err := limiter.Wait(ctx, method); err != nil {
+				return err
+			}
+			return next.SendRequestCtx(ctx, v, method, params)
+		}
+
+		if batchProvider, ok := next.(BatchProvider); ok {
+			provider.sendBatch = func(ctx context.Context, batch []BatchElem) error {
+				for index := range batch {
+					if err := limiter.Wait(ctx, batch[index].Method); err != nil {
+						return err
+					}
+				}
+				return batchProvider.SendBatchCtx(ctx, batch)
+			}
+		}
+
+		return provider
+	}
+}
@@ -0,0 +1,418 @@
+/********************************************************************************
+   This file is part of go-web3.
+   go-web3 is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-web3 is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-web3.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file reconnect.go
+ */
+
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/cellcycle/go-web3/constants"
+	"github.com/cellcycle/go-web3/dto"
+	"github.com/cellcycle/go-web3/providers/util"
+)
+
+// ReconnectPolicy - How a lost connection is restored.
+// The zero value of a field takes the value of DefaultReconnectPolicy.
+type ReconnectPolicy struct {
+	// InitialBackoff is the wait after the first failed attempt
+	InitialBackoff time.Duration
+	// MaxBackoff caps the wait between two attempts
+	MaxBackoff time.Duration
+	// Multiplier grows the wait after each failed attempt
+	Multiplier float64
+	// MaxAttempts gives up after that many attempts, 0 retries forever
+	MaxAttempts int
+	// Timeout bounds each dial and each request restoring the subscriptions
+	Timeout time.Duration
+	// MaxBackfill is the longest gap, in blocks, backfilled after a reconnection.
+	// Over it the subscription goes on with the live notifications and the gap
+	// is reported on Err as a *GapError.
+	MaxBackfill uint64
+}
+
+// DefaultReconnectPolicy - Retries forever, waiting from 500ms up to 30s between
+// attempts, and backfills up to 128 blocks
+func DefaultReconnectPolicy() ReconnectPolicy {
+	return ReconnectPolicy{
+		InitialBackoff: 500 * time.Millisecond,
+		MaxBackoff:     30 * time.Second,
+		Multiplier:     2,
+		Timeout:        10 * time.Second,
+		MaxBackfill:    128,
+	}
+}
+
+func (policy ReconnectPolicy) withDefaults() ReconnectPolicy {
+
+	defaults := DefaultReconnectPolicy()
+
+	if policy.InitialBackoff <= 0 {
+		policy.InitialBackoff = defaults.InitialBackoff
+	}
+	if policy.MaxBackoff <= 0 {
+		policy.MaxBackoff = defaults.MaxBackoff
+	}
+	if policy.Multiplier < 1 {
+		policy.Multiplier = defaults.Multiplier
+	}
+	if policy.Timeout <= 0 {
+		policy.Timeout = defaults.Timeout
+	}
+	if policy.MaxBackfill == 0 {
+		policy.MaxBackfill = defaults.MaxBackfill
+	}
+
+	return policy
+}
+
+// requestContext bounds a single request restoring a subscription
+func (policy ReconnectPolicy) requestContext() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), policy.Timeout)
+}
+
+// GapError - The notifications of the blocks From to To, missed while the
+// connection was down, were not backfilled. The subscription goes on.
+type GapError struct {
+	From uint64
+	To   uint64
+	// Err is the error of the node, nil when the gap was over MaxBackfill
+	Err error
+}
+
+func (err *GapError) Error() string {
+	if err.Err != nil {
+		return fmt.Sprintf("Notifications of the blocks %d to %d missed: %v", err.From, err.To, err.Err)
+	}
+	return fmt.Sprintf("Notifications of the blocks %d to %d missed, over the backfill limit", err.From, err.To)
+}
+
+func (err *GapError) Unwrap() error {
+	return err.Err
+}
+
+// reconnect dials again with an exponential backoff until the connection is
+// restored with its subscriptions, the policy gives up or the client closes
+func (client *streamClient) reconnect(done chan struct{}) {
+
+	policy := *client.policy
+	backoff := policy.InitialBackoff
+
+	var err error
+
+	for attempt := 1; ; attempt++ {
+
+		if err = client.restore(policy); err == nil {
+			break
+		}
+
+		if client.isClosed() || (policy.MaxAttempts > 0 && attempt >= policy.MaxAttempts) {
+			break
+		}
+
+		select {
+		case <-time.After(backoff):
+		case <-client.shutdown:
+		}
+
+		backoff = time.Duration(float64(backoff) * policy.Multiplier)
+		if backoff > policy.MaxBackoff {
+			backoff = policy.MaxBackoff
+		}
+	}
+
+	client.mutex.Lock()
+	client.reconnecting = nil
+	orphans := client.orphans
+	if err != nil {
+		// the subscriptions are lost for good
+		client.orphans = make(map[*Subscription]bool)
+	} else {
+		orphans = nil
+	}
+	client.mutex.Unlock()
+
+	if client.isClosed() {
+		err = customerror.PROVIDERCLOSED
+	}
+
+	for sub := range orphans {
+		sub.terminate(err)
+	}
+
+	close(done)
+}
+
+func (client *streamClient) isClosed() bool {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	return client.closed
+}
+
+// restore dials a new connection then subscribes again the orphans on it
+func (client *streamClient) restore(policy ReconnectPolicy) error {
+
+	ctx, cancel := policy.requestContext()
+	codec, err := client.dial(ctx)
+	cancel()
+
+	if err != nil {
+		return err
+	}
+
+	client.mutex.Lock()
+
+	if client.closed {
+		client.mutex.Unlock()
+		codec.Close()
+		return customerror.PROVIDERCLOSED
+	}
+
+	client.codec = codec
+	orphans := client.orphans
+	client.orphans = make(map[*Subscription]bool)
+
+	client.mutex.Unlock()
+
+	go client.read(codec)
+
+	var head uint64
+	var headKnown bool
+
+	for sub := range orphans {
+
+		delete(orphans, sub)
+
+		if sub.done() {
+			continue
+		}
+
+		err := client.resubscribe(policy, codec, sub, &head, &headKnown)
+
+		if _, isNodeError := err.(*resumeError); isNodeError {
+			sub.terminate(err.(*resumeError).err)
+			continue
+		}
+
+		if err != nil {
+			// the new connection failed too, the rest waits for the next attempt
+			client.adopt(sub, orphans)
+			client.fail(codec, err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// resumeError - The node refused to restore a subscription
+type resumeError struct {
+	err error
+}
+
+func (err *resumeError) Error() string {
+	return err.err.Error()
+}
+
+// adopt gives back subscriptions not restored yet to the next attempt
+func (client *streamClient) adopt(sub *Subscription, orphans map[*Subscription]bool) {
+
+	client.mutex.Lock()
+
+	closed := client.closed
+	if !closed {
+		client.orphans[sub] = true
+		for orphan := range orphans {
+			client.orphans[orphan] = true
+		}
+	}
+
+	client.mutex.Unlock()
+
+	if closed {
+		sub.terminate(customerror.PROVIDERCLOSED)
+		for orphan := range orphans {
+			orphan.terminate(customerror.PROVIDERCLOSED)
+		}
+	}
+}
+
+// resubscribe subscribes sub again under a new id and queues the
+// notifications missed since its last one, before the live ones. Each
+// request has its own deadline, a long backfill doesn't expire the others.
+func (client *streamClient) resubscribe(policy ReconnectPolicy, codec streamCodec, sub *Subscription, head *uint64, headKnown *bool) error {
+
+	sub.hold()
+
+	request := util.JSONRPCObject{Version: "2.0", Method: sub.namespace + "_subscribe", Params: sub.args, ID: client.nextID()}
+
+	message, err := json.Marshal(request)
+	if err != nil {
+		return &resumeError{err}
+	}
+
+	ctx, cancel := policy.requestContext()
+	response, err := client.roundTripOn(ctx, codec, message, &pendingCall{ids: []int{request.ID}, subscription: sub})
+	cancel()
+	if err != nil {
+		return err
+	}
+
+	pointer := &dto.RequestResult{}
+	if err := json.Unmarshal(response, pointer); err != nil {
+		return &resumeError{err}
+	}
+
+	if _, err := pointer.ToString(); err != nil {
+		return &resumeError{err}
+	}
+
+	if sub.done() {
+		// unsubscribed while the request was in flight
+		if id, active := client.removeSubscription(sub); active {
+			ctx, cancel := policy.requestContext()
+			client.sendRequestOn(ctx, codec, new(json.RawMessage), sub.namespace+"_unsubscribe", []string{id})
+			cancel()
+		}
+		return nil
+	}
+
+	last, tracked := sub.last()
+	if !tracked {
+		sub.release(0, false)
+		return nil
+	}
+
+	if !*headKnown {
+		blockNumber := &dto.RequestResult{}
+		ctx, cancel := policy.requestContext()
+		err := client.sendRequestOn(ctx, codec, blockNumber, "eth_blockNumber", nil)
+		cancel()
+		if err != nil {
+			return err
+		}
+		number, err := blockNumber.ToBigInt()
+		if err != nil || !number.IsUint64() {
+			// without the head the gap can't be measured, resume the live stream only
+			sub.release(0, false)
+			return nil
+		}
+		*head, *headKnown = number.Uint64(), true
+	}
+
+	if *head > last && *head-last > policy.MaxBackfill {
+		// too long to fetch, the live stream goes on and the gap is reported
+		sub.release(0, false)
+		sub.report(&GapError{From: last + 1, To: *head})
+		return nil
+	}
+
+	missed, err := client.missed(policy, codec, sub, last, *head)
+	if err != nil {
+		if _, isNodeError := err.(*resumeError); !isNodeError {
+			return err
+		}
+		// the gap is lost but the subscription itself is alive again
+		sub.release(0, false)
+		sub.report(&GapError{From: last + 1, To: *head, Err: err.(*resumeError).err})
+		return nil
+	}
+
+	sub.backfill(missed)
+	sub.release(*head, true)
+
+	return nil
+}
+
+// missed fetches the notifications of the blocks after last up to head. The
+// logs of last are fetched again, the ones queued already are skipped by backfill.
+func (client *streamClient) missed(policy ReconnectPolicy, codec streamCodec, sub *Subscription, last uint64, head uint64) ([]json.RawMessage, error) {
+
+	if head < last {
+		return nil, nil
+	}
+
+	var results []json.RawMessage
+
+	switch sub.blockField {
+
+	case "number":
+		for number := last + 1; number <= head; number++ {
+			ctx, cancel := policy.requestContext()
+			block, err := client.resultOn(ctx, codec, "eth_getBlockByNumber", []interface{}{fmt.Sprintf("0x%x", number), false})
+			cancel()
+			if err != nil {
+				return nil, err
+			}
+			if string(block) == "null" {
+				break
+			}
+			results = append(results, block)
+		}
+
+	case "blockNumber":
+		filter := make(map[string]interface{})
+		if len(sub.args) > 1 {
+			encoded, err := json.Marshal(sub.args[1])
+			if err != nil {
+				return nil, &resumeError{err}
+			}
+			if err := json.Unmarshal(encoded, &filter); err != nil {
+				return nil, &resumeError{err}
+			}
+		}
+		filter["fromBlock"] = fmt.Sprintf("0x%x", last)
+		filter["toBlock"] = fmt.Sprintf("0x%x", head)
+
+		ctx, cancel := policy.requestContext()
+		logs, err := client.resultOn(ctx, codec, "eth_getLogs", []interface{}{filter})
+		cancel()
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(logs, &results); err != nil {
+			return nil, &resumeError{err}
+		}
+	}
+
+	return results, nil
+}
+
+// resultOn calls method over codec and returns its raw result
+func (client *streamClient) resultOn(ctx context.Context, codec streamCodec, method string, params interface{}) (json.RawMessage, error) {
+
+	var response struct {
+		Result json.RawMessage `json:"result"`
+		Error  *dto.Error      `json:"error"`
+	}
+
+	if err := client.sendRequestOn(ctx, codec, &response, method, params); err != nil {
+		if _, isJSONError := err.(*json.SyntaxError); isJSONError {
+			return nil, &resumeError{err}
+		}
+		return nil, err
+	}
+
+	if response.Error != nil {
+		return nil, &resumeError{dto.NewRPCError(response.Error)}
+	}
+
+	return response.Result, nil
+}
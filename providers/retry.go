@@ -0,0 +1,298 @@
+/********************************************************************************
+   This file is part of go-web3.
+   go-web3 is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-web3 is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-web3.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file retry.go
+ */
+
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/rand"
+	"time"
+
+	"github.com/cellcycle/go-web3/constants"
+	"github.com/cellcycle/go-web3/dto"
+)
+
+// maxRetryAfter - a longer Retry-After is returned to the caller instead of waited
+const maxRetryAfter = 30 * time.Second
+
+// idempotentMethods - the read methods that can be sent again without side effect
+var idempotentMethods = map[string]bool{
+	"web3_clientVersion":                      true,
+	"web3_sha3":                               true,
+	"net_version":                             true,
+	"net_listening":                           true,
+	"net_peerCount":                           true,
+	"eth_protocolVersion":                     true,
+	"eth_syncing":                             true,
+	"eth_coinbase":                            true,
+	"eth_mining":                              true,
+	"eth_hashrate":                            true,
+	"eth_gasPrice":                            true,
+	"eth_accounts":                            true,
+	"eth_blockNumber":                         true,
+	"eth_chainId":                             true,
+	"eth_getBalance":                          true,
+	"eth_getStorageAt":                        true,
+	"eth_getTransactionCount":                 true,
+	"eth_getBlockTransactionCountByHash":      true,
+	"eth_getBlockTransactionCountByNumber":    true,
+	"eth_getUncleCountByBlockHash":            true,
+	"eth_getUncleCountByBlockNumber":          true,
+	"eth_getCode":                             true,
+	"eth_call":                                true,
+	"eth_estimateGas":                         true,
+	"eth_getBlockByHash":                      true,
+	"eth_getBlockByNumber":                    true,
+	"eth_getTransactionByHash":                true,
+	"eth_getTransactionByBlockHashAndIndex":   true,
+	"eth_getTransactionByBlockNumberAndIndex": true,
+	"eth_getTransactionReceipt":               true,
+	"eth_getUncleByBlockHashAndIndex":         true,
+	"eth_getUncleByBlockNumberAndIndex":       true,
+	"eth_getCompilers":                        true,
+	"eth_getLogs":                             true,
+	"eth_getFilterLogs":                       true,
+	"eth_getProof":                            true,
+	"eth_feeHistory":                          true,
+	"eth_maxPriorityFeePerGas":                true,
+}
+
+// IsIdempotent - Reports whether method only reads the state of the node,
+// so that sending it twice has the same effect as sending it once
+func IsIdempotent(method string) bool {
+	return idempotentMethods[method]
+}
+
+// RetryPolicy - How the Retry middleware sends a failed request again.
+// The zero value of a field takes the value of DefaultRetryPolicy.
+type RetryPolicy struct {
+	// MaxAttempts counts the first attempt
+	MaxAttempts int
+	// InitialBackoff is the longest wait before the second attempt
+	InitialBackoff time.Duration
+	// MaxBackoff caps the wait between two attempts
+	MaxBackoff time.Duration
+	// Multiplier grows the wait after each failed attempt
+	Multiplier float64
+	// Retryable selects the methods sent again, IsIdempotent when nil
+	Retryable func(method string) bool
+}
+
+// DefaultRetryPolicy - 4 attempts, waiting up to 100ms, 200ms then 400ms
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    4,
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     5 * time.Second,
+		Multiplier:     2,
+		Retryable:      IsIdempotent,
+	}
+}
+
+func (policy RetryPolicy) withDefaults() RetryPolicy {
+
+	defaults := DefaultRetryPolicy()
+
+	if policy.MaxAttempts <= 0 {
+		policy.MaxAttempts = defaults.MaxAttempts
+	}
+	if policy.InitialBackoff <= 0 {
+		policy.InitialBackoff = defaults.InitialBackoff
+	}
+	if policy.MaxBackoff <= 0 {
+		policy.MaxBackoff = defaults.MaxBackoff
+	}
+	if policy.Multiplier < 1 {
+		policy.Multiplier = defaults.Multiplier
+	}
+	if policy.Retryable == nil {
+		policy.Retryable = defaults.Retryable
+	}
+
+	return policy
+}
+
+// Retry - Sends the retryable methods again when they fail with a transport
+// error, a 5xx or 429 status or a rate limit error of the node. The wait
+// between attempts grows exponentially, with a full jitter, and follows the
+// Retry-After of the node when it is longer, a Retry-After over 30s returns the
+// error to the caller. Batches are sent again only when
+// every call of the batch is retryable.
+func Retry(policy RetryPolicy) Middleware {
+
+	policy = policy.withDefaults()
+
+	return func(next ProviderInterface) ProviderInterface {
+
+		provider := newMiddlewareProvider(next)
+
+		provider.send = func(ctx context.Context, v interface{}, method string, params interface{}) error {
+
+			if !policy.Retryable(method) {
+				return next.SendRequestCtx(ctx, v, method, params)
+			}
+
+			return policy.do(ctx, func() error {
+				resetResult(v)
+				if err := next.SendRequestCtx(ctx, v, method, params); err != nil {
+					return err
+				}
+				return resultError(v)
+			})
+		}
+
+		if batchProvider, ok := next.(BatchProvider); ok {
+			provider.sendBatch = func(ctx context.Context, batch []BatchElem) error {
+
+				for index := range batch {
+					if !policy.Retryable(batch[index].Method) {
+						return batchProvider.SendBatchCtx(ctx, batch)
+					}
+				}
+
+				return policy.do(ctx, func() error {
+					for index := range batch {
+						resetResult(batch[index].Result)
+					}
+					return batchProvider.SendBatchCtx(ctx, batch)
+				})
+			}
+		}
+
+		return provider
+	}
+}
+
+// do runs attempt until it succeeds, fails for good or the attempts run out
+func (policy RetryPolicy) do(ctx context.Context, attempt func() error) error {
+
+	backoff := policy.InitialBackoff
+
+	for count := 1; ; count++ {
+
+		err := attempt()
+		if err == nil || count >= policy.MaxAttempts || ctx.Err() != nil || !isTransient(err) {
+			if _, kept := err.(keptError); kept {
+				// the error stays in the result for the caller to read
+				return nil
+			}
+			return err
+		}
+
+		wait := time.Duration(rand.Int63n(int64(backoff) + 1))
+
+		var httpError *HTTPError
+		if errors.As(err, &httpError) && httpError.RetryAfter > wait {
+			if httpError.RetryAfter > maxRetryAfter {
+				return err
+			}
+			wait = httpError.RetryAfter
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+
+		backoff = time.Duration(float64(backoff) * policy.Multiplier)
+		if backoff > policy.MaxBackoff {
+			backoff = policy.MaxBackoff
+		}
+	}
+}
+
+// isTransient reports whether err may not happen again on the next attempt
+func isTransient(err error) bool {
+
+	if errors.Is(err, customerror.RATELIMITED) {
+		return true
+	}
+
+	var httpError *HTTPError
+	if errors.As(err, &httpError) {
+		return httpError.StatusCode >= 500
+	}
+
+	var rpcError *dto.RPCError
+	var syntaxError *json.SyntaxError
+	var typeError *json.UnmarshalTypeError
+
+	switch {
+	case errors.As(err, &rpcError), errors.As(err, &syntaxError), errors.As(err, &typeError):
+		return false
+	case err == customerror.PROVIDERCLOSED, err == context.Canceled, err == context.DeadlineExceeded:
+		return false
+	}
+
+	return true
+}
+
+// keptError - A node error left in the result of the call, it makes the call
+// retried but it is read by the caller from the result
+type keptError struct {
+	err *dto.RPCError
+}
+
+func (err keptError) Error() string {
+	return err.err.Error()
+}
+
+func (err keptError) Unwrap() error {
+	return err.err
+}
+
+// requestResult finds the RequestResult a call decodes its response into
+func requestResult(v interface{}) *dto.RequestResult {
+	switch result := v.(type) {
+	case *dto.RequestResult:
+		return result
+	case **dto.RequestResult:
+		if result != nil {
+			return *result
+		}
+	}
+	return nil
+}
+
+// resetResult clears what a failed attempt decoded in the result
+func resetResult(v interface{}) {
+	if result := requestResult(v); result != nil {
+		*result = dto.RequestResult{}
+	}
+}
+
+// resultError returns the rate limit error answered by the node in the result
+func resultError(v interface{}) error {
+
+	result := requestResult(v)
+	if result == nil || result.Error == nil {
+		return nil
+	}
+
+	rpcError := dto.NewRPCError(result.Error)
+	if !errors.Is(rpcError, customerror.RATELIMITED) {
+		return nil
+	}
+
+	return keptError{rpcError}
+}
@@ -0,0 +1,487 @@
+/********************************************************************************
+   This file is part of go-web3.
+   go-web3 is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-web3 is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-web3.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file stream-client.go
+ */
+
+package providers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/cellcycle/go-web3/constants"
+	"github.com/cellcycle/go-web3/dto"
+	"github.com/cellcycle/go-web3/providers/util"
+)
+
+// streamCodec - A long-lived connection carrying JSON-RPC messages both ways
+type streamCodec interface {
+	// ReadMessage blocks until the next complete message arrives
+	ReadMessage() (json.RawMessage, error)
+	// WriteMessage sends one message, giving up at the deadline of ctx
+	WriteMessage(ctx context.Context, message []byte) error
+	Close() error
+}
+
+// streamResponse - What the reader hands to the caller waiting on an id
+type streamResponse struct {
+	message json.RawMessage
+	err     error
+}
+
+// pendingCall - A request, or a whole batch, waiting for its response
+type pendingCall struct {
+	ids      []int
+	response chan streamResponse
+	// subscription is registered as soon as the subscribe call is answered,
+	// before the reader looks at the notifications following the response
+	subscription *Subscription
+}
+
+// streamClient multiplexes concurrent requests over a single connection.
+// Every request gets a unique id and a reader goroutine hands each response
+// to the caller waiting on that id, whatever the order they arrive in.
+type streamClient struct {
+	lastID int64 // first field to keep the 64 bits alignment of atomic ops
+
+	dial func(ctx context.Context) (streamCodec, error)
+
+	writeMutex sync.Mutex
+
+	mutex         sync.Mutex
+	codec         streamCodec
+	pending       map[int]*pendingCall
+	subscriptions map[string]*Subscription
+	closed        bool
+
+	// policy is nil when the connection is not restored automatically
+	policy *ReconnectPolicy
+	// reconnecting is closed when the running reconnection ends
+	reconnecting chan struct{}
+	// orphans are the subscriptions waiting for the connection to come back
+	orphans map[*Subscription]bool
+	// shutdown is closed by close, it stops the reconnection backoff
+	shutdown chan struct{}
+}
+
+func newStreamClient(dial func(ctx context.Context) (streamCodec, error)) *streamClient {
+	client := new(streamClient)
+	client.dial = dial
+	client.pending = make(map[int]*pendingCall)
+	client.subscriptions = make(map[string]*Subscription)
+	client.orphans = make(map[*Subscription]bool)
+	client.shutdown = make(chan struct{})
+	return client
+}
+
+// nextID hands out unique and monotonic request ids
+func (client *streamClient) nextID() int {
+	return int(atomic.AddInt64(&client.lastID, 1))
+}
+
+// connect returns the current connection, dialing it when there is none.
+// While a reconnection is running it waits for its outcome instead.
+func (client *streamClient) connect(ctx context.Context) (streamCodec, error) {
+
+	client.mutex.Lock()
+
+	for client.reconnecting != nil && !client.closed {
+		reconnecting := client.reconnecting
+		client.mutex.Unlock()
+
+		select {
+		case <-reconnecting:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+
+		client.mutex.Lock()
+	}
+
+	defer client.mutex.Unlock()
+
+	if client.closed {
+		return nil, customerror.PROVIDERCLOSED
+	}
+
+	if client.codec != nil {
+		return client.codec, nil
+	}
+
+	codec, err := client.dial(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	client.codec = codec
+	go client.read(codec)
+
+	return codec, nil
+}
+
+func (client *streamClient) sendRequest(ctx context.Context, v interface{}, method string, params interface{}) error {
+
+	codec, err := client.connect(ctx)
+	if err != nil {
+		return err
+	}
+
+	return client.sendRequestOn(ctx, codec, v, method, params)
+}
+
+// sendRequestOn is sendRequest over a given connection
+func (client *streamClient) sendRequestOn(ctx context.Context, codec streamCodec, v interface{}, method string, params interface{}) error {
+
+	request := util.JSONRPCObject{Version: "2.0", Method: method, Params: params, ID: client.nextID()}
+
+	message, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	response, err := client.roundTripOn(ctx, codec, message, &pendingCall{ids: []int{request.ID}})
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(response, v)
+}
+
+func (client *streamClient) sendBatch(ctx context.Context, batch []BatchElem) error {
+
+	if len(batch) == 0 {
+		return nil
+	}
+
+	request, positions := newBatchRequest(batch, client.nextID)
+
+	message, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	ids := make([]int, len(request))
+	for index := range request {
+		ids[index] = request[index].ID
+	}
+
+	response, err := client.roundTrip(ctx, message, &pendingCall{ids: ids})
+	if err != nil {
+		return err
+	}
+
+	return dispatchBatchResponse(batch, positions, response)
+}
+
+func (client *streamClient) subscribe(ctx context.Context, namespace string, args []interface{}) (*Subscription, error) {
+
+	sub := newSubscription(client, namespace, args)
+
+	request := util.JSONRPCObject{Version: "2.0", Method: namespace + "_subscribe", Params: args, ID: client.nextID()}
+
+	message, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := client.roundTrip(ctx, message, &pendingCall{ids: []int{request.ID}, subscription: sub})
+	if err != nil {
+		// the node may have created the subscription before ctx was done
+		if id, active := client.removeSubscription(sub); active {
+			go client.sendRequest(context.Background(), new(json.RawMessage), namespace+"_unsubscribe", []string{id})
+		}
+		return nil, err
+	}
+
+	pointer := &dto.RequestResult{}
+	if err := json.Unmarshal(response, pointer); err != nil {
+		return nil, err
+	}
+
+	if _, err := pointer.ToString(); err != nil {
+		return nil, err
+	}
+
+	go sub.forward()
+
+	return sub, nil
+}
+
+// register links a subscription to the id answered by the node
+func (client *streamClient) register(sub *Subscription, response json.RawMessage) {
+
+	var result struct {
+		Result string `json:"result"`
+	}
+
+	if json.Unmarshal(response, &result) != nil || result.Result == "" {
+		return
+	}
+
+	client.mutex.Lock()
+	sub.id = result.Result
+	client.subscriptions[sub.id] = sub
+	client.mutex.Unlock()
+}
+
+// removeSubscription unlinks sub, reporting whether the node still knows it
+func (client *streamClient) removeSubscription(sub *Subscription) (string, bool) {
+
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+
+	if client.orphans[sub] {
+		delete(client.orphans, sub)
+		return sub.id, false
+	}
+
+	if sub.id == "" || client.subscriptions[sub.id] != sub {
+		return sub.id, false
+	}
+
+	delete(client.subscriptions, sub.id)
+
+	return sub.id, client.codec != nil
+}
+
+// roundTrip writes message and waits for the response matching the call ids
+func (client *streamClient) roundTrip(ctx context.Context, message []byte, call *pendingCall) (json.RawMessage, error) {
+
+	codec, err := client.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.roundTripOn(ctx, codec, message, call)
+}
+
+// roundTripOn is roundTrip over a given connection
+func (client *streamClient) roundTripOn(ctx context.Context, codec streamCodec, message []byte, call *pendingCall) (json.RawMessage, error) {
+
+	call.response = make(chan streamResponse, 1)
+
+	client.mutex.Lock()
+	for _, id := range call.ids {
+		client.pending[id] = call
+	}
+	client.mutex.Unlock()
+
+	client.writeMutex.Lock()
+	err := codec.WriteMessage(ctx, message)
+	client.writeMutex.Unlock()
+
+	if err != nil {
+		client.forget(call)
+		return nil, contextError(ctx, err)
+	}
+
+	select {
+	case response := <-call.response:
+		return response.message, response.err
+	case <-ctx.Done():
+		client.forget(call)
+		return nil, ctx.Err()
+	}
+}
+
+// forget drops a call that is no longer waiting for its response
+func (client *streamClient) forget(call *pendingCall) {
+
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+
+	for _, id := range call.ids {
+		if client.pending[id] == call {
+			delete(client.pending, id)
+		}
+	}
+}
+
+// read runs for the lifetime of codec, dispatching every message it receives
+func (client *streamClient) read(codec streamCodec) {
+
+	for {
+		message, err := codec.ReadMessage()
+		if err != nil {
+			client.fail(codec, err)
+			return
+		}
+
+		client.dispatch(message)
+	}
+}
+
+func (client *streamClient) dispatch(message json.RawMessage) {
+
+	message = bytes.TrimSpace(message)
+
+	if len(message) > 0 && message[0] == '[' {
+		var responses []json.RawMessage
+		if json.Unmarshal(message, &responses) != nil {
+			return
+		}
+		for _, response := range responses {
+			if call := client.take(response); call != nil {
+				call.response <- streamResponse{message: message}
+				return
+			}
+		}
+		return
+	}
+
+	if call := client.take(message); call != nil {
+		if call.subscription != nil {
+			client.register(call.subscription, message)
+		}
+		call.response <- streamResponse{message: message}
+		return
+	}
+
+	client.notify(message)
+}
+
+// notify routes a <namespace>_subscription notification to its subscription
+func (client *streamClient) notify(message json.RawMessage) {
+
+	var notification struct {
+		Method string `json:"method"`
+		Params struct {
+			Subscription string          `json:"subscription"`
+			Result       json.RawMessage `json:"result"`
+		} `json:"params"`
+	}
+
+	if json.Unmarshal(message, &notification) != nil || !strings.HasSuffix(notification.Method, "_subscription") {
+		return
+	}
+
+	client.mutex.Lock()
+	sub, ok := client.subscriptions[notification.Params.Subscription]
+	client.mutex.Unlock()
+
+	if ok {
+		sub.deliver(notification.Params.Result)
+	}
+}
+
+// take removes and returns the call waiting for the id of message
+func (client *streamClient) take(message json.RawMessage) *pendingCall {
+
+	var header struct {
+		ID *int `json:"id"`
+	}
+
+	if json.Unmarshal(message, &header) != nil || header.ID == nil {
+		return nil
+	}
+
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+
+	call, ok := client.pending[*header.ID]
+	if !ok {
+		return nil
+	}
+
+	for _, id := range call.ids {
+		delete(client.pending, id)
+	}
+
+	return call
+}
+
+// fail tears down a broken connection and fails every call still waiting on it
+func (client *streamClient) fail(codec streamCodec, err error) error {
+
+	client.mutex.Lock()
+
+	if client.codec != codec && !client.closed {
+		// a stale connection, its calls have already been failed
+		client.mutex.Unlock()
+		return codec.Close()
+	}
+
+	client.codec = nil
+
+	if client.closed {
+		err = customerror.PROVIDERCLOSED
+	}
+
+	pending := client.pending
+	client.pending = make(map[int]*pendingCall)
+
+	subscriptions := client.subscriptions
+	client.subscriptions = make(map[string]*Subscription)
+
+	if client.policy != nil && !client.closed {
+		// keep the subscriptions until the connection is restored
+		for _, sub := range subscriptions {
+			client.orphans[sub] = true
+		}
+		subscriptions = nil
+
+		if client.reconnecting == nil {
+			client.reconnecting = make(chan struct{})
+			go client.reconnect(client.reconnecting)
+		}
+	}
+
+	client.mutex.Unlock()
+
+	closeErr := codec.Close()
+
+	for _, sub := range subscriptions {
+		sub.terminate(err)
+	}
+
+	failed := make(map[*pendingCall]bool)
+	for _, call := range pending {
+		if !failed[call] {
+			failed[call] = true
+			call.response <- streamResponse{err: err}
+		}
+	}
+
+	return closeErr
+}
+
+// close shuts the connection down for good, failing the calls in flight
+func (client *streamClient) close() error {
+
+	client.mutex.Lock()
+	if !client.closed {
+		close(client.shutdown)
+	}
+	client.closed = true
+	codec := client.codec
+	orphans := client.orphans
+	client.orphans = make(map[*Subscription]bool)
+	client.mutex.Unlock()
+
+	for sub := range orphans {
+		sub.terminate(customerror.PROVIDERCLOSED)
+	}
+
+	if codec == nil {
+		return nil
+	}
+
+	return client.fail(codec, customerror.PROVIDERCLOSED)
+}
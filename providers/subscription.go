@@ -0,0 +1,376 @@
+/********************************************************************************
+   This file is part of go-web3.
+   go-web3 is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-web3 is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-web3.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file subscription.go
+ */
+
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/cellcycle/go-web3/constants"
+)
+
+// maxQueuedNotifications bounds the notifications kept for a slow consumer
+const maxQueuedNotifications = 10000
+
+// SubscriptionProvider - A provider able to receive pushed notifications
+type SubscriptionProvider interface {
+	ProviderInterface
+	// SubscribeCtx calls <namespace>_subscribe with args and routes the
+	// notifications of the new subscription to the returned Subscription.
+	SubscribeCtx(ctx context.Context, namespace string, args ...interface{}) (*Subscription, error)
+}
+
+// Subscription - A server side subscription created with <namespace>_subscribe.
+// Notifications are queued so a slow consumer never blocks the connection.
+type Subscription struct {
+	client    *streamClient
+	namespace string
+	args      []interface{}
+
+	// id is guarded by the client mutex, it is set by the reader goroutine
+	id string
+
+	mutex sync.Mutex
+	queue []json.RawMessage
+	wake  chan struct{}
+	// blockField names the block number of the notifications, it is only
+	// set when the subscription can be backfilled after a reconnection
+	blockField string
+	lastBlock  uint64
+	tracked    bool
+	// lastLogs are the logs of lastBlock queued, by block hash and log index,
+	// the backfill of a logs subscription starts at lastBlock and skips them
+	lastLogs map[string]bool
+	// holding keeps the notifications aside while the missed ones are fetched
+	holding bool
+	held    []json.RawMessage
+
+	notifications chan json.RawMessage
+	err           chan error
+	quit          chan struct{}
+	once          sync.Once
+}
+
+func newSubscription(client *streamClient, namespace string, args []interface{}) *Subscription {
+	sub := new(Subscription)
+	sub.client = client
+	sub.namespace = namespace
+	sub.args = args
+	sub.wake = make(chan struct{}, 1)
+	sub.notifications = make(chan json.RawMessage)
+	// room for a gap reported and the error ending the subscription
+	sub.err = make(chan error, 2)
+	sub.quit = make(chan struct{})
+
+	if client.policy != nil && namespace == "eth" && len(args) > 0 {
+		switch args[0] {
+		case "newHeads":
+			sub.blockField = "number"
+		case "logs":
+			sub.blockField = "blockNumber"
+		}
+	}
+
+	return sub
+}
+
+// ID - The id the node gave to the subscription
+func (sub *Subscription) ID() string {
+	sub.client.mutex.Lock()
+	defer sub.client.mutex.Unlock()
+	return sub.id
+}
+
+// Notifications - The raw result of every notification, in arrival order.
+// The channel is closed once the subscription ends.
+func (sub *Subscription) Notifications() <-chan json.RawMessage {
+	return sub.notifications
+}
+
+// Err - Receives the error that ended the subscription, if any, and is
+// closed when the subscription ends. A *GapError is received without ending
+// the subscription when notifications missed during a reconnection were not
+// backfilled.
+func (sub *Subscription) Err() <-chan error {
+	return sub.err
+}
+
+// Unsubscribe - Cancels the subscription on the node and stops the delivery
+func (sub *Subscription) Unsubscribe() error {
+	return sub.UnsubscribeCtx(context.Background())
+}
+
+// UnsubscribeCtx - Same as Unsubscribe, using ctx to cancel the request or set its deadline.
+func (sub *Subscription) UnsubscribeCtx(ctx context.Context) error {
+
+	id, active := sub.client.removeSubscription(sub)
+	sub.terminate(nil)
+
+	if !active {
+		return nil
+	}
+
+	var result json.RawMessage
+
+	err := sub.client.sendRequest(ctx, &result, sub.namespace+"_unsubscribe", []string{id})
+	if err == customerror.PROVIDERCLOSED {
+		return nil
+	}
+
+	return err
+}
+
+// deliver queues a notification, it is called by the reader goroutine
+func (sub *Subscription) deliver(result json.RawMessage) {
+
+	sub.mutex.Lock()
+
+	var ok bool
+	if sub.holding {
+		ok = len(sub.held) < maxQueuedNotifications
+		if ok {
+			sub.held = append(sub.held, result)
+		}
+	} else {
+		ok = sub.enqueue(result)
+	}
+
+	sub.mutex.Unlock()
+
+	if !ok {
+		go sub.overflow()
+		return
+	}
+
+	sub.signal()
+}
+
+// enqueue appends result to the queue, the caller holds the mutex
+func (sub *Subscription) enqueue(result json.RawMessage) bool {
+
+	if len(sub.queue) >= maxQueuedNotifications {
+		return false
+	}
+
+	sub.queue = append(sub.queue, result)
+
+	if number, ok := notificationBlock(result, sub.blockField); ok {
+		if sub.blockField == "blockNumber" {
+			if !sub.tracked || number != sub.lastBlock {
+				sub.lastLogs = make(map[string]bool)
+			}
+			if key := logKey(result); key != "" {
+				sub.lastLogs[key] = true
+			}
+		}
+		sub.lastBlock = number
+		sub.tracked = true
+	}
+
+	return true
+}
+
+func (sub *Subscription) signal() {
+	select {
+	case sub.wake <- struct{}{}:
+	default:
+	}
+}
+
+// hold starts keeping the live notifications aside
+func (sub *Subscription) hold() {
+	sub.mutex.Lock()
+	sub.holding = true
+	sub.mutex.Unlock()
+}
+
+// last returns the block of the last queued notification, if it is known
+func (sub *Subscription) last() (uint64, bool) {
+	sub.mutex.Lock()
+	defer sub.mutex.Unlock()
+	return sub.lastBlock, sub.tracked
+}
+
+// backfill queues the notifications missed while the connection was down
+func (sub *Subscription) backfill(results []json.RawMessage) {
+
+	sub.mutex.Lock()
+
+	ok := true
+	for _, result := range results {
+		if sub.blockField == "blockNumber" && sub.lastLogs[logKey(result)] {
+			// queued before the connection was lost
+			continue
+		}
+		if ok = sub.enqueue(result); !ok {
+			break
+		}
+	}
+
+	sub.mutex.Unlock()
+
+	if !ok {
+		go sub.overflow()
+		return
+	}
+
+	sub.signal()
+}
+
+// release queues the notifications held during the resubscription, dropping
+// the ones of the blocks up to head which have been backfilled already
+func (sub *Subscription) release(head uint64, backfilled bool) {
+
+	sub.mutex.Lock()
+
+	ok := true
+	for _, result := range sub.held {
+		if number, known := notificationBlock(result, sub.blockField); backfilled && known && number <= head {
+			continue
+		}
+		if ok = sub.enqueue(result); !ok {
+			break
+		}
+	}
+
+	sub.held = nil
+	sub.holding = false
+
+	sub.mutex.Unlock()
+
+	if !ok {
+		go sub.overflow()
+		return
+	}
+
+	sub.signal()
+}
+
+func (sub *Subscription) overflow() {
+	if id, active := sub.client.removeSubscription(sub); active {
+		var result json.RawMessage
+		sub.client.sendRequest(context.Background(), &result, sub.namespace+"_unsubscribe", []string{id})
+	}
+	sub.terminate(customerror.SUBSCRIPTIONOVERFLOW)
+}
+
+// report sends err on Err without ending the subscription, it is dropped when
+// an error is waiting to be read already
+func (sub *Subscription) report(err error) {
+
+	sub.mutex.Lock()
+	defer sub.mutex.Unlock()
+
+	if !sub.done() && len(sub.err) == 0 {
+		sub.err <- err
+	}
+}
+
+// forward hands the queued notifications to the consumer, one at a time
+func (sub *Subscription) forward() {
+
+	defer close(sub.notifications)
+
+	for {
+		sub.mutex.Lock()
+
+		if len(sub.queue) == 0 {
+			sub.mutex.Unlock()
+			select {
+			case <-sub.wake:
+				continue
+			case <-sub.quit:
+				return
+			}
+		}
+
+		next := sub.queue[0]
+		sub.queue[0] = nil
+		sub.queue = sub.queue[1:]
+		sub.mutex.Unlock()
+
+		select {
+		case sub.notifications <- next:
+		case <-sub.quit:
+			return
+		}
+	}
+}
+
+// done reports whether the subscription has ended
+func (sub *Subscription) done() bool {
+	select {
+	case <-sub.quit:
+		return true
+	default:
+		return false
+	}
+}
+
+// notificationBlock reads the block number found in field of a notification
+func notificationBlock(result json.RawMessage, field string) (uint64, bool) {
+
+	if field == "" {
+		return 0, false
+	}
+
+	var fields map[string]json.RawMessage
+	if json.Unmarshal(result, &fields) != nil {
+		return 0, false
+	}
+
+	var value string
+	if json.Unmarshal(fields[field], &value) != nil || !strings.HasPrefix(value, "0x") {
+		return 0, false
+	}
+
+	number, err := strconv.ParseUint(value[2:], 16, 64)
+
+	return number, err == nil
+}
+
+// logKey identifies a log by its block hash and index, empty for the other notifications
+func logKey(result json.RawMessage) string {
+
+	var log struct {
+		BlockHash string `json:"blockHash"`
+		LogIndex  string `json:"logIndex"`
+	}
+	if json.Unmarshal(result, &log) != nil || log.BlockHash == "" || log.LogIndex == "" {
+		return ""
+	}
+
+	return log.BlockHash + "/" + log.LogIndex
+}
+
+// terminate ends the subscription, reporting err when it is not nil
+func (sub *Subscription) terminate(err error) {
+	sub.once.Do(func() {
+		sub.mutex.Lock()
+		defer sub.mutex.Unlock()
+		if err != nil {
+			sub.err <- err
+		}
+		close(sub.err)
+		close(sub.quit)
+	})
+}
@@ -0,0 +1,173 @@
+/********************************************************************************
+   This file is part of go-web3.
+   go-web3 is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-web3 is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-web3.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file tracing.go
+ */
+
+package providers
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/cellcycle/go-web3/dto"
+)
+
+// Tracer - Starts the spans of the requests, such as an OpenTelemetry
+// trace.Tracer starting client spans once adapted
+type Tracer interface {
+	// Start starts a span named name, a child of the span of ctx, and returns
+	// the context holding it
+	Start(ctx context.Context, name string, attributes map[string]interface{}) (context.Context, Span)
+}
+
+// Span - A span started by a Tracer
+type Span interface {
+	SetAttributes(attributes map[string]interface{})
+	// SetError records err and sets the status of the span to error
+	SetError(err error)
+	End()
+}
+
+// spanKey - the context key of the span of a request
+type spanKey struct{}
+
+type tracingObserver struct {
+	tracer Tracer
+}
+
+// NewTracingObserver - An Observer starting a span for every request. The span
+// is named after the method and follows the OpenTelemetry conventions for
+// JSON-RPC: rpc.system, rpc.method, rpc.jsonrpc.version, server.address, and
+// rpc.jsonrpc.error_code and rpc.jsonrpc.error_message for the node errors.
+func NewTracingObserver(tracer Tracer) Observer {
+	return &tracingObserver{tracer: tracer}
+}
+
+func (observer *tracingObserver) RequestStarted(ctx context.Context, request RequestInfo) context.Context {
+
+	attributes := map[string]interface{}{
+		"rpc.system":          "jsonrpc",
+		"rpc.method":          request.Method,
+		"rpc.jsonrpc.version": "2.0",
+		"web3.params.size":    request.ParamsSize,
+	}
+	if request.Endpoint != "" {
+		attributes["server.address"] = request.Endpoint
+	}
+	if request.Batch {
+		attributes["web3.batch"] = true
+	}
+
+	ctx, span := observer.tracer.Start(ctx, request.Method, attributes)
+
+	return context.WithValue(ctx, spanKey{}, span)
+}
+
+func (observer *tracingObserver) RequestFinished(ctx context.Context, request RequestInfo, outcome RequestOutcome) {
+
+	span, ok := ctx.Value(spanKey{}).(Span)
+	if !ok {
+		return
+	}
+
+	if outcome.Err != nil {
+		attributes := map[string]interface{}{"error.type": string(outcome.Class)}
+
+		var rpcError *dto.RPCError
+		if errors.As(outcome.Err, &rpcError) {
+			attributes["rpc.jsonrpc.error_code"] = rpcError.Code
+			attributes["rpc.jsonrpc.error_message"] = rpcError.Message
+		}
+
+		span.SetAttributes(attributes)
+		span.SetError(outcome.Err)
+	}
+
+	span.End()
+}
+
+// MemorySpan - A span recorded by a MemoryTracer
+type MemorySpan struct {
+	Name       string
+	Attributes map[string]interface{}
+	Err        error
+	Ended      bool
+	// Parent is the span of the context the span was started with
+	Parent *MemorySpan
+
+	tracer *MemoryTracer
+}
+
+// SetAttributes - Adds attributes to the span
+func (span *MemorySpan) SetAttributes(attributes map[string]interface{}) {
+	span.tracer.mutex.Lock()
+	defer span.tracer.mutex.Unlock()
+	for key, value := range attributes {
+		span.Attributes[key] = value
+	}
+}
+
+// SetError - Records err
+func (span *MemorySpan) SetError(err error) {
+	span.tracer.mutex.Lock()
+	defer span.tracer.mutex.Unlock()
+	span.Err = err
+}
+
+// End - Ends the span
+func (span *MemorySpan) End() {
+	span.tracer.mutex.Lock()
+	defer span.tracer.mutex.Unlock()
+	span.Ended = true
+}
+
+// MemoryTracer - A Tracer keeping its spans in memory, for the tests
+type MemoryTracer struct {
+	mutex sync.Mutex
+	spans []*MemorySpan
+}
+
+// NewMemoryTracer - A MemoryTracer without span
+func NewMemoryTracer() *MemoryTracer {
+	return new(MemoryTracer)
+}
+
+// Start - Starts a span, a child of the MemorySpan of ctx if any
+func (tracer *MemoryTracer) Start(ctx context.Context, name string, attributes map[string]interface{}) (context.Context, Span) {
+
+	span := &MemorySpan{Name: name, Attributes: make(map[string]interface{}), tracer: tracer}
+	for key, value := range attributes {
+		span.Attributes[key] = value
+	}
+
+	if parent, ok := ctx.Value(spanKey{}).(*MemorySpan); ok {
+		span.Parent = parent
+	}
+
+	tracer.mutex.Lock()
+	tracer.spans = append(tracer.spans, span)
+	tracer.mutex.Unlock()
+
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+// Spans - The spans started so far, in order
+func (tracer *MemoryTracer) Spans() []*MemorySpan {
+	tracer.mutex.Lock()
+	defer tracer.mutex.Unlock()
+	return append([]*MemorySpan(nil), tracer.spans...)
+}
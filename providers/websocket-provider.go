@@ -22,52 +22,278 @@
 package providers
 
 import (
-	"math/rand"
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
 
 	"github.com/cellcycle/go-web3/constants"
 
-	"github.com/cellcycle/go-web3/providers/util"
 	"golang.org/x/net/websocket"
 )
 
 type WebSocketProvider struct {
 	address string
-	ws      *websocket.Conn
+	options WebSocketOptions
+	client  *streamClient
+}
+
+// WebSocketOptions - How a websocket provider dials the node, the zero value dials it directly
+type WebSocketOptions struct {
+	// Header is sent with the handshake request
+	Header http.Header
+	// Auth sets the credentials of each handshake, after Header
+	Auth Authenticator
+	// TLSConfig is used for the wss:// addresses, the host name is verified when nil
+	TLSConfig *tls.Config
+	// Proxy returns the HTTP proxy the connection is tunneled through, as
+	// http.ProxyFromEnvironment does, nil to connect directly
+	Proxy func(*http.Request) (*url.URL, error)
+	// DialTimeout bounds the connection and the handshake, in addition to the context of the call
+	DialTimeout time.Duration
+	// Reconnect restores the connection when it is lost, see NewWebSocketProviderWithReconnect
+	Reconnect *ReconnectPolicy
+}
+
+// connectTunnel asks the HTTP proxy at the other end of conn for a tunnel to host
+func connectTunnel(conn net.Conn, proxy *url.URL, host string) error {
+
+	request := &http.Request{
+		Method: "CONNECT",
+		URL:    &url.URL{Opaque: host},
+		Host:   host,
+		Header: make(http.Header),
+	}
+	if proxy.User != nil {
+		password, _ := proxy.User.Password()
+		credentials := base64.StdEncoding.EncodeToString([]byte(proxy.User.Username() + ":" + password))
+		request.Header.Set("Proxy-Authorization", "Basic "+credentials)
+	}
+
+	if err := request.Write(conn); err != nil {
+		return err
+	}
+
+	// the proxy sends nothing after its answer until the handshake is sent,
+	// so the reader doesn't buffer any byte of the tunnel
+	response, err := http.ReadResponse(bufio.NewReader(conn), request)
+	if err != nil {
+		return err
+	}
+	response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("Proxy %s refused the tunnel to %s: %s", proxy.Host, host, response.Status)
+	}
+
+	return nil
 }
 
 func NewWebSocketProvider(address string) *WebSocketProvider {
+	return NewWebSocketProviderWithOptions(address, WebSocketOptions{})
+}
+
+// NewWebSocketProviderWithOptions - A websocket provider dialing the node with options
+func NewWebSocketProviderWithOptions(address string, options WebSocketOptions) *WebSocketProvider {
 	provider := new(WebSocketProvider)
 	provider.address = address
+	provider.options = options
+	provider.client = newStreamClient(provider.dial)
+	if options.Reconnect != nil {
+		policy := options.Reconnect.withDefaults()
+		provider.client.policy = &policy
+	}
 	return provider
 }
 
-func (provider WebSocketProvider) SendRequest(v interface{}, method string, params interface{}) error {
+// NewWebSocketProviderWithReconnect - A websocket provider restoring its connection when it is lost.
+// The calls in flight fail with the connection error and the new calls wait for the reconnection.
+// The subscriptions are created again on the new connection, under a new id, and the newHeads
+// and logs subscriptions first receive the headers and logs of the blocks missed in between.
+func NewWebSocketProviderWithReconnect(address string, policy ReconnectPolicy) *WebSocketProvider {
+	return NewWebSocketProviderWithOptions(address, WebSocketOptions{Reconnect: &policy})
+}
 
-	bodyString := util.JSONRPCObject{Version: "2.0", Method: method, Params: params, ID: rand.Intn(100)}
+// Endpoint - The ws:// or wss:// address of the node
+func (provider *WebSocketProvider) Endpoint() string {
+	return provider.address
+}
 
-	if provider.ws == nil {
-		ws, err := websocket.Dial(provider.address, "", provider.address)
-		if err != nil {
-			return err
-		}
-		provider.ws = ws
+func (provider *WebSocketProvider) SendRequest(v interface{}, method string, params interface{}) error {
+	return provider.SendRequestCtx(context.Background(), v, method, params)
+}
+
+func (provider *WebSocketProvider) SendRequestCtx(ctx context.Context, v interface{}, method string, params interface{}) error {
+	return provider.client.sendRequest(ctx, v, method, params)
+}
+
+func (provider *WebSocketProvider) SendBatch(batch []BatchElem) error {
+	return provider.SendBatchCtx(context.Background(), batch)
+}
+
+func (provider *WebSocketProvider) SendBatchCtx(ctx context.Context, batch []BatchElem) error {
+	return provider.client.sendBatch(ctx, batch)
+}
+
+func (provider *WebSocketProvider) SubscribeCtx(ctx context.Context, namespace string, args ...interface{}) (*Subscription, error) {
+	return provider.client.subscribe(ctx, namespace, args)
+}
+
+func (provider *WebSocketProvider) Close() error {
+
+	provider.client.mutex.Lock()
+	connected := provider.client.codec != nil
+	provider.client.mutex.Unlock()
+
+	if err := provider.client.close(); err != nil || connected {
+		return err
+	}
+
+	return customerror.WEBSOCKETNOTDENIFIED
+
+}
+
+func (provider *WebSocketProvider) dial(ctx context.Context) (streamCodec, error) {
+
+	if provider.options.DialTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, provider.options.DialTimeout)
+		defer cancel()
 	}
 
-	message := []byte(bodyString.AsJsonString())
-	_, err := provider.ws.Write(message)
+	ws, err := dialWebSocket(ctx, provider.address, provider.options)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return websocket.JSON.Receive(provider.ws, v)
+	return &websocketCodec{ws: ws}, nil
+}
+
+// websocketCodec - One JSON-RPC message per websocket frame
+type websocketCodec struct {
+	ws *websocket.Conn
+}
 
+func (codec *websocketCodec) ReadMessage() (json.RawMessage, error) {
+	var message []byte
+	err := websocket.Message.Receive(codec.ws, &message)
+	return message, err
 }
 
-func (provider WebSocketProvider) Close() error {
-	if provider.ws != nil {
-		return provider.ws.Close()
+func (codec *websocketCodec) WriteMessage(ctx context.Context, message []byte) error {
+
+	if deadline, ok := ctx.Deadline(); ok {
+		codec.ws.SetWriteDeadline(deadline)
+		defer codec.ws.SetWriteDeadline(time.Time{})
 	}
 
-	return customerror.WEBSOCKETNOTDENIFIED
+	_, err := codec.ws.Write(message)
+	return err
+}
+
+func (codec *websocketCodec) Close() error {
+	return codec.ws.Close()
+}
+
+// dialWebSocket opens the TCP (or TLS) connection, through the proxy of options
+// if any, and runs the websocket handshake, giving up as soon as ctx is done.
+func dialWebSocket(ctx context.Context, address string, options WebSocketOptions) (*websocket.Conn, error) {
+
+	config, err := websocket.NewConfig(address, address)
+	if err != nil {
+		return nil, err
+	}
+
+	for key, values := range options.Header {
+		for _, value := range values {
+			config.Header.Add(key, value)
+		}
+	}
+	if options.Auth != nil {
+		if err := options.Auth.Authenticate(config.Header); err != nil {
+			return nil, err
+		}
+	}
+	config.TlsConfig = options.TLSConfig
+
+	host := config.Location.Host
+	if config.Location.Port() == "" {
+		if config.Location.Scheme == "wss" {
+			host = net.JoinHostPort(config.Location.Hostname(), "443")
+		} else {
+			host = net.JoinHostPort(config.Location.Hostname(), "80")
+		}
+	}
+
+	var proxy *url.URL
+	if options.Proxy != nil {
+		// the proxy is chosen as for the http(s) url of the node
+		target := *config.Location
+		target.Scheme = "http"
+		if config.Location.Scheme == "wss" {
+			target.Scheme = "https"
+		}
+		if proxy, err = options.Proxy(&http.Request{Method: "GET", URL: &target, Header: make(http.Header)}); err != nil {
+			return nil, err
+		}
+	}
+
+	dialed := host
+	if proxy != nil {
+		dialed = proxy.Host
+		if proxy.Port() == "" {
+			dialed = net.JoinHostPort(proxy.Hostname(), "80")
+		}
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", dialed)
+	if err != nil {
+		return nil, err
+	}
+
+	stop := watchContext(ctx, conn)
+
+	if proxy != nil {
+		if err := connectTunnel(conn, proxy, host); err != nil {
+			stop()
+			conn.Close()
+			return nil, contextError(ctx, err)
+		}
+	}
+
+	if config.Location.Scheme == "wss" {
+		tlsConfig := &tls.Config{ServerName: config.Location.Hostname()}
+		if config.TlsConfig != nil {
+			tlsConfig = config.TlsConfig.Clone()
+			if tlsConfig.ServerName == "" {
+				tlsConfig.ServerName = config.Location.Hostname()
+			}
+		}
+		tlsConn := tls.Client(conn, tlsConfig)
+		if err := tlsConn.Handshake(); err != nil {
+			stop()
+			conn.Close()
+			return nil, contextError(ctx, err)
+		}
+		conn = tlsConn
+	}
+
+	ws, err := websocket.NewClient(config, conn)
+	stop()
+	if err != nil {
+		conn.Close()
+		return nil, contextError(ctx, err)
+	}
+
+	// the connection outlives ctx, so drop the deadline set for the handshake
+	conn.SetDeadline(time.Time{})
 
+	return ws, nil
 }
@@ -0,0 +1,628 @@
+/********************************************************************************
+   This file is part of go-web3.
+   go-web3 is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-web3 is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-web3.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file server.go
+ */
+
+// Package server answers JSON-RPC 2.0 requests, sent over HTTP or websocket, by
+// forwarding them through a provider. It is the base of a gateway in front of the
+// nodes: the provider brings the caching, the failover or the retries, the server
+// the method allowlist, the rate limits per client and the custom methods.
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cellcycle/go-web3/dto"
+	"github.com/cellcycle/go-web3/providers"
+)
+
+// The codes of the errors answered by the server itself
+const (
+	CodeParseError     = -32700
+	CodeInvalidRequest = -32600
+	CodeMethodNotFound = -32601
+	CodeInvalidParams  = -32602
+	CodeInternalError  = -32603
+	// CodeServerError is the code of the errors such as an unknown subscription
+	CodeServerError = -32000
+	// CodeLimitExceeded is the code of the calls over the rate limit of the client
+	CodeLimitExceeded = -32005
+)
+
+// privateNamespaces are the namespaces of the methods handling the accounts or the
+// node, they are only forwarded when they are listed in Options.Methods
+var privateNamespaces = []string{"admin_", "personal_"}
+
+// Request - A call received by the server
+type Request struct {
+	Method string
+	// Params holds the params as sent, null when there were none
+	Params json.RawMessage
+	// Header is the header of the HTTP request, or of the websocket handshake
+	Header http.Header
+	// RemoteAddr is the address of the client, as in http.Request
+	RemoteAddr string
+}
+
+// Handler - Answers the calls of a method. The result is encoded to JSON, an error
+// found with errors.As to be a *dto.RPCError is answered with its code and data,
+// any other error as an internal error.
+type Handler interface {
+	ServeRPC(ctx context.Context, request *Request) (interface{}, error)
+}
+
+// HandlerFunc - A function used as a Handler
+type HandlerFunc func(ctx context.Context, request *Request) (interface{}, error)
+
+// ServeRPC - Calls function with ctx and request
+func (function HandlerFunc) ServeRPC(ctx context.Context, request *Request) (interface{}, error) {
+	return function(ctx, request)
+}
+
+// Options - The limits of a server, the zero value serves every method without a rate limit
+type Options struct {
+	// Methods are the methods forwarded to the provider, every method but the admin_
+	// and personal_ ones when empty. The others are answered with a method not found
+	// error, unless they have a handler.
+	Methods []string
+	// Origins are the origins of the browser pages allowed to open a websocket, such
+	// as https://app.example.org, or * for any. A handshake sent from another origin
+	// than the host of the server is refused when the origin is not listed.
+	Origins []string
+	// Key returns the client a request counts for in the rate limits, such as the
+	// value of an API key header. The requests are counted by remote host when nil.
+	Key func(request *http.Request) string
+	// RateLimit is the limit of each client for the methods missing in MethodLimits.
+	// The calls over the limit are answered right away with a CodeLimitExceeded
+	// error, and over HTTP with the status 429 when no call of the request was served.
+	RateLimit    providers.RateLimit
+	MethodLimits map[string]providers.RateLimit
+	// LimiterIdle is how long the limits of a client are kept after its last call,
+	// 10 minutes when zero. A client calling again later starts with full buckets.
+	LimiterIdle time.Duration
+	// MaxBatchSize bounds the calls of a batch, 100 when zero
+	MaxBatchSize int
+	// MaxBodySize bounds the size of a request or of a websocket message, 5 MiB when zero
+	MaxBodySize int64
+	// MaxConnectionCalls bounds the messages of a websocket connection answered at
+	// the same time, 32 when zero. The next message is read once one is answered.
+	MaxConnectionCalls int
+	// ErrorLog logs the errors answered to the clients as internal errors, whose
+	// detail is not sent to them. The standard logger is used when nil.
+	ErrorLog *log.Logger
+}
+
+func (options Options) withDefaults() Options {
+	if options.MaxBatchSize <= 0 {
+		options.MaxBatchSize = 100
+	}
+	if options.MaxBodySize <= 0 {
+		options.MaxBodySize = 5 << 20
+	}
+	if options.LimiterIdle <= 0 {
+		options.LimiterIdle = 10 * time.Minute
+	}
+	if options.MaxConnectionCalls <= 0 {
+		options.MaxConnectionCalls = 32
+	}
+	return options
+}
+
+// Server - A JSON-RPC server forwarding the calls to a provider. It is an http.Handler
+// answering the POST requests and the websocket handshakes.
+type Server struct {
+	provider providers.ProviderInterface
+	options  Options
+	allowed  map[string]bool
+
+	mutex       sync.Mutex
+	handlers    map[string]Handler
+	limiters    map[string]*clientLimiter
+	swept       time.Time
+	connections map[*connection]bool
+}
+
+// clientLimiter - the rate limits of a client and the time of its last call
+type clientLimiter struct {
+	limiter  *providers.Limiter
+	lastCall time.Time
+}
+
+// NewServer - A server forwarding the calls it receives to provider
+func NewServer(provider providers.ProviderInterface, options Options) *Server {
+
+	server := new(Server)
+	server.provider = provider
+	server.options = options.withDefaults()
+	server.handlers = make(map[string]Handler)
+	server.limiters = make(map[string]*clientLimiter)
+	server.connections = make(map[*connection]bool)
+
+	if len(options.Methods) > 0 {
+		server.allowed = make(map[string]bool, len(options.Methods))
+		for _, method := range options.Methods {
+			server.allowed[method] = true
+		}
+	}
+
+	return server
+}
+
+// Handle - Answers the calls of method with handler instead of forwarding them
+func (server *Server) Handle(method string, handler Handler) {
+	server.mutex.Lock()
+	server.handlers[method] = handler
+	server.mutex.Unlock()
+}
+
+// HandleFunc - Same as Handle, with a function
+func (server *Server) HandleFunc(method string, handler func(ctx context.Context, request *Request) (interface{}, error)) {
+	server.Handle(method, HandlerFunc(handler))
+}
+
+// Forward - Sends request to the provider and returns its raw result, or the error
+// answered by the node as a *dto.RPCError. The handlers call it to wrap a method.
+func (server *Server) Forward(ctx context.Context, request *Request) (interface{}, error) {
+
+	pointer := &dto.RequestResult{}
+
+	if err := server.provider.SendRequestCtx(ctx, pointer, request.Method, paramsOf(request.Params)); err != nil {
+		return nil, err
+	}
+
+	return resultOf(pointer)
+}
+
+// Close - Ends the websocket connections and their subscriptions
+func (server *Server) Close() error {
+
+	server.mutex.Lock()
+	connections := make([]*connection, 0, len(server.connections))
+	for conn := range server.connections {
+		connections = append(connections, conn)
+	}
+	server.mutex.Unlock()
+
+	for _, conn := range connections {
+		conn.close()
+	}
+
+	return nil
+}
+
+func (server *Server) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+
+	if strings.EqualFold(request.Header.Get("Upgrade"), "websocket") {
+		server.serveWebSocket(writer, request)
+		return
+	}
+
+	if request.Method != http.MethodPost {
+		writer.Header().Set("Allow", http.MethodPost)
+		http.Error(writer, "JSON-RPC requests are sent with POST", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := ioutil.ReadAll(http.MaxBytesReader(writer, request.Body, server.options.MaxBodySize))
+	if err != nil {
+		http.Error(writer, fmt.Sprintf("Request body over %d bytes", server.options.MaxBodySize), http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	client := server.newSession(request)
+
+	answer, limited := server.handleMessage(request.Context(), client, body)
+
+	writer.Header().Set("Content-Type", "application/json")
+	if limited {
+		writer.WriteHeader(http.StatusTooManyRequests)
+	}
+	writer.Write(answer)
+}
+
+// session - the client of a request, or of a websocket connection
+type session struct {
+	header     http.Header
+	remoteAddr string
+	key        string
+	// conn is nil over HTTP, where the subscriptions are not supported
+	conn *connection
+}
+
+func (server *Server) newSession(request *http.Request) *session {
+
+	client := &session{header: request.Header, remoteAddr: request.RemoteAddr}
+
+	if server.options.Key != nil {
+		client.key = server.options.Key(request)
+	} else if host, _, err := net.SplitHostPort(request.RemoteAddr); err == nil {
+		client.key = host
+	} else {
+		client.key = request.RemoteAddr
+	}
+
+	return client
+}
+
+// call - a JSON-RPC request, or a notification when ID is missing
+type call struct {
+	Version string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params"`
+}
+
+func (c *call) isNotification() bool {
+	return len(c.ID) == 0
+}
+
+// response - the answer to a call, Result is only left out with an Error
+type response struct {
+	Version string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *dto.Error      `json:"error,omitempty"`
+}
+
+func newResponse(id json.RawMessage, result json.RawMessage, err *dto.Error) *response {
+
+	if len(id) == 0 {
+		id = json.RawMessage("null")
+	}
+	if err == nil && len(result) == 0 {
+		result = json.RawMessage("null")
+	}
+
+	return &response{Version: "2.0", ID: id, Result: result, Error: err}
+}
+
+func errorResponse(id json.RawMessage, code int, message string) *response {
+	return newResponse(id, nil, &dto.Error{Code: code, Message: message})
+}
+
+// handleMessage answers a single call or a batch, it returns nothing when the
+// message only holds notifications. It also tells whether every call of the
+// message was refused by the rate limit.
+func (server *Server) handleMessage(ctx context.Context, client *session, message []byte) ([]byte, bool) {
+
+	message = bytes.TrimSpace(message)
+
+	if len(message) > 0 && message[0] == '[' {
+		return server.handleBatch(ctx, client, message)
+	}
+
+	var single call
+	if err := json.Unmarshal(message, &single); err != nil {
+		return encode(errorResponse(nil, CodeParseError, "Parse error: "+err.Error())), false
+	}
+
+	answer, limited := server.handleCall(ctx, client, &single)
+	if answer == nil {
+		return nil, limited
+	}
+
+	return encode(answer), limited
+}
+
+func (server *Server) handleBatch(ctx context.Context, client *session, message []byte) ([]byte, bool) {
+
+	var raw []json.RawMessage
+	if err := json.Unmarshal(message, &raw); err != nil {
+		return encode(errorResponse(nil, CodeParseError, "Parse error: "+err.Error())), false
+	}
+
+	if len(raw) == 0 {
+		return encode(errorResponse(nil, CodeInvalidRequest, "Empty batch")), false
+	}
+	if len(raw) > server.options.MaxBatchSize {
+		return encode(errorResponse(nil, CodeInvalidRequest, fmt.Sprintf("Batch of %d calls over the limit of %d", len(raw), server.options.MaxBatchSize))), false
+	}
+
+	answers := make([]*response, len(raw))
+	calls := make([]*call, len(raw))
+	limited := 0
+
+	// the calls without a handler are forwarded together, in a single batch
+	var forwarded []int
+	var batch []providers.BatchElem
+
+	for index, element := range raw {
+
+		current := new(call)
+		if err := json.Unmarshal(element, current); err != nil {
+			answers[index] = errorResponse(nil, CodeInvalidRequest, "Invalid request: "+err.Error())
+			continue
+		}
+		calls[index] = current
+
+		if answer, ok := server.check(client, current); !ok {
+			answers[index] = answer
+			if isLimitExceeded(answer) {
+				limited++
+			}
+			continue
+		}
+
+		if handler := server.handler(client, current.Method); handler != nil {
+			answers[index] = server.serve(ctx, client, current, handler)
+			continue
+		}
+
+		forwarded = append(forwarded, index)
+		batch = append(batch, providers.BatchElem{Method: current.Method, Params: paramsOf(current.Params)})
+	}
+
+	if len(batch) > 0 {
+
+		err := providers.SendBatchCtx(ctx, server.provider, batch)
+
+		for position, index := range forwarded {
+			elementErr := batch[position].Error
+			if err != nil {
+				elementErr = err
+			}
+
+			var result interface{}
+			if elementErr == nil {
+				result, elementErr = resultOf(batch[position].Result)
+			}
+
+			answers[index] = server.answer(calls[index], result, elementErr)
+		}
+	}
+
+	encoded := make([]json.RawMessage, 0, len(answers))
+	for index, answer := range answers {
+		// the notifications are never answered, even with an error
+		if calls[index] != nil && calls[index].isNotification() {
+			continue
+		}
+		encoded = append(encoded, encode(answer))
+	}
+
+	if len(encoded) == 0 {
+		return nil, limited == len(raw)
+	}
+
+	return encode(encoded), limited == len(raw)
+}
+
+// handleCall answers a single call, nil for a notification. It also tells
+// whether the call was refused by the rate limit.
+func (server *Server) handleCall(ctx context.Context, client *session, current *call) (*response, bool) {
+
+	answer, ok := server.check(client, current)
+
+	if ok {
+		if handler := server.handler(client, current.Method); handler != nil {
+			answer = server.serve(ctx, client, current, handler)
+		} else {
+			request := server.newRequest(client, current)
+			result, err := server.Forward(ctx, request)
+			answer = server.answer(current, result, err)
+		}
+	}
+
+	limited := !ok && isLimitExceeded(answer)
+
+	if current.isNotification() {
+		return nil, limited
+	}
+
+	return answer, limited
+}
+
+// check validates current and takes a token of its rate limit. It returns the
+// error answer and false when the call must not be served.
+func (server *Server) check(client *session, current *call) (*response, bool) {
+
+	if current.Version != "2.0" || current.Method == "" {
+		return errorResponse(current.ID, CodeInvalidRequest, "Invalid request"), false
+	}
+
+	if !server.serves(client, current.Method) {
+		return errorResponse(current.ID, CodeMethodNotFound, fmt.Sprintf("The method %s does not exist/is not available", current.Method)), false
+	}
+
+	if limiter := server.limiter(client.key); limiter != nil && !limiter.Allow(current.Method) {
+		return errorResponse(current.ID, CodeLimitExceeded, "Rate limit exceeded"), false
+	}
+
+	return nil, true
+}
+
+// isLimitExceeded tells if answer refuses a call over the rate limit
+func isLimitExceeded(answer *response) bool {
+	return answer != nil && answer.Error != nil && answer.Error.Code == CodeLimitExceeded
+}
+
+// serves tells if method is served to client, the methods with a handler always are
+func (server *Server) serves(client *session, method string) bool {
+
+	if server.handler(client, method) != nil {
+		return true
+	}
+
+	return server.forwards(method)
+}
+
+// forwards tells if method is sent to the provider, the methods of the private
+// namespaces are only sent when they are listed
+func (server *Server) forwards(method string) bool {
+
+	if server.allowed != nil {
+		return server.allowed[method]
+	}
+
+	for _, namespace := range privateNamespaces {
+		if strings.HasPrefix(method, namespace) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// handler returns the handler of method, the subscriptions are handled by the
+// websocket connection of client
+func (server *Server) handler(client *session, method string) Handler {
+
+	server.mutex.Lock()
+	handler := server.handlers[method]
+	server.mutex.Unlock()
+
+	if handler != nil {
+		return handler
+	}
+
+	if isSubscriptionMethod(method) && server.forwards(method) {
+		return subscriptionHandler{client: client}
+	}
+
+	return nil
+}
+
+// limiter returns the rate limiter of key, nil without a rate limit. The limiters
+// unused for LimiterIdle are dropped, so the clients gone don't stay in memory.
+func (server *Server) limiter(key string) *providers.Limiter {
+
+	if server.options.RateLimit.Rate <= 0 && len(server.options.MethodLimits) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	if now.Sub(server.swept) >= server.options.LimiterIdle {
+		for client, idle := range server.limiters {
+			if now.Sub(idle.lastCall) >= server.options.LimiterIdle {
+				delete(server.limiters, client)
+			}
+		}
+		server.swept = now
+	}
+
+	client, ok := server.limiters[key]
+	if !ok {
+		client = &clientLimiter{limiter: providers.NewLimiter(server.options.RateLimit, server.options.MethodLimits)}
+		server.limiters[key] = client
+	}
+	client.lastCall = now
+
+	return client.limiter
+}
+
+func (server *Server) newRequest(client *session, current *call) *Request {
+
+	params := current.Params
+	if len(params) == 0 {
+		params = json.RawMessage("null")
+	}
+
+	return &Request{Method: current.Method, Params: params, Header: client.header, RemoteAddr: client.remoteAddr}
+}
+
+func (server *Server) serve(ctx context.Context, client *session, current *call, handler Handler) *response {
+	result, err := handler.ServeRPC(ctx, server.newRequest(client, current))
+	return server.answer(current, result, err)
+}
+
+// answer builds the response of current from the result or the error of its handler
+func (server *Server) answer(current *call, result interface{}, err error) *response {
+
+	if err != nil {
+		return newResponse(current.ID, nil, server.errorObject(current, err))
+	}
+
+	encoded, err := json.Marshal(result)
+	if err != nil {
+		return errorResponse(current.ID, CodeInternalError, "Unencodable result: "+err.Error())
+	}
+
+	return newResponse(current.ID, encoded, nil)
+}
+
+// errorObject is the JSON-RPC error object of err. An error other than a
+// *dto.RPCError, such as the failure of the transport to the node, is logged
+// and answered without its detail.
+func (server *Server) errorObject(current *call, err error) *dto.Error {
+
+	var rpcError *dto.RPCError
+	if errors.As(err, &rpcError) {
+		return &dto.Error{Code: rpcError.Code, Message: rpcError.Message, Data: rpcError.Data}
+	}
+
+	if server.options.ErrorLog != nil {
+		server.options.ErrorLog.Printf("server: %s: %v", current.Method, err)
+	} else {
+		log.Printf("server: %s: %v", current.Method, err)
+	}
+
+	return &dto.Error{Code: CodeInternalError, Message: "Internal error"}
+}
+
+// paramsOf is the value sent as the params of a forwarded call
+func paramsOf(params json.RawMessage) interface{} {
+
+	if len(params) == 0 || bytes.Equal(params, []byte("null")) {
+		return nil
+	}
+
+	return params
+}
+
+// resultOf is the raw result of a forwarded call, or the error answered by the node.
+// The result is forwarded as received, its numbers and keys left untouched.
+func resultOf(pointer *dto.RequestResult) (interface{}, error) {
+
+	if pointer.Error != nil {
+		return nil, dto.NewRPCError(pointer.Error)
+	}
+
+	if raw := pointer.RawResult(); raw != nil {
+		return raw, nil
+	}
+
+	encoded, err := json.Marshal(pointer.Result)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.RawMessage(encoded), nil
+}
+
+func encode(value interface{}) []byte {
+
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		encoded, _ = json.Marshal(errorResponse(nil, CodeInternalError, err.Error()))
+	}
+
+	return encoded
+}
@@ -0,0 +1,299 @@
+/********************************************************************************
+   This file is part of go-web3.
+   go-web3 is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-web3 is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-web3.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file websocket.go
+ */
+
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/cellcycle/go-web3/dto"
+	"github.com/cellcycle/go-web3/providers"
+
+	"golang.org/x/net/websocket"
+)
+
+// connection - a websocket client, its calls are answered concurrently and its
+// subscriptions forward the notifications of the subscriptions to the provider
+type connection struct {
+	server *Server
+	ws     *websocket.Conn
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	writeMutex sync.Mutex
+
+	mutex         sync.Mutex
+	subscriptions map[string]*providers.Subscription
+}
+
+func (server *Server) serveWebSocket(writer http.ResponseWriter, request *http.Request) {
+
+	client := server.newSession(request)
+
+	handler := websocket.Server{
+		Handshake: server.checkOrigin,
+		Handler: func(ws *websocket.Conn) {
+			ws.MaxPayloadBytes = int(server.options.MaxBodySize)
+			server.serveConnection(ws, client)
+		},
+	}
+
+	handler.ServeHTTP(writer, request)
+}
+
+// checkOrigin refuses the handshakes sent by a browser page of another origin
+// than the server, unless the origin is listed in Options.Origins. The clients
+// other than browsers send no Origin header, or the address they dial.
+func (server *Server) checkOrigin(config *websocket.Config, request *http.Request) error {
+
+	origin := request.Header.Get("Origin")
+	if origin == "" {
+		return nil
+	}
+
+	for _, allowed := range server.options.Origins {
+		if allowed == "*" || strings.EqualFold(allowed, origin) {
+			return nil
+		}
+	}
+
+	if location, err := url.Parse(origin); err == nil && strings.EqualFold(location.Host, request.Host) {
+		return nil
+	}
+
+	return fmt.Errorf("Origin %s not allowed", origin)
+}
+
+func (server *Server) serveConnection(ws *websocket.Conn, client *session) {
+
+	conn := &connection{server: server, ws: ws, subscriptions: make(map[string]*providers.Subscription)}
+	conn.ctx, conn.cancel = context.WithCancel(context.Background())
+	client.conn = conn
+
+	server.mutex.Lock()
+	server.connections[conn] = true
+	server.mutex.Unlock()
+
+	// the calls in flight are canceled by close, then waited for
+	var calls sync.WaitGroup
+	defer calls.Wait()
+
+	// a slot is taken for each message answered, the next message waits for one
+	slots := make(chan struct{}, server.options.MaxConnectionCalls)
+
+	defer func() {
+		server.mutex.Lock()
+		delete(server.connections, conn)
+		server.mutex.Unlock()
+		conn.close()
+	}()
+
+	for {
+		var message []byte
+		if err := websocket.Message.Receive(ws, &message); err != nil {
+			return
+		}
+
+		select {
+		case slots <- struct{}{}:
+		case <-conn.ctx.Done():
+			return
+		}
+
+		calls.Add(1)
+		go func() {
+			defer func() {
+				<-slots
+				calls.Done()
+			}()
+			if answer, _ := server.handleMessage(conn.ctx, client, message); answer != nil {
+				conn.write(answer)
+			}
+		}()
+	}
+}
+
+// write sends message in a text frame, one writer at a time
+func (conn *connection) write(message []byte) error {
+	conn.writeMutex.Lock()
+	defer conn.writeMutex.Unlock()
+	return websocket.Message.Send(conn.ws, string(message))
+}
+
+// close cancels the calls in flight, ends the subscriptions and the connection
+func (conn *connection) close() {
+
+	conn.cancel()
+
+	conn.mutex.Lock()
+	subscriptions := conn.subscriptions
+	conn.subscriptions = make(map[string]*providers.Subscription)
+	conn.mutex.Unlock()
+
+	for _, sub := range subscriptions {
+		sub.Unsubscribe()
+	}
+
+	conn.ws.Close()
+}
+
+// isSubscriptionMethod tells if method is a <namespace>_subscribe or <namespace>_unsubscribe
+func isSubscriptionMethod(method string) bool {
+	return strings.HasSuffix(method, "_subscribe") || strings.HasSuffix(method, "_unsubscribe")
+}
+
+// subscriptionHandler - answers the <namespace>_subscribe and <namespace>_unsubscribe
+// calls with the subscriptions of the websocket connection of client
+type subscriptionHandler struct {
+	client *session
+}
+
+func (handler subscriptionHandler) ServeRPC(ctx context.Context, request *Request) (interface{}, error) {
+
+	conn := handler.client.conn
+	if conn == nil {
+		return nil, &dto.RPCError{Code: CodeMethodNotFound, Message: "notifications not supported"}
+	}
+
+	if strings.HasSuffix(request.Method, "_unsubscribe") {
+		return conn.unsubscribe(ctx, request)
+	}
+
+	return conn.subscribe(ctx, request)
+}
+
+// subscribe subscribes to the provider, under an id of the server which stays the
+// same when a reconnecting provider subscribes again
+func (conn *connection) subscribe(ctx context.Context, request *Request) (interface{}, error) {
+
+	subscriber, ok := conn.server.provider.(providers.SubscriptionProvider)
+	if !ok {
+		return nil, &dto.RPCError{Code: CodeMethodNotFound, Message: "notifications not supported"}
+	}
+
+	var params []json.RawMessage
+	if err := json.Unmarshal(request.Params, &params); err != nil || len(params) == 0 {
+		return nil, &dto.RPCError{Code: CodeInvalidParams, Message: "Invalid params: expected the subscription name"}
+	}
+
+	args := make([]interface{}, len(params))
+	for index := range params {
+		args[index] = params[index]
+	}
+
+	namespace := strings.TrimSuffix(request.Method, "_subscribe")
+
+	// the subscription lives as long as the connection, not as the call
+	sub, err := subscriber.SubscribeCtx(conn.ctx, namespace, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := newSubscriptionID()
+	if err != nil {
+		sub.Unsubscribe()
+		return nil, err
+	}
+
+	conn.mutex.Lock()
+	if err := conn.ctx.Err(); err != nil {
+		// the connection was closed during the call
+		conn.mutex.Unlock()
+		sub.Unsubscribe()
+		return nil, err
+	}
+	conn.subscriptions[id] = sub
+	conn.mutex.Unlock()
+
+	go conn.forward(namespace, id, sub)
+
+	return id, nil
+}
+
+func (conn *connection) unsubscribe(ctx context.Context, request *Request) (interface{}, error) {
+
+	var ids []string
+	if err := json.Unmarshal(request.Params, &ids); err != nil || len(ids) != 1 {
+		return nil, &dto.RPCError{Code: CodeInvalidParams, Message: "Invalid params: expected the subscription id"}
+	}
+
+	conn.mutex.Lock()
+	sub, ok := conn.subscriptions[ids[0]]
+	delete(conn.subscriptions, ids[0])
+	conn.mutex.Unlock()
+
+	if !ok {
+		return nil, &dto.RPCError{Code: CodeServerError, Message: "subscription not found"}
+	}
+
+	if err := sub.UnsubscribeCtx(ctx); err != nil {
+		return nil, err
+	}
+
+	return true, nil
+}
+
+// forward sends the notifications of sub to the client until the subscription ends
+func (conn *connection) forward(namespace string, id string, sub *providers.Subscription) {
+
+	defer func() {
+		conn.mutex.Lock()
+		if conn.subscriptions[id] == sub {
+			delete(conn.subscriptions, id)
+		}
+		conn.mutex.Unlock()
+	}()
+
+	for result := range sub.Notifications() {
+
+		notification := struct {
+			Version string `json:"jsonrpc"`
+			Method  string `json:"method"`
+			Params  struct {
+				Subscription string          `json:"subscription"`
+				Result       json.RawMessage `json:"result"`
+			} `json:"params"`
+		}{Version: "2.0", Method: namespace + "_subscription"}
+		notification.Params.Subscription = id
+		notification.Params.Result = result
+
+		if conn.write(encode(notification)) != nil {
+			sub.Unsubscribe()
+			return
+		}
+	}
+}
+
+// newSubscriptionID - a random 128 bits id, as the ones of geth
+func newSubscriptionID() (string, error) {
+
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+
+	return "0x" + hex.EncodeToString(id), nil
+}
@@ -22,6 +22,7 @@
 package shh
 
 import (
+	"context"
 	"github.com/cellcycle/go-web3/dto"
 	"github.com/cellcycle/go-web3/providers"
 	"github.com/cellcycle/go-web3/utils"
@@ -43,14 +44,20 @@ func NewSHH(provider providers.ProviderInterface) *SHH {
 // GetVersion - Returns the current whisper protocol version.
 // Reference: https://github.com/ethereum/wiki/wiki/JSON-RPC#shh_version
 // Parameters:
-//    - none
+//   - none
+//
 // Returns:
-// 	  - String - The current whisper protocol version
+//   - String - The current whisper protocol version
 func (shh *SHH) GetVersion() (string, error) {
+	return shh.GetVersionCtx(context.Background())
+}
+
+// GetVersionCtx - Same as GetVersion, using ctx to cancel the request or set its deadline.
+func (shh *SHH) GetVersionCtx(ctx context.Context) (string, error) {
 
 	pointer := &dto.RequestResult{}
 
-	err := shh.provider.SendRequest(pointer, "shh_version", nil)
+	err := shh.provider.SendRequestCtx(ctx, pointer, "shh_version", nil)
 
 	if err != nil {
 		return "", err
@@ -63,16 +70,23 @@ func (shh *SHH) GetVersion() (string, error) {
 // Post - Sends a whisper message.
 // Reference: https://github.com/ethereum/wiki/wiki/JSON-RPC#shh_post
 // Parameters:
-//     1 .Object - The whisper post object:
-//	  	- from: DATA, 60 Bytes - (optional) The identity of the sender.
-//    	- to: DATA, 60 Bytes - (optional) The identity of the receiver. When present whisper will encrypt the message so that only the receiver can decrypt it.
-//   	- topics: Array of DATA - Array of DATA topics, for the receiver to identify messages.
-//    	- payload: DATA - The payload of the message.
-//    	- priority: QUANTITY - The integer of the priority in a rang from ... (?).
-//    	- ttl: QUANTITY - integer of the time to live in seconds.
+//
+//	    1 .Object - The whisper post object:
+//		  	- from: DATA, 60 Bytes - (optional) The identity of the sender.
+//	   	- to: DATA, 60 Bytes - (optional) The identity of the receiver. When present whisper will encrypt the message so that only the receiver can decrypt it.
+//	  	- topics: Array of DATA - Array of DATA topics, for the receiver to identify messages.
+//	   	- payload: DATA - The payload of the message.
+//	   	- priority: QUANTITY - The integer of the priority in a rang from ... (?).
+//	   	- ttl: QUANTITY - integer of the time to live in seconds.
+//
 // Returns:
-// 	  - Boolean - returns true if the message was send, otherwise false.
+//   - Boolean - returns true if the message was send, otherwise false.
 func (shh *SHH) Post(from string, to string, topics []string, payload string, priority *big.Int, ttl *big.Int) (bool, error) {
+	return shh.PostCtx(context.Background(), from, to, topics, payload, priority, ttl)
+}
+
+// PostCtx - Same as Post, using ctx to cancel the request or set its deadline.
+func (shh *SHH) PostCtx(ctx context.Context, from string, to string, topics []string, payload string, priority *big.Int, ttl *big.Int) (bool, error) {
 
 	params := make([]dto.SHHPostParameters, 1)
 	params[0].From = from
@@ -84,7 +98,7 @@ func (shh *SHH) Post(from string, to string, topics []string, payload string, pr
 
 	pointer := &dto.RequestResult{}
 
-	err := shh.provider.SendRequest(pointer, "shh_post", params)
+	err := shh.provider.SendRequestCtx(ctx, pointer, "shh_post", params)
 
 	if err != nil {
 		return false, err
@@ -0,0 +1,200 @@
+/********************************************************************************
+   This file is part of go-web3.
+   go-web3 is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-web3 is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-web3.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file abi_test.go
+ */
+package test
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	"github.com/cellcycle/go-web3/abi"
+)
+
+const marketABI = `[
+	{"type":"constructor","inputs":[{"name":"owner","type":"address"}],"stateMutability":"nonpayable"},
+	{"type":"function","name":"transfer","inputs":[{"name":"to","type":"address"},{"name":"amount","type":"uint256"}],"outputs":[{"name":"","type":"bool"}],"stateMutability":"nonpayable"},
+	{"type":"function","name":"transfer","inputs":[{"name":"to","type":"address"},{"name":"amount","type":"uint256"},{"name":"data","type":"bytes"}],"outputs":[{"name":"","type":"bool"}],"stateMutability":"nonpayable"},
+	{"type":"function","name":"place","inputs":[{"name":"orders","type":"tuple[]","internalType":"struct Market.Order[]","components":[
+		{"name":"maker","type":"address"},
+		{"name":"amounts","type":"uint[2]"},
+		{"name":"fee","type":"tuple","internalType":"struct Market.Fee","components":[{"name":"rate","type":"uint16"},{"name":"recipient","type":"address"}]}
+	]}],"outputs":[],"stateMutability":"payable"},
+	{"type":"event","name":"Transfer","anonymous":false,"inputs":[{"name":"from","type":"address","indexed":true},{"name":"to","type":"address","indexed":true},{"name":"value","type":"uint256","indexed":false}]},
+	{"type":"error","name":"InsufficientBalance","inputs":[{"name":"available","type":"uint256"},{"name":"required","type":"uint256"}]},
+	{"type":"receive","stateMutability":"payable"}
+]`
+
+func TestParseJSON(t *testing.T) {
+
+	definition, err := abi.ParseJSON([]byte(marketABI))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if definition.Constructor == nil || definition.Constructor.Inputs[0].Type.Kind != abi.AddressKind || definition.Receive == nil {
+		t.Errorf("Unexpected special functions %+v %+v", definition.Constructor, definition.Receive)
+	}
+
+	if len(definition.MethodsByName("transfer")) != 2 {
+		t.Errorf("Expected the 2 overloads of transfer")
+	}
+
+	if _, err := definition.Method("transfer"); err == nil {
+		t.Error("Expected an error for the overloaded name")
+	}
+
+	transfer, err := definition.Method("transfer(address,uint256)")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if selector := transfer.Selector(); hex.EncodeToString(selector[:]) != "a9059cbb" {
+		t.Errorf("Unexpected selector %x", selector)
+	}
+
+	if found, err := definition.MethodBySelector([]byte{0xa9, 0x05, 0x9c, 0xbb, 0x00}); err != nil || found != transfer {
+		t.Errorf("Function not found by selector: %v", err)
+	}
+
+	place, err := definition.Method("place")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	orders := place.Inputs[0].Type
+	if place.Signature() != "place((address,uint256[2],(uint16,address))[])" || !place.IsPayable() || place.IsConstant() {
+		t.Errorf("Unexpected function %s %s", place.Signature(), place.StateMutability)
+	}
+
+	if orders.Kind != abi.SliceKind || orders.Elem.TupleName != "Order" || orders.Elem.Components[2].Type.TupleName != "Fee" || !orders.IsDynamic() {
+		t.Errorf("Unexpected tuple %+v", orders)
+	}
+
+	if orders.Elem.IsDynamic() || orders.Elem.Components[1].Type.Size != 2 {
+		t.Errorf("Unexpected static tuple %+v", orders.Elem)
+	}
+
+	event, err := definition.Event("Transfer")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if topic := event.Topic(); hex.EncodeToString(topic[:]) != "ddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef" {
+		t.Errorf("Unexpected topic %x", topic)
+	}
+
+	if found, err := definition.EventByTopic(event.Topic()); err != nil || found != event || len(event.Inputs.NonIndexed()) != 1 {
+		t.Errorf("Event not found by topic: %v", err)
+	}
+
+	contractError, err := definition.Error("InsufficientBalance")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if selector := contractError.Selector(); hex.EncodeToString(selector[:]) != "cf479181" {
+		t.Errorf("Unexpected error selector %x", selector)
+	}
+
+}
+
+func TestParseTruffleABI(t *testing.T) {
+
+	content, err := ioutil.ReadFile("../resources/simple-token.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var artifact struct {
+		Abi string `json:"abi"`
+	}
+	json.Unmarshal(content, &artifact)
+
+	definition, err := abi.ParseJSON([]byte(artifact.Abi))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the ABI predates stateMutability, constant tells the view functions
+	balanceOf, err := definition.Method("balanceOf")
+	if err != nil || !balanceOf.IsConstant() || balanceOf.Outputs[0].Type.String() != "uint256" {
+		t.Errorf("Unexpected balanceOf %+v %v", balanceOf, err)
+	}
+
+	approve, err := definition.Method("approve")
+	if err != nil || approve.IsConstant() || approve.Signature() != "approve(address,uint256)" {
+		t.Errorf("Unexpected approve %+v %v", approve, err)
+	}
+
+}
+
+func TestParseJSONErrors(t *testing.T) {
+
+	invalid := []string{
+		`{"type":"function"}`,
+		`[{"type":"function","name":"f","inputs":[{"name":"a","type":"uint7"}]}]`,
+		`[{"type":"function","name":"f","inputs":[{"name":"a","type":"bytes33"}]}]`,
+		`[{"type":"function","name":"f","inputs":[{"name":"a","type":"uint256[0]"}]}]`,
+		`[{"type":"function","name":"f","inputs":[{"name":"a","type":"tuple"}]}]`,
+		`[{"type":"function","inputs":[]}]`,
+		`[{"type":"modifier","name":"onlyOwner"}]`,
+		`[{"type":"function","name":"f","inputs":[]},{"type":"function","name":"f","inputs":[]}]`,
+		`[{"type":"function","name":"f","inputs":{"name":"a"}}]`,
+	}
+
+	for _, definition := range invalid {
+		if _, err := abi.ParseJSON([]byte(definition)); err == nil {
+			t.Errorf("Expected an error for %s", definition)
+		}
+	}
+
+}
+
+func TestNewType(t *testing.T) {
+
+	cases := map[string]string{
+		"uint":             "uint256",
+		"int8":             "int8",
+		"fixed":            "fixed128x18",
+		"ufixed64x10":      "ufixed64x10",
+		"bytes1[][3]":      "bytes1[][3]",
+		"address[2][]":     "address[2][]",
+		"function":         "function",
+		" string ":         "string",
+		"bool[4][5][6][7]": "bool[4][5][6][7]",
+	}
+
+	for name, expected := range cases {
+		parsed, err := abi.NewType(name)
+		if err != nil || parsed.String() != expected {
+			t.Errorf("%q: [Expected %s | Got %s %v]", name, expected, parsed.String(), err)
+		}
+	}
+
+	// the last brackets are the outer array
+	parsed, _ := abi.NewType("uint8[2][]")
+	if parsed.Kind != abi.SliceKind || parsed.Elem.Kind != abi.ArrayKind || parsed.Elem.Size != 2 {
+		t.Errorf("Unexpected type %+v", parsed)
+	}
+
+	if _, err := abi.NewType("uint8[2"); err == nil {
+		t.Error("Expected an error for an unclosed array")
+	}
+
+}
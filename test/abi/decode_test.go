@@ -0,0 +1,276 @@
+/********************************************************************************
+   This file is part of go-web3.
+   go-web3 is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-web3 is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-web3.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file decode_test.go
+ */
+package test
+
+import (
+	"encoding/hex"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func decodeHex(t *testing.T, encoded string) []byte {
+
+	data, err := hex.DecodeString(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return data
+}
+
+func TestUnpackSpecificationExamples(t *testing.T) {
+
+	// the arguments of f(uint256,uint32[],bytes10,bytes) of the Solidity ABI specification
+	method := newMethod(t, `[{"name":"a","type":"uint"},{"name":"b","type":"uint32[]"},{"name":"c","type":"bytes10"},{"name":"d","type":"bytes"}]`)
+
+	values, err := method.Inputs.Unpack(decodeHex(t, words(
+		"0000000000000000000000000000000000000000000000000000000000000123",
+		"0000000000000000000000000000000000000000000000000000000000000080",
+		"3132333435363738393000000000000000000000000000000000000000000000",
+		"00000000000000000000000000000000000000000000000000000000000000e0",
+		"0000000000000000000000000000000000000000000000000000000000000002",
+		"0000000000000000000000000000000000000000000000000000000000000456",
+		"0000000000000000000000000000000000000000000000000000000000000789",
+		"000000000000000000000000000000000000000000000000000000000000000d",
+		"48656c6c6f2c20776f726c642100000000000000000000000000000000000000",
+	)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tenBytes [10]byte
+	copy(tenBytes[:], "1234567890")
+
+	expected := []interface{}{
+		big.NewInt(0x123),
+		[]*big.Int{big.NewInt(0x456), big.NewInt(0x789)},
+		tenBytes,
+		[]byte("Hello, world!"),
+	}
+
+	if !reflect.DeepEqual(values, expected) {
+		t.Errorf("[Expected %v | Got %v]", expected, values)
+	}
+
+	// g(uint256[][],string[]), into a struct
+	method = newMethod(t, `[{"name":"numbers","type":"uint256[][]"},{"name":"words","type":"string[]"}]`)
+
+	var decoded struct {
+		Numbers [][]uint64
+		Words   []string
+	}
+
+	err = method.Inputs.UnpackInto(&decoded, decodeHex(t, words(
+		"0000000000000000000000000000000000000000000000000000000000000040",
+		"0000000000000000000000000000000000000000000000000000000000000140",
+		"0000000000000000000000000000000000000000000000000000000000000002",
+		"0000000000000000000000000000000000000000000000000000000000000040",
+		"00000000000000000000000000000000000000000000000000000000000000a0",
+		"0000000000000000000000000000000000000000000000000000000000000002",
+		"0000000000000000000000000000000000000000000000000000000000000001",
+		"0000000000000000000000000000000000000000000000000000000000000002",
+		"0000000000000000000000000000000000000000000000000000000000000001",
+		"0000000000000000000000000000000000000000000000000000000000000003",
+		"0000000000000000000000000000000000000000000000000000000000000003",
+		"0000000000000000000000000000000000000000000000000000000000000060",
+		"00000000000000000000000000000000000000000000000000000000000000a0",
+		"00000000000000000000000000000000000000000000000000000000000000e0",
+		"0000000000000000000000000000000000000000000000000000000000000003",
+		"6f6e650000000000000000000000000000000000000000000000000000000000",
+		"0000000000000000000000000000000000000000000000000000000000000003",
+		"74776f0000000000000000000000000000000000000000000000000000000000",
+		"0000000000000000000000000000000000000000000000000000000000000005",
+		"7468726565000000000000000000000000000000000000000000000000000000",
+	)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(decoded.Numbers, [][]uint64{{1, 2}, {3}}) || !reflect.DeepEqual(decoded.Words, []string{"one", "two", "three"}) {
+		t.Errorf("Unexpected decoded values %v", decoded)
+	}
+
+}
+
+func TestUnpackTuplesRoundTrip(t *testing.T) {
+
+	type Fee struct {
+		Rate      uint16
+		Recipient [20]byte `abi:"to"`
+	}
+
+	type Order struct {
+		Maker [20]byte
+		Note  string
+		Fee   Fee
+	}
+
+	method := newMethod(t, `[
+		{"name":"orders","type":"tuple[]","components":[
+			{"name":"maker","type":"address"},
+			{"name":"note","type":"string"},
+			{"name":"fee","type":"tuple","components":[{"name":"rate","type":"uint16"},{"name":"to","type":"address"}]}
+		]},
+		{"name":"delta","type":"int8"}
+	]`)
+
+	orders := []Order{
+		{Maker: [20]byte{19: 0xaa}, Note: "first", Fee: Fee{Rate: 3, Recipient: [20]byte{19: 0xbb}}},
+		{Maker: [20]byte{0: 0x01}, Note: "", Fee: Fee{Rate: 65535}},
+	}
+
+	data, err := method.Inputs.Pack(orders, -128)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// multiple values into the fields of a struct
+	var decoded struct {
+		Orders []Order
+		Delta  int8 `abi:"delta"`
+	}
+
+	if err := method.Inputs.UnpackInto(&decoded, data); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(decoded.Orders, orders) || decoded.Delta != -128 {
+		t.Errorf("[Expected %v | Got %v]", orders, decoded)
+	}
+
+	// the tuples into generated structs
+	values, err := method.Inputs.Unpack(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first := reflect.ValueOf(values[0]).Index(0)
+	if note := first.FieldByName("Note").Interface(); note != "first" {
+		t.Errorf("Unexpected note %v", note)
+	}
+	if rate := first.FieldByName("Fee").FieldByName("Rate").Interface().(*big.Int); rate.Int64() != 3 {
+		t.Errorf("Unexpected rate %v", rate)
+	}
+	if delta := values[1].(*big.Int); delta.Int64() != -128 {
+		t.Errorf("Unexpected delta %v", delta)
+	}
+
+	// a single value into a []interface{}
+	single := newMethod(t, `[{"name":"fee","type":"tuple","components":[{"name":"rate","type":"uint16"},{"name":"to","type":"address"}]}]`)
+
+	data, err = single.Inputs.Pack(Fee{Rate: 7, Recipient: [20]byte{19: 0xbb}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var fields []interface{}
+	if err := single.Inputs.UnpackInto(&fields, data); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(fields) != 2 || fields[0].(*big.Int).Int64() != 7 || fields[1].([20]byte)[19] != 0xbb {
+		t.Errorf("Unexpected fields %v", fields)
+	}
+
+	var recipient string
+	if err := newMethod(t, `[{"name":"to","type":"address"}]`).Inputs.UnpackInto(&recipient, data[32:]); err != nil || recipient != "0x00000000000000000000000000000000000000bb" {
+		t.Errorf("Unexpected recipient %q: %v", recipient, err)
+	}
+
+}
+
+func TestUnpackDuplicateFieldNames(t *testing.T) {
+
+	// the fallback name of the unnamed component is taken by another one
+	method := newMethod(t, `[
+		{"name":"pair","type":"tuple","components":[
+			{"name":"field1","type":"uint256"},
+			{"name":"","type":"uint256"},
+			{"name":"field1_1","type":"uint256"},
+			{"name":"_field1","type":"uint256"}
+		]}
+	]`)
+
+	data, err := method.Inputs.Pack([]interface{}{1, 2, 3, 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	values, err := method.Inputs.Unpack(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pair := reflect.ValueOf(values[0])
+	for index := 0; index < pair.NumField(); index++ {
+		if value := pair.Field(index).Interface().(*big.Int); value.Int64() != int64(index+1) {
+			t.Errorf("Unexpected field %s %v", pair.Type().Field(index).Name, value)
+		}
+	}
+
+}
+
+func TestUnpackErrors(t *testing.T) {
+
+	word := func(last string) string {
+		return strings.Repeat("0", 64-len(last)) + last
+	}
+
+	cases := []struct {
+		outputs string
+		data    string
+	}{
+		// too short
+		{`[{"name":"a","type":"uint256"}]`, ""},
+		{`[{"name":"a","type":"uint256"},{"name":"b","type":"bool"}]`, word("01")},
+		{`[{"name":"a","type":"string"}]`, word("20") + word("40")},
+		{`[{"name":"a","type":"uint256[]"}]`, word("20") + word("03") + word("01")},
+		// malformed
+		{`[{"name":"a","type":"bool"}]`, word("02")},
+		{`[{"name":"a","type":"uint8"}]`, word("0100")},
+		{`[{"name":"a","type":"int8"}]`, word("80")},
+		{`[{"name":"a","type":"address"}]`, word("01" + "0000000000000000000000000000000000000000")},
+		{`[{"name":"a","type":"bytes2"}]`, "616263" + word("")[6:]},
+		{`[{"name":"a","type":"bytes"}]`, word("ff")},
+		{`[{"name":"a","type":"uint256[]"}]`, word("20") + "ff" + word("")[2:]},
+	}
+
+	for _, test := range cases {
+		if values, err := newMethod(t, test.outputs).Inputs.Unpack(decodeHex(t, test.data)); err == nil {
+			t.Errorf("%s %s: expected an error, got %v", test.outputs, test.data, values)
+		}
+	}
+
+	// values which don't fit their Go type
+	var small int8
+	if err := newMethod(t, `[{"name":"a","type":"uint256"}]`).Inputs.UnpackInto(&small, decodeHex(t, word("80"))); err == nil {
+		t.Error("Expected an overflow of int8")
+	}
+
+	var flag bool
+	if err := newMethod(t, `[{"name":"a","type":"uint256"}]`).Inputs.UnpackInto(&flag, decodeHex(t, word("01"))); err == nil {
+		t.Error("Expected an error decoding an integer into a bool")
+	}
+
+	if err := newMethod(t, `[{"name":"a","type":"uint256"}]`).Inputs.UnpackInto(flag, decodeHex(t, word("01"))); err == nil {
+		t.Error("Expected an error decoding into a value which isn't a pointer")
+	}
+
+}
@@ -0,0 +1,236 @@
+/********************************************************************************
+   This file is part of go-web3.
+   go-web3 is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-web3 is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-web3.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file encode_test.go
+ */
+package test
+
+import (
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/cellcycle/go-web3/abi"
+)
+
+// words joins the hex words of an expected encoding
+func words(parts ...string) string {
+	return strings.Join(parts, "")
+}
+
+// newMethod parses the function of a one entry JSON ABI
+func newMethod(t *testing.T, inputs string) *abi.Method {
+
+	definition, err := abi.ParseJSON([]byte(`[{"type":"function","name":"f","inputs":` + inputs + `}]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return definition.Methods[0]
+}
+
+func TestPackSpecificationExamples(t *testing.T) {
+
+	// the examples of the Solidity ABI specification
+	cases := []struct {
+		definition string
+		values     []interface{}
+		expected   string
+	}{
+		{
+			`[{"type":"function","name":"baz","inputs":[{"name":"x","type":"uint32"},{"name":"y","type":"bool"}]}]`,
+			[]interface{}{69, true},
+			words(
+				"cdcd77c0",
+				"0000000000000000000000000000000000000000000000000000000000000045",
+				"0000000000000000000000000000000000000000000000000000000000000001",
+			),
+		},
+		{
+			`[{"type":"function","name":"bar","inputs":[{"name":"x","type":"bytes3[2]"}]}]`,
+			[]interface{}{[][3]byte{{'a', 'b', 'c'}, {'d', 'e', 'f'}}},
+			words(
+				"fce353f6",
+				"6162630000000000000000000000000000000000000000000000000000000000",
+				"6465660000000000000000000000000000000000000000000000000000000000",
+			),
+		},
+		{
+			`[{"type":"function","name":"sam","inputs":[{"name":"a","type":"bytes"},{"name":"b","type":"bool"},{"name":"c","type":"uint256[]"}]}]`,
+			[]interface{}{[]byte("dave"), true, []*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(3)}},
+			words(
+				"a5643bf2",
+				"0000000000000000000000000000000000000000000000000000000000000060",
+				"0000000000000000000000000000000000000000000000000000000000000001",
+				"00000000000000000000000000000000000000000000000000000000000000a0",
+				"0000000000000000000000000000000000000000000000000000000000000004",
+				"6461766500000000000000000000000000000000000000000000000000000000",
+				"0000000000000000000000000000000000000000000000000000000000000003",
+				"0000000000000000000000000000000000000000000000000000000000000001",
+				"0000000000000000000000000000000000000000000000000000000000000002",
+				"0000000000000000000000000000000000000000000000000000000000000003",
+			),
+		},
+		{
+			`[{"type":"function","name":"f","inputs":[{"name":"a","type":"uint"},{"name":"b","type":"uint32[]"},{"name":"c","type":"bytes10"},{"name":"d","type":"bytes"}]}]`,
+			[]interface{}{big.NewInt(0x123), []uint32{0x456, 0x789}, []byte("1234567890"), []byte("Hello, world!")},
+			words(
+				"8be65246",
+				"0000000000000000000000000000000000000000000000000000000000000123",
+				"0000000000000000000000000000000000000000000000000000000000000080",
+				"3132333435363738393000000000000000000000000000000000000000000000",
+				"00000000000000000000000000000000000000000000000000000000000000e0",
+				"0000000000000000000000000000000000000000000000000000000000000002",
+				"0000000000000000000000000000000000000000000000000000000000000456",
+				"0000000000000000000000000000000000000000000000000000000000000789",
+				"000000000000000000000000000000000000000000000000000000000000000d",
+				"48656c6c6f2c20776f726c642100000000000000000000000000000000000000",
+			),
+		},
+		{
+			`[{"type":"function","name":"g","inputs":[{"name":"a","type":"uint256[][]"},{"name":"b","type":"string[]"}]}]`,
+			[]interface{}{[][]int{{1, 2}, {3}}, []string{"one", "two", "three"}},
+			words(
+				"2289b18c",
+				"0000000000000000000000000000000000000000000000000000000000000040",
+				"0000000000000000000000000000000000000000000000000000000000000140",
+				"0000000000000000000000000000000000000000000000000000000000000002",
+				"0000000000000000000000000000000000000000000000000000000000000040",
+				"00000000000000000000000000000000000000000000000000000000000000a0",
+				"0000000000000000000000000000000000000000000000000000000000000002",
+				"0000000000000000000000000000000000000000000000000000000000000001",
+				"0000000000000000000000000000000000000000000000000000000000000002",
+				"0000000000000000000000000000000000000000000000000000000000000001",
+				"0000000000000000000000000000000000000000000000000000000000000003",
+				"0000000000000000000000000000000000000000000000000000000000000003",
+				"0000000000000000000000000000000000000000000000000000000000000060",
+				"00000000000000000000000000000000000000000000000000000000000000a0",
+				"00000000000000000000000000000000000000000000000000000000000000e0",
+				"0000000000000000000000000000000000000000000000000000000000000003",
+				"6f6e650000000000000000000000000000000000000000000000000000000000",
+				"0000000000000000000000000000000000000000000000000000000000000003",
+				"74776f0000000000000000000000000000000000000000000000000000000000",
+				"0000000000000000000000000000000000000000000000000000000000000005",
+				"7468726565000000000000000000000000000000000000000000000000000000",
+			),
+		},
+	}
+
+	for _, test := range cases {
+
+		definition, err := abi.ParseJSON([]byte(test.definition))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		data, err := definition.Methods[0].Pack(test.values...)
+		if err != nil {
+			t.Errorf("%s: %v", definition.Methods[0].Signature(), err)
+			continue
+		}
+
+		if encoded := hex.EncodeToString(data); encoded != test.expected {
+			t.Errorf("%s:\n[Expected %s\n | Got    %s]", definition.Methods[0].Signature(), test.expected, encoded)
+		}
+	}
+
+}
+
+func TestPackTuplesAndSignedIntegers(t *testing.T) {
+
+	type Fee struct {
+		Rate      uint16
+		Recipient string `abi:"to"`
+	}
+
+	type Order struct {
+		Maker string
+		Note  string
+		Fee   Fee
+	}
+
+	method := newMethod(t, `[
+		{"name":"order","type":"tuple","components":[
+			{"name":"maker","type":"address"},
+			{"name":"note","type":"string"},
+			{"name":"fee","type":"tuple","components":[{"name":"rate","type":"uint16"},{"name":"to","type":"address"}]}
+		]},
+		{"name":"delta","type":"int8"}
+	]`)
+
+	order := Order{Maker: "0x00000000000000000000000000000000000000aa", Note: "x", Fee: Fee{Rate: 3, Recipient: "0x00000000000000000000000000000000000000bb"}}
+
+	expected := words(
+		"0000000000000000000000000000000000000000000000000000000000000040",
+		"ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff80",
+		"00000000000000000000000000000000000000000000000000000000000000aa",
+		"0000000000000000000000000000000000000000000000000000000000000080",
+		"0000000000000000000000000000000000000000000000000000000000000003",
+		"00000000000000000000000000000000000000000000000000000000000000bb",
+		"0000000000000000000000000000000000000000000000000000000000000001",
+		"7800000000000000000000000000000000000000000000000000000000000000",
+	)
+
+	for _, value := range []interface{}{
+		order,
+		&order,
+		map[string]interface{}{"maker": order.Maker, "note": "x", "fee": []interface{}{3, order.Fee.Recipient}},
+	} {
+		data, err := method.Inputs.Pack(value, int8(-128))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if encoded := hex.EncodeToString(data); encoded != expected {
+			t.Errorf("%T:\n[Expected %s\n | Got    %s]", value, expected, encoded)
+		}
+	}
+
+}
+
+func TestPackErrors(t *testing.T) {
+
+	cases := []struct {
+		inputs string
+		value  interface{}
+	}{
+		{`[{"name":"a","type":"uint8"}]`, 256},
+		{`[{"name":"a","type":"uint256"}]`, -1},
+		{`[{"name":"a","type":"int8"}]`, 128},
+		{`[{"name":"a","type":"int8"}]`, big.NewInt(-129)},
+		{`[{"name":"a","type":"uint256"}]`, new(big.Int).Lsh(big.NewInt(1), 256)},
+		{`[{"name":"a","type":"uint256"}]`, "10"},
+		{`[{"name":"a","type":"bool"}]`, 1},
+		{`[{"name":"a","type":"address"}]`, "0x1234"},
+		{`[{"name":"a","type":"address"}]`, "00000000000000000000000000000000000000aa"},
+		{`[{"name":"a","type":"bytes3"}]`, []byte("abcd")},
+		{`[{"name":"a","type":"bytes3"}]`, []byte("ab")},
+		{`[{"name":"a","type":"bytes"}]`, "0xzz"},
+		{`[{"name":"a","type":"uint8[2]"}]`, []int{1, 2, 3}},
+		{`[{"name":"a","type":"string"}]`, nil},
+		{`[{"name":"a","type":"tuple","components":[{"name":"b","type":"bool"}]}]`, struct{ C bool }{}},
+	}
+
+	for _, test := range cases {
+		if data, err := newMethod(t, test.inputs).Pack(test.value); err == nil {
+			t.Errorf("%s %v: expected an error, got %x", test.inputs, test.value, data)
+		}
+	}
+
+	if _, err := newMethod(t, `[{"name":"a","type":"bool"}]`).Pack(); err == nil {
+		t.Error("Expected an error for a missing value")
+	}
+
+}
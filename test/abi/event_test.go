@@ -0,0 +1,168 @@
+/********************************************************************************
+   This file is part of go-web3.
+   go-web3 is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-web3 is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-web3.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file event_test.go
+ */
+package test
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/cellcycle/go-web3/abi"
+)
+
+const eventsABI = `[
+	{"type":"event","name":"Transfer","inputs":[
+		{"name":"from","type":"address","indexed":true},
+		{"name":"to","type":"address","indexed":true},
+		{"name":"value","type":"uint256","indexed":false}
+	]},
+	{"type":"event","name":"Tagged","inputs":[
+		{"name":"tag","type":"string","indexed":true},
+		{"name":"ids","type":"uint256[]","indexed":true},
+		{"name":"note","type":"string","indexed":false}
+	]}
+]`
+
+func topicOf(t *testing.T, encoded string) [32]byte {
+
+	var topic [32]byte
+	copy(topic[:], decodeHex(t, encoded))
+	return topic
+}
+
+func TestEventLogs(t *testing.T) {
+
+	definition, err := abi.ParseJSON([]byte(eventsABI))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	transfer, _ := definition.Event("Transfer")
+
+	if topic := transfer.Topic(); hex.EncodeToString(topic[:]) != "ddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef" {
+		t.Errorf("Unexpected topic %x", topic)
+	}
+
+	topics := [][32]byte{
+		transfer.Topic(),
+		topicOf(t, "00000000000000000000000000000000000000000000000000000000000000aa"),
+		topicOf(t, "00000000000000000000000000000000000000000000000000000000000000bb"),
+	}
+	data := decodeHex(t, "00000000000000000000000000000000000000000000000000000000000003e8")
+
+	values, err := transfer.UnpackLog(topics, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if values[0].([20]byte)[19] != 0xaa || values[1].([20]byte)[19] != 0xbb || values[2].(*big.Int).Int64() != 1000 {
+		t.Errorf("Unexpected values %v", values)
+	}
+
+	var decoded struct {
+		From  string
+		To    [20]byte
+		Value uint64
+	}
+
+	if err := transfer.UnpackLogInto(&decoded, topics, data); err != nil {
+		t.Fatal(err)
+	}
+
+	if decoded.From != "0x00000000000000000000000000000000000000aa" || decoded.To[19] != 0xbb || decoded.Value != 1000 {
+		t.Errorf("Unexpected decoded log %+v", decoded)
+	}
+
+	if _, err := transfer.UnpackLog(topics[:2], data); err == nil {
+		t.Error("Expected an error for a missing topic")
+	}
+
+	if _, err := transfer.UnpackLog(topics, data[:31]); err == nil {
+		t.Error("Expected an error for short data")
+	}
+
+	tagged, _ := definition.Event("Tagged")
+	if _, err := tagged.UnpackLog(topics, data); err == nil {
+		t.Error("Expected an error for the log of another event")
+	}
+
+}
+
+func TestEventTopics(t *testing.T) {
+
+	definition, err := abi.ParseJSON([]byte(eventsABI))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	transfer, _ := definition.Event("Transfer")
+
+	topics, err := transfer.Topics(nil, "0x00000000000000000000000000000000000000bb")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(topics) != 3 || topics[0][0] != transfer.Topic() || len(topics[1]) != 0 || topics[2][0][31] != 0xbb {
+		t.Errorf("Unexpected topics %x", topics)
+	}
+
+	// the trailing wildcards are left out
+	if topics, err := transfer.Topics(nil, nil); err != nil || len(topics) != 1 {
+		t.Errorf("Unexpected topics %x: %v", topics, err)
+	}
+
+	if _, err := transfer.Topics(nil, nil, 1); err == nil {
+		t.Error("Expected an error for a value of a field which isn't indexed")
+	}
+
+	// the dynamic indexed fields are hashed
+	tagged, _ := definition.Event("Tagged")
+
+	topics, err = tagged.Topics("hello", []int{1, 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if hex.EncodeToString(topics[1][0][:]) != "1c8aff950685c2ed4bc3174f3472287b56d9517b9c948127319a09a7a36deac8" {
+		t.Errorf("Unexpected topic of a string %x", topics[1][0])
+	}
+
+	bytesType, _ := abi.NewType("bytes")
+	expected, _ := abi.EncodeTopic(bytesType, decodeHex(t, words(
+		"0000000000000000000000000000000000000000000000000000000000000001",
+		"0000000000000000000000000000000000000000000000000000000000000002",
+	)))
+
+	if topics[2][0] != expected {
+		t.Errorf("Unexpected topic of an array [Expected %x | Got %x]", expected, topics[2][0])
+	}
+
+	values, err := tagged.UnpackLog([][32]byte{tagged.Topic(), topics[1][0], topics[2][0]}, decodeHex(t, words(
+		"0000000000000000000000000000000000000000000000000000000000000020",
+		"0000000000000000000000000000000000000000000000000000000000000002",
+		"6869000000000000000000000000000000000000000000000000000000000000",
+	)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if values[0].([32]byte) != topics[1][0] || values[2].(string) != "hi" {
+		t.Errorf("Unexpected values %v", values)
+	}
+
+}
@@ -0,0 +1,163 @@
+/********************************************************************************
+   This file is part of go-web3.
+   go-web3 is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-web3 is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-web3.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file human_test.go
+ */
+package test
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/cellcycle/go-web3/abi"
+)
+
+// marketDeclarations - the human-readable ABI of marketABI
+var marketDeclarations = []string{
+	"struct Fee { uint16 rate; address recipient; }",
+	"struct Order { address maker; uint256[2] amounts; Fee fee; }",
+	"constructor(address owner)",
+	"function transfer(address to, uint256 amount) returns (bool)",
+	"function transfer(address to, uint256 amount, bytes data) returns (bool)",
+	"function place(Order[] orders) payable",
+	"event Transfer(address indexed from, address indexed to, uint256 value)",
+	"error InsufficientBalance(uint256 available, uint256 required)",
+	"receive() external payable",
+}
+
+func marshal(t *testing.T, definition *abi.ABI) string {
+
+	encoded, err := json.Marshal(definition)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(encoded)
+}
+
+func TestParseHumanReadable(t *testing.T) {
+
+	fromJSON, err := abi.ParseJSON([]byte(marketABI))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fromHuman, err := abi.ParseHumanReadable(marketDeclarations...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// both parsers build the same model, rendered to the same JSON
+	if expected, got := marshal(t, fromJSON), marshal(t, fromHuman); expected != got {
+		t.Errorf("[Expected %s\n | Got    %s]", expected, got)
+	}
+
+	if !reflect.DeepEqual(fromJSON.HumanReadable(), marketDeclarations) {
+		t.Errorf("Unexpected declarations:\n%s", strings.Join(fromJSON.HumanReadable(), "\n"))
+	}
+
+	// the rendered JSON parses back to the same ABI
+	reparsed, err := abi.ParseJSON([]byte(marshal(t, fromHuman)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(reparsed, fromHuman) {
+		t.Errorf("The rendered JSON ABI doesn't parse back to the same ABI")
+	}
+
+	place, _ := fromHuman.Method("place")
+	if place.Signature() != "place((address,uint256[2],(uint16,address))[])" || place.Inputs[0].Type.Elem.TupleName != "Order" {
+		t.Errorf("Unexpected function %s", place.Signature())
+	}
+
+}
+
+func TestParseHumanReadableSyntax(t *testing.T) {
+
+	definition, err := abi.ParseHumanReadable(`
+		function balanceOf(address) external view returns (uint256);
+		function name() public constant returns (string memory)
+		function swap(tuple(address token, uint256 amount)[2] calldata legs, address payable to) payable returns (uint256 out)
+		event Log(string indexed tag, bytes data) anonymous
+		fallback()
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	balanceOf, err := definition.Method("balanceOf")
+	if err != nil || !balanceOf.IsConstant() || balanceOf.Inputs[0].Name != "" || balanceOf.Outputs[0].Type.String() != "uint256" {
+		t.Errorf("Unexpected balanceOf %+v %v", balanceOf, err)
+	}
+
+	if name, _ := definition.Method("name"); name == nil || name.StateMutability != abi.View {
+		t.Errorf("Unexpected name %+v", name)
+	}
+
+	swap, err := definition.Method("swap")
+	if err != nil || swap.Signature() != "swap((address,uint256)[2],address)" || !swap.IsPayable() || swap.Outputs[0].Name != "out" {
+		t.Errorf("Unexpected swap %+v %v", swap, err)
+	}
+
+	if event, _ := definition.Event("Log"); event == nil || !event.Anonymous || !event.Inputs[0].Indexed || event.Inputs[1].Indexed {
+		t.Errorf("Unexpected event %+v", event)
+	}
+
+	if definition.Fallback == nil || definition.Fallback.StateMutability != abi.NonPayable {
+		t.Errorf("Unexpected fallback %+v", definition.Fallback)
+	}
+
+	expected := []string{
+		"function balanceOf(address) view returns (uint256)",
+		"function name() view returns (string)",
+		"function swap(tuple(address token, uint256 amount)[2] legs, address to) payable returns (uint256 out)",
+		"event Log(string indexed tag, bytes data) anonymous",
+		"fallback()",
+	}
+
+	if got := definition.HumanReadable(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Unexpected declarations:\n%s", strings.Join(got, "\n"))
+	}
+
+}
+
+func TestParseHumanReadableErrors(t *testing.T) {
+
+	invalid := []string{
+		"transfer(address to)",
+		"function transfer(address to",
+		"function f(uint7 a)",
+		"function f(Missing a)",
+		"function f(uint256 indexed a)",
+		"function f() returns",
+		"function f() view returns (bool) anonymous",
+		"event E(uint256 a) returns (bool)",
+		"struct A { B b; } struct B { A a; } function f(A a)",
+		"struct A { uint256; }",
+		"struct A { uint256 a; } struct A { uint256 b; }",
+		"function f(); function f()",
+		"function f(uint256 a) = 1",
+	}
+
+	for _, declaration := range invalid {
+		if _, err := abi.ParseHumanReadable(declaration); err == nil {
+			t.Errorf("Expected an error for %s", declaration)
+		}
+	}
+
+}
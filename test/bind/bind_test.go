@@ -0,0 +1,315 @@
+/********************************************************************************
+   This file is part of go-web3.
+   go-web3 is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-web3 is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-web3.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file bind_test.go
+ */
+
+package test
+
+import (
+	"context"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	web3 "github.com/cellcycle/go-web3"
+	"github.com/cellcycle/go-web3/abi/bind"
+	"github.com/cellcycle/go-web3/dto"
+	"github.com/cellcycle/go-web3/providers"
+	"github.com/cellcycle/go-web3/test/bind/market"
+	"github.com/cellcycle/go-web3/test/bind/simpletoken"
+	"github.com/cellcycle/go-web3/test/helpers"
+)
+
+// updateEnv - the environment variable writing the golden files again, e.g.
+// WEB3_UPDATE_GOLDEN=1 go test ./test/bind/
+const updateEnv = "WEB3_UPDATE_GOLDEN"
+
+const marketAddress = "0x00000000000000000000000000000000000000cc"
+
+func TestGenerateGolden(t *testing.T) {
+
+	tests := []struct {
+		artifact string
+		pkg      string
+	}{
+		{"simple-token.json", "simpletoken"},
+		{"market.json", "market"},
+	}
+
+	for _, test := range tests {
+
+		content, err := ioutil.ReadFile(filepath.Join("..", "resources", test.artifact))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		artifact, err := bind.ReadArtifact(content)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		code, err := bind.Generate(bind.Options{
+			Package:  test.pkg,
+			Type:     artifact.Name,
+			ABI:      artifact.ABI,
+			Bytecode: artifact.Bytecode,
+			Source:   test.artifact,
+		})
+		if err != nil {
+			t.Fatalf("%s: %v", test.artifact, err)
+		}
+
+		golden := filepath.Join(test.pkg, test.pkg+".go")
+
+		if os.Getenv(updateEnv) != "" {
+			if err := ioutil.WriteFile(golden, code, 0644); err != nil {
+				t.Fatal(err)
+			}
+			continue
+		}
+
+		expected, err := ioutil.ReadFile(golden)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if string(code) != string(expected) {
+			t.Errorf("%s differs from the generated code, run the tests with %s=1 to write it again", golden, updateEnv)
+		}
+	}
+
+}
+
+func TestReadArtifact(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		content  string
+		abi      string
+		bytecode string
+	}{
+		{"abi", `[{"type":"receive"}]`, `[{"type":"receive"}]`, ""},
+		{"truffle", `{"contractName":"A","abi":"[]","bytecode":"0x6080"}`, "[]", "0x6080"},
+		{"hardhat", `{"contractName":"A","abi":[],"bytecode":"0x6080"}`, "[]", "0x6080"},
+		{"solc", `{"contractName":"A","abi":[],"bytecode":{"object":"6080"}}`, "[]", "0x6080"},
+		{"interface", `{"contractName":"A","abi":[],"bytecode":"0x"}`, "[]", ""},
+	}
+
+	for _, test := range tests {
+
+		artifact, err := bind.ReadArtifact([]byte(test.content))
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+
+		if artifact.ABI != test.abi || artifact.Bytecode != test.bytecode {
+			t.Errorf("%s: unexpected artifact %+v", test.name, artifact)
+		}
+	}
+
+	for _, content := range []string{`{"contractName":"A"}`, `{"abi":`} {
+		if _, err := bind.ReadArtifact([]byte(content)); err == nil {
+			t.Errorf("Expected an error for %s", content)
+		}
+	}
+
+}
+
+func TestGenerateErrors(t *testing.T) {
+
+	tests := []bind.Options{
+		{Package: "token", Type: "token", ABI: "[]"},
+		{Package: "my-token", Type: "Token", ABI: "[]"},
+		{Package: "token", Type: "Token", ABI: "{"},
+		{Package: "token", Type: "Token", ABI: "[]", Bytecode: "0xzz"},
+	}
+
+	for _, test := range tests {
+		if _, err := bind.Generate(test); err == nil {
+			t.Errorf("Expected an error for %+v", test)
+		}
+	}
+
+}
+
+func TestGenerateNames(t *testing.T) {
+
+	code, err := bind.Generate(bind.Options{Package: "names", Type: "Names", ABI: `[
+		{"type":"function","name":"from","inputs":[{"name":"","type":"uint8"},{"name":"_type","type":"int24"}],"outputs":[],"stateMutability":"nonpayable"},
+		{"type":"function","name":"_from","inputs":[{"name":"string","type":"string"}],"outputs":[],"stateMutability":"nonpayable"},
+		{"type":"event","name":"Moved","inputs":[{"name":"raw","type":"bytes","indexed":true}]}
+	]`})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, expected := range []string{
+		"func (binding *Names) From0(ctx context.Context, transaction *dto.TransactionParameters, arg0 uint8, typeArg *big.Int) (string, error)",
+		"func (binding *Names) From1(ctx context.Context, transaction *dto.TransactionParameters, stringArg string) (string, error)",
+		"Raw    [32]byte             `abi:\"raw\"`",
+		"RawLog *dto.TransactionLogs `abi:\"-\"`",
+	} {
+		if !strings.Contains(string(code), expected) {
+			t.Errorf("Expected %s in\n%s", expected, code)
+		}
+	}
+
+}
+
+// collidingABI - names colliding once made Go names, in a parameter list, in the
+// outputs, in an event and in tuples differing only by a nested component name
+const collidingABI = `[
+	{"type":"function","name":"pair","inputs":[{"name":"arg1","type":"uint256"},{"name":"","type":"uint256"}],"outputs":[{"name":"field","type":"uint256"},{"name":"_field","type":"uint256"}],"stateMutability":"view"},
+	{"type":"function","name":"place","inputs":[{"name":"order","type":"tuple","components":[{"name":"id","type":"uint256"},{"name":"_id","type":"uint256"},{"name":"fee","type":"tuple","components":[{"name":"rate","type":"uint16"}]}]}],"outputs":[],"stateMutability":"nonpayable"},
+	{"type":"function","name":"cancel","inputs":[{"name":"order","type":"tuple","components":[{"name":"id","type":"uint256"},{"name":"_id","type":"uint256"},{"name":"fee","type":"tuple","components":[{"name":"bps","type":"uint16"}]}]}],"outputs":[],"stateMutability":"nonpayable"},
+	{"type":"event","name":"Transfer","inputs":[{"name":"from","type":"address","indexed":true},{"name":"_from","type":"address","indexed":true},{"name":"raw","type":"uint256"},{"name":"rawLog","type":"uint256"}]}
+]`
+
+func TestGenerateCollidingNames(t *testing.T) {
+
+	code, err := bind.Generate(bind.Options{Package: "colliding", Type: "Colliding", ABI: collidingABI})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, expected := range []string{
+		"func (binding *Colliding) Pair(ctx context.Context, arg1 *big.Int, arg10 *big.Int) (CollidingPairOutput, error)",
+		"Field0 *big.Int `abi:\"_field\"`",
+		"Id0 *big.Int               `abi:\"_id\"`",
+		"From0   string               `abi:\"_from\"`",
+		"RawLog0 *dto.TransactionLogs `abi:\"-\"`",
+		"Rate uint16 `abi:\"rate\"`",
+		"Bps uint16 `abi:\"bps\"`",
+	} {
+		if !strings.Contains(string(code), expected) {
+			t.Errorf("Expected %s in\n%s", expected, code)
+		}
+	}
+
+	// the generated package is built and vetted within the module
+	goCommand, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go command not found")
+	}
+
+	directory, err := ioutil.TempDir(".", "colliding")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(directory)
+
+	if err := ioutil.WriteFile(filepath.Join(directory, "colliding.go"), code, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if output, err := exec.Command(goCommand, "vet", "./"+directory).CombinedOutput(); err != nil {
+		t.Errorf("Invalid generated code: %v\n%s\n%s", err, output, code)
+	}
+
+}
+
+func TestSimpleTokenBinding(t *testing.T) {
+
+	mock := providers.NewMockProvider()
+	mock.On("eth_call").Return("0x00000000000000000000000000000000000000000000000000000000000003e8")
+	mock.On("eth_sendTransaction").Return("0x0000000000000000000000000000000000000000000000000000000000000001")
+
+	token, err := simpletoken.NewSimpleToken(web3.NewWeb3(mock).Eth, marketAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	balance, err := token.BalanceOf(context.Background(), "0x00000000000000000000000000000000000000aa")
+	if err != nil || balance.Int64() != 1000 {
+		t.Fatalf("Unexpected balance %v %v", balance, err)
+	}
+
+	sender := &dto.TransactionParameters{From: "0x00000000000000000000000000000000000000bb"}
+
+	hash, err := token.Transfer(context.Background(), sender, "0x00000000000000000000000000000000000000aa", big.NewInt(16))
+	if err != nil || hash != "0x0000000000000000000000000000000000000000000000000000000000000001" {
+		t.Fatalf("Unexpected transaction %v %v", hash, err)
+	}
+
+	if sender.To != "" {
+		t.Errorf("The transaction of the caller was changed: %+v", sender)
+	}
+
+	helpers.ExpectRequests(t, mock,
+		`eth_call [{"from":"","to":"0x00000000000000000000000000000000000000cc","data":"0x70a0823100000000000000000000000000000000000000000000000000000000000000aa"},"latest"]`,
+		`eth_sendTransaction [{"from":"0x00000000000000000000000000000000000000bb","to":"0x00000000000000000000000000000000000000cc","data":"0xa9059cbb00000000000000000000000000000000000000000000000000000000000000aa0000000000000000000000000000000000000000000000000000000000000010"}]`,
+	)
+
+}
+
+func TestMarketBinding(t *testing.T) {
+
+	mock := providers.NewMockProvider()
+	mock.On("eth_call").Return("0x" +
+		"00000000000000000000000000000000000000000000000000000000000000aa" +
+		"0000000000000000000000000000000000000000000000000000000000000001" +
+		"0000000000000000000000000000000000000000000000000000000000000002" +
+		"0000000000000000000000000000000000000000000000000000000000000019" +
+		"00000000000000000000000000000000000000000000000000000000000000bb" +
+		"0000000000000000000000000000000000000000000000000000000000000064")
+
+	contract, err := market.NewMarket(web3.NewWeb3(mock).Eth, marketAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	order, err := contract.Order(context.Background(), big.NewInt(7))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if order.Order.Maker != "0x00000000000000000000000000000000000000aa" || order.Order.Amounts[1].Int64() != 2 ||
+		order.Order.Fee.Rate != 25 || order.Order.Fee.Recipient != "0x00000000000000000000000000000000000000bb" || order.Expiry != 100 {
+		t.Errorf("Unexpected order %+v", order)
+	}
+
+	log := &dto.TransactionLogs{
+		Address: marketAddress,
+		Topics: []string{
+			"0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef",
+			"0x00000000000000000000000000000000000000000000000000000000000000aa",
+			"0x00000000000000000000000000000000000000000000000000000000000000bb",
+		},
+		Data: "0x00000000000000000000000000000000000000000000000000000000000003e8",
+	}
+
+	transfer, err := contract.ParseTransfer(log)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if transfer.From != "0x00000000000000000000000000000000000000aa" || transfer.Value.Int64() != 1000 || transfer.Raw != log {
+		t.Errorf("Unexpected transfer %+v", transfer)
+	}
+
+	if _, err := contract.ParseListed(log); err == nil {
+		t.Error("Expected an error for the log of another event")
+	}
+
+	if market.MarketInsufficientBalanceSelector != "0xcf479181" {
+		t.Errorf("Unexpected selector %s", market.MarketInsufficientBalanceSelector)
+	}
+
+}
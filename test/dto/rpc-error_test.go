@@ -0,0 +1,145 @@
+/********************************************************************************
+   This file is part of go-web3.
+   go-web3 is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-web3 is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-web3.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file rpc-error_test.go
+ */
+package test
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/cellcycle/go-web3/constants"
+	"github.com/cellcycle/go-web3/dto"
+)
+
+// revertData is Error("Not enough Ether provided.") as returned by geth
+const revertData = "0x08c379a0" +
+	"0000000000000000000000000000000000000000000000000000000000000020" +
+	"000000000000000000000000000000000000000000000000000000000000001a" +
+	"4e6f7420656e6f7567682045746865722070726f76696465642e000000000000"
+
+func responseError(t *testing.T, response string) error {
+
+	pointer := &dto.RequestResult{}
+	if err := json.Unmarshal([]byte(response), pointer); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := pointer.ToString()
+
+	return err
+}
+
+func TestRPCErrorRevertReason(t *testing.T) {
+
+	err := responseError(t, `{"jsonrpc":"2.0","id":1,"error":{"code":3,"message":"execution reverted: Not enough Ether provided.","data":"`+revertData+`"}}`)
+
+	var rpcError *dto.RPCError
+	if !errors.As(err, &rpcError) {
+		t.Fatalf("Expected an RPCError, got %T", err)
+	}
+
+	if rpcError.Code != 3 || err.Error() != "execution reverted: Not enough Ether provided." {
+		t.Errorf("Unexpected error %d %s", rpcError.Code, err)
+	}
+
+	if !errors.Is(err, customerror.EXECUTIONREVERTED) || errors.Is(err, customerror.NONCETOOLOW) {
+		t.Error("Error not matched as a revert")
+	}
+
+	revert, ok := rpcError.Revert()
+	if !ok || revert.Reason != "Not enough Ether provided." || revert.PanicCode != nil {
+		t.Errorf("Unexpected revert %+v", revert)
+	}
+
+}
+
+func TestRPCErrorPanicCode(t *testing.T) {
+
+	// Panic(0x11) nested in a data object, as some clients send it
+	err := responseError(t, `{"jsonrpc":"2.0","id":1,"error":{"code":-32000,"message":"VM Exception while processing transaction: revert","data":{"data":"0x4e487b710000000000000000000000000000000000000000000000000000000000000011"}}}`)
+
+	var rpcError *dto.RPCError
+	if !errors.As(err, &rpcError) {
+		t.Fatalf("Expected an RPCError, got %T", err)
+	}
+
+	revert, ok := rpcError.Revert()
+	if !ok || revert.PanicCode == nil || revert.PanicCode.Int64() != 0x11 {
+		t.Fatalf("Unexpected revert %+v", revert)
+	}
+
+	if revert.String() != "panic 0x11: arithmetic overflow or underflow" {
+		t.Errorf("Unexpected description %s", revert)
+	}
+
+}
+
+func TestDecodeRevertMalformed(t *testing.T) {
+
+	word := func(hex string) string {
+		return strings.Repeat("0", 64-len(hex)) + hex
+	}
+
+	for _, payload := range []string{
+		"08c379a0",
+		"08c379a0" + word("20"),
+		// the offset overflows when 32 is added to it
+		"08c379a0" + word("ffffffffffffffff") + word("1a"),
+		"08c379a0" + word("ffffffffffffffffffffffffffffffffffff") + word("1a"),
+		"08c379a0" + word("21") + word("1a"),
+		"08c379a0" + word("20") + word("ffffffffffffffff"),
+		"08c379a0" + word("20") + word("21") + "4e6f7420656e6f7567682045746865722070726f76696465642e000000000000",
+		"4e487b71",
+	} {
+		data, err := hex.DecodeString(payload)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if revert, err := dto.DecodeRevert(data); err == nil {
+			t.Errorf("Expected an error for 0x%s, got %+v", payload, revert)
+		}
+	}
+
+}
+
+func TestRPCErrorSentinels(t *testing.T) {
+
+	cases := []struct {
+		response string
+		expected error
+	}{
+		{`{"error":{"code":-32000,"message":"nonce too low"}}`, customerror.NONCETOOLOW},
+		{`{"error":{"code":-32000,"message":"insufficient funds for gas * price + value"}}`, customerror.INSUFFICIENTFUNDS},
+		{`{"error":{"code":-32601,"message":"the method eth_foo does not exist/is not available"}}`, customerror.METHODNOTFOUND},
+		{`{"error":{"code":-32005,"message":"daily request count exceeded, request rate limited"}}`, customerror.RATELIMITED},
+		{`{"error":{"code":429,"message":"Too Many Requests"}}`, customerror.RATELIMITED},
+	}
+
+	for _, c := range cases {
+		if err := responseError(t, c.response); !errors.Is(err, c.expected) {
+			t.Errorf("%s not matched as %v", c.response, c.expected)
+		}
+	}
+
+	if _, ok := (&dto.RPCError{Code: 3, Message: "execution reverted"}).Revert(); ok {
+		t.Error("Revert decoded without data")
+	}
+
+}
@@ -0,0 +1,139 @@
+/********************************************************************************
+   This file is part of go-web3.
+   go-web3 is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-web3 is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-web3.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file eth-batch_test.go
+ */
+
+package test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	web3 "github.com/cellcycle/go-web3"
+	"github.com/cellcycle/go-web3/eth/block"
+	"github.com/cellcycle/go-web3/providers"
+	"github.com/cellcycle/go-web3/test/helpers"
+)
+
+func TestEthBatch(t *testing.T) {
+
+	requests := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		requests++
+
+		body, _ := ioutil.ReadAll(r.Body)
+
+		var calls []struct {
+			ID     int             `json:"id"`
+			Method string          `json:"method"`
+			Params json.RawMessage `json:"params"`
+		}
+
+		if err := json.Unmarshal(body, &calls); err != nil {
+			t.Errorf("batch is not a JSON array: %s", body)
+			return
+		}
+
+		var responses []string
+
+		// answer in reverse order and forget eth_gasPrice
+		for index := len(calls) - 1; index >= 0; index-- {
+			id := strconv.Itoa(calls[index].ID)
+			switch calls[index].Method {
+			case "eth_blockNumber":
+				responses = append(responses, `{"jsonrpc":"2.0","id":`+id+`,"result":"0x10"}`)
+			case "eth_getBalance":
+				responses = append(responses, `{"jsonrpc":"2.0","id":`+id+`,"result":"0x2a"}`)
+			case "eth_coinbase":
+				responses = append(responses, `{"jsonrpc":"2.0","id":`+id+`,"error":{"code":-32000,"message":"no coinbase"}}`)
+			}
+		}
+
+		w.Write([]byte("[" + strings.Join(responses, ",") + "]"))
+	}))
+	defer server.Close()
+
+	var connection = web3.NewWeb3(providers.NewHTTPProvider(strings.TrimPrefix(server.URL, "http://"), 10, false))
+
+	batch := connection.Eth.NewBatch()
+	blockNumber := batch.GetBlockNumber()
+	balance := batch.GetBalance("0x0000000000000000000000000000000000000000", block.LATEST)
+	coinbase := batch.GetCoinbase()
+	gasPrice := batch.GetGasPrice()
+	malformed := batch.GetBlockByHash("0x1234", false)
+
+	if err := batch.Execute(); err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if requests != 1 {
+		t.Errorf("Expected a single round trip, got %d", requests)
+	}
+
+	number, err := blockNumber.Result()
+	if err != nil || number.Cmp(big.NewInt(16)) != 0 {
+		t.Errorf("Unexpected block number %v (%v)", number, err)
+	}
+
+	bal, err := balance.Result()
+	if err != nil || bal.Cmp(big.NewInt(42)) != 0 {
+		t.Errorf("Unexpected balance %v (%v)", bal, err)
+	}
+
+	if _, err := coinbase.Result(); err == nil || err.Error() != "no coinbase" {
+		t.Errorf("Expected the node error, got %v", err)
+	}
+
+	if _, err := gasPrice.Result(); err == nil {
+		t.Error("Expected an error for the missing response")
+	}
+
+	if _, err := malformed.Result(); err == nil {
+		t.Error("Expected an error for the malformed hash")
+	}
+
+}
+
+func TestEthBatchMethodNames(t *testing.T) {
+
+	mock := providers.NewMockProvider()
+	mock.On("eth_getStorageAt").Return("0x01")
+
+	batch := web3.NewWeb3(mock).Eth.NewBatch()
+	storage := batch.GetStorageAt("0x0000000000000000000000000000000000000001", big.NewInt(2), block.LATEST)
+
+	if err := batch.Execute(); err != nil {
+		t.Fatal(err)
+	}
+
+	if value, err := storage.Result(); err != nil || value != "0x01" {
+		t.Errorf("Unexpected storage %q %v", value, err)
+	}
+
+	helpers.ExpectRequests(t, mock,
+		`eth_getStorageAt ["0x0000000000000000000000000000000000000001","0x2","latest"]`,
+	)
+
+}
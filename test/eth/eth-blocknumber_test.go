@@ -24,13 +24,12 @@ package test
 import (
 	"testing"
 
-	"github.com/cellcycle/go-web3"
-	"github.com/cellcycle/go-web3/providers"
+	"github.com/cellcycle/go-web3/test/helpers"
 )
 
 func TestEthBlockNumber(t *testing.T) {
 
-	var connection = web3.NewWeb3(providers.NewHTTPProvider("127.0.0.1:8545", 10, false))
+	connection, mock := helpers.NewFixtureConnection(t, "eth-blocknumber")
 
 	blockNumber, err := connection.Eth.GetBlockNumber()
 
@@ -43,4 +42,9 @@ func TestEthBlockNumber(t *testing.T) {
 		t.Errorf("Invalid Block Number")
 		t.Fail()
 	}
+
+	helpers.ExpectRequests(t, mock,
+		`eth_blockNumber null`,
+	)
+
 }
@@ -0,0 +1,155 @@
+/********************************************************************************
+   This file is part of go-web3.
+   go-web3 is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-web3 is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-web3.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file eth-contract-errors_test.go
+ */
+
+package test
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	web3 "github.com/cellcycle/go-web3"
+	"github.com/cellcycle/go-web3/constants"
+	"github.com/cellcycle/go-web3/dto"
+	"github.com/cellcycle/go-web3/eth"
+	"github.com/cellcycle/go-web3/providers"
+)
+
+const vaultABI = `[
+	{"type":"function","name":"withdraw","inputs":[{"name":"amount","type":"uint256"}],"outputs":[{"name":"","type":"bool"}],"stateMutability":"view"},
+	{"type":"error","name":"InsufficientBalance","inputs":[{"name":"available","type":"uint256"},{"name":"required","type":"uint256"}]}
+]`
+
+func TestEthContractErrors(t *testing.T) {
+
+	cases := []struct {
+		data     interface{}
+		name     string
+		message  string
+		panicked bool
+	}{
+		{
+			"0xcf479181" +
+				"0000000000000000000000000000000000000000000000000000000000000001" +
+				"0000000000000000000000000000000000000000000000000000000000000064",
+			"InsufficientBalance",
+			"execution reverted: InsufficientBalance(available: 1, required: 100)",
+			false,
+		},
+		{
+			// the data nested in an object, as some clients send it
+			map[string]interface{}{"data": "0x08c379a0" +
+				"0000000000000000000000000000000000000000000000000000000000000020" +
+				"000000000000000000000000000000000000000000000000000000000000000a" +
+				"4e6f7420656e6f75676800000000000000000000000000000000000000000000"},
+			"Error",
+			"execution reverted: Not enough",
+			false,
+		},
+		{
+			"0x4e487b71" +
+				"0000000000000000000000000000000000000000000000000000000000000011",
+			"Panic",
+			"execution reverted: panic 0x11: arithmetic overflow or underflow",
+			true,
+		},
+		{
+			"0xdeadbeef",
+			"",
+			"execution reverted: unknown error 0xdeadbeef",
+			false,
+		},
+	}
+
+	for _, test := range cases {
+
+		mock := providers.NewMockProvider()
+		mock.On("eth_call").ReturnError(3, "execution reverted", test.data)
+		mock.On("eth_estimateGas").ReturnError(3, "execution reverted", test.data)
+
+		contract, err := web3.NewWeb3(mock).Eth.NewContract(vaultABI)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		transaction := &dto.TransactionParameters{To: "0x00000000000000000000000000000000000000cc"}
+
+		_, callErr := contract.Call(transaction, "withdraw", 100)
+		_, estimateErr := contract.EstimateGas(transaction, "withdraw", 100)
+
+		for _, err := range []error{callErr, estimateErr} {
+
+			var contractError *eth.ContractError
+			if !errors.As(err, &contractError) {
+				t.Fatalf("Expected a ContractError, got %v", err)
+			}
+
+			if contractError.Name != test.name || err.Error() != test.message || contractError.IsPanic() != test.panicked {
+				t.Errorf("Unexpected error %q named %q", err, contractError.Name)
+			}
+
+			if !errors.Is(err, customerror.EXECUTIONREVERTED) {
+				t.Errorf("Expected %v to be an EXECUTIONREVERTED", err)
+			}
+		}
+	}
+
+}
+
+func TestEthContractErrorDecode(t *testing.T) {
+
+	mock := providers.NewMockProvider()
+	mock.On("eth_call").ReturnError(3, "execution reverted", "0xcf479181"+
+		"0000000000000000000000000000000000000000000000000000000000000001"+
+		"0000000000000000000000000000000000000000000000000000000000000064")
+	mock.On("eth_estimateGas").ReturnError(-32000, "nonce too low", nil)
+
+	contract, err := web3.NewWeb3(mock).Eth.NewContract(vaultABI)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	transaction := &dto.TransactionParameters{To: "0x00000000000000000000000000000000000000cc"}
+
+	_, err = contract.Call(transaction, "withdraw", 100)
+
+	var contractError *eth.ContractError
+	if !errors.As(err, &contractError) {
+		t.Fatalf("Expected a ContractError, got %v", err)
+	}
+
+	var balance struct {
+		Available *big.Int
+		Required  uint64
+	}
+
+	if err := contractError.Decode(&balance); err != nil {
+		t.Fatal(err)
+	}
+
+	if balance.Available.Int64() != 1 || balance.Required != 100 || contractError.Arguments["required"].(*big.Int).Int64() != 100 {
+		t.Errorf("Unexpected arguments %+v", balance)
+	}
+
+	// the errors without revert data are left as they are
+	_, err = contract.EstimateGas(transaction, "withdraw", 100)
+	if errors.As(err, &contractError) || !errors.Is(err, customerror.NONCETOOLOW) {
+		t.Errorf("Unexpected error %v", err)
+	}
+
+}
@@ -0,0 +1,146 @@
+/********************************************************************************
+   This file is part of go-web3.
+   go-web3 is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-web3 is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-web3.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file eth-contract-events_test.go
+ */
+
+package test
+
+import (
+	"math/big"
+	"testing"
+
+	web3 "github.com/cellcycle/go-web3"
+	"github.com/cellcycle/go-web3/dto"
+	"github.com/cellcycle/go-web3/eth/block"
+	"github.com/cellcycle/go-web3/providers"
+	"github.com/cellcycle/go-web3/test/helpers"
+)
+
+const tokenEventsABI = `[
+	{"type":"event","name":"Transfer","inputs":[
+		{"name":"from","type":"address","indexed":true},
+		{"name":"to","type":"address","indexed":true},
+		{"name":"value","type":"uint256","indexed":false}
+	]},
+	{"type":"event","name":"Approval","inputs":[
+		{"name":"owner","type":"address","indexed":true},
+		{"name":"spender","type":"address","indexed":true},
+		{"name":"value","type":"uint256","indexed":false}
+	]}
+]`
+
+const (
+	transferTopic = "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"
+	tokenAddress  = "0x00000000000000000000000000000000000000cc"
+)
+
+func transferLog() map[string]interface{} {
+	return map[string]interface{}{
+		"address": tokenAddress,
+		"topics": []string{
+			transferTopic,
+			"0x00000000000000000000000000000000000000000000000000000000000000aa",
+			"0x00000000000000000000000000000000000000000000000000000000000000bb",
+		},
+		"data":             "0x00000000000000000000000000000000000000000000000000000000000003e8",
+		"blockNumber":      "0x10",
+		"transactionHash":  "0x0000000000000000000000000000000000000000000000000000000000000001",
+		"transactionIndex": "0x0",
+		"blockHash":        "0x0000000000000000000000000000000000000000000000000000000000000002",
+		"logIndex":         "0x3",
+		"removed":          false,
+	}
+}
+
+func TestEthContractParseLog(t *testing.T) {
+
+	connection := web3.NewWeb3(providers.NewMockProvider())
+
+	contract, err := connection.Eth.NewContract(tokenEventsABI)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	log := &dto.TransactionLogs{
+		Address: tokenAddress,
+		Topics:  transferLog()["topics"].([]string),
+		Data:    transferLog()["data"].(string),
+	}
+
+	event, err := contract.ParseLog(log)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if event.Name != "Transfer" || event.Arguments["value"].(*big.Int).Int64() != 1000 || event.Arguments["to"].([20]byte)[19] != 0xbb {
+		t.Errorf("Unexpected event %+v", event)
+	}
+
+	var transfer struct {
+		From  string
+		To    string
+		Value *big.Int
+	}
+
+	if err := event.Decode(&transfer); err != nil {
+		t.Fatal(err)
+	}
+
+	if transfer.From != "0x00000000000000000000000000000000000000aa" || transfer.Value.Int64() != 1000 {
+		t.Errorf("Unexpected transfer %+v", transfer)
+	}
+
+	log.Topics = []string{"0x0000000000000000000000000000000000000000000000000000000000000001"}
+	if _, err := contract.ParseLog(log); err == nil {
+		t.Error("Expected an error for an unknown topic")
+	}
+
+}
+
+func TestEthContractFilterLogs(t *testing.T) {
+
+	mock := providers.NewMockProvider()
+	mock.On("eth_getLogs").Return([]interface{}{transferLog()})
+
+	connection := web3.NewWeb3(mock)
+
+	contract, err := connection.Eth.NewContract(tokenEventsABI)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := contract.FilterLogs("Transfer", block.EARLIEST, block.LATEST); err == nil {
+		t.Error("Expected an error for a contract without address")
+	}
+
+	events, err := contract.At(tokenAddress).FilterLogs("Transfer", block.NUMBER(big.NewInt(16)), block.LATEST, nil, "0x00000000000000000000000000000000000000bb")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(events) != 1 || events[0].Arguments["value"].(*big.Int).Int64() != 1000 || events[0].Log.LogIndex.Int64() != 3 {
+		t.Errorf("Unexpected events %+v", events)
+	}
+
+	helpers.ExpectRequests(t, mock,
+		`eth_getLogs [{"address":"`+tokenAddress+`","fromBlock":"0x10","toBlock":"latest","topics":["`+transferTopic+`",null,"0x00000000000000000000000000000000000000000000000000000000000000bb"]}]`,
+	)
+
+	if _, err := contract.At(tokenAddress).FilterLogs("Transfer", block.EARLIEST, block.LATEST, 1, 2, 3); err == nil {
+		t.Error("Expected an error for too many indexed values")
+	}
+
+}
@@ -0,0 +1,96 @@
+/********************************************************************************
+   This file is part of go-web3.
+   go-web3 is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-web3 is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-web3.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file eth-contract-overload_test.go
+ */
+
+package test
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	web3 "github.com/cellcycle/go-web3"
+	"github.com/cellcycle/go-web3/dto"
+	"github.com/cellcycle/go-web3/providers"
+)
+
+const overloadedABI = `[
+	{"type":"function","name":"balanceOf","inputs":[{"name":"owner","type":"address"}],"outputs":[{"name":"","type":"uint256"}],"stateMutability":"view"},
+	{"type":"function","name":"balanceOf","inputs":[{"name":"owner","type":"address"},{"name":"id","type":"uint256"}],"outputs":[{"name":"","type":"uint256"}],"stateMutability":"view"},
+	{"type":"function","name":"owner","inputs":[],"outputs":[{"name":"","type":"address"}],"stateMutability":"view"}
+]`
+
+func TestEthContractOverloads(t *testing.T) {
+
+	mock := providers.NewMockProvider()
+	mock.On("eth_call").Return("0x000000000000000000000000000000000000000000000000000000000000002a")
+
+	connection := web3.NewWeb3(mock)
+
+	contract, err := connection.Eth.NewContract(overloadedABI)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	owner := "0x0000000000000000000000000000000000000001"
+	transaction := &dto.TransactionParameters{To: "0x00000000000000000000000000000000000000cc"}
+
+	if _, err := contract.Call(transaction, "balanceOf", owner); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := contract.Call(transaction, "balanceOf(address,uint256)", owner, big.NewInt(0)); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := contract.Call(transaction, "balanceOf", owner, big.NewInt(0), big.NewInt(0)); err == nil {
+		t.Error("Expected an error for the number of arguments")
+	}
+
+	if _, err := contract.Call(transaction, "allowance", owner); err == nil {
+		t.Error("Expected an error for an unknown function")
+	}
+
+	if _, err := connection.Eth.NewContract(`[{"type":"function","name":"f","inputs":[{"name":"a","type":"uint7"}]}]`); err == nil {
+		t.Error("Expected an error for an invalid ABI")
+	}
+
+	// the selectors are computed locally, without web3_sha3
+	requests := mock.Requests()
+	if len(requests) != 2 {
+		t.Fatalf("Unexpected requests %v", requests)
+	}
+
+	if !strings.Contains(string(requests[0].Params), `"data":"0x70a08231`) || !strings.Contains(string(requests[1].Params), `"data":"0x00fdd58e`) {
+		t.Errorf("Unexpected selectors in %v", requests)
+	}
+
+	// the human-readable ABI
+	human, err := connection.Eth.NewContract(`
+		function balanceOf(address owner) view returns (uint256)
+		function owner() view returns (address)
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	values, err := human.Call(transaction, "owner")
+	if err != nil || values[0].([20]byte)[19] != 0x2a {
+		t.Errorf("Unexpected owner %v: %v", values, err)
+	}
+
+}
@@ -24,12 +24,16 @@ package test
 import (
 	"encoding/json"
 	"fmt"
-	web3 "github.com/cellcycle/go-web3"
-	"github.com/cellcycle/go-web3/dto"
-	"github.com/cellcycle/go-web3/providers"
 	"io/ioutil"
 	"math/big"
+	"reflect"
+	"strings"
 	"testing"
+
+	web3 "github.com/cellcycle/go-web3"
+	"github.com/cellcycle/go-web3/dto"
+	"github.com/cellcycle/go-web3/providers"
+	"github.com/cellcycle/go-web3/test/helpers"
 )
 
 func TestEthContract(t *testing.T) {
@@ -45,7 +49,7 @@ func TestEthContract(t *testing.T) {
 
 	json.Unmarshal(content, &unmarshalResponse)
 
-	var connection = web3.NewWeb3(providers.NewHTTPProvider("127.0.0.1:8545", 10, false))
+	connection, _ := helpers.NewFixtureConnection(t, "eth-contract")
 	bytecode := unmarshalResponse.Bytecode
 	contract, err := connection.Eth.NewContract(unmarshalResponse.Abi)
 
@@ -83,46 +87,40 @@ func TestEthContract(t *testing.T) {
 		t.FailNow()
 	}
 
-	if result != nil && err == nil {
-		name, _ := result.ToComplexString()
-		if name.ToString() != "SimpleToken" {
-			t.Errorf(fmt.Sprintf("Name not expected; [Expected %s | Got %s]", "SimpleToken", name.ToString()))
-			t.FailNow()
-		}
+	if name, _ := result[0].(string); name != "SimpleToken" {
+		t.Errorf(fmt.Sprintf("Name not expected; [Expected %s | Got %v]", "SimpleToken", result))
+		t.FailNow()
 	}
 
-	result, err = contract.Call(transaction, "symbol")
-	if result != nil && err == nil {
-		symbol, _ := result.ToComplexString()
-		if symbol.ToString() != "SIM" {
-			t.Errorf("Symbol not expected")
-			t.FailNow()
-		}
+	var symbol string
+	err = contract.CallInto(transaction, &symbol, "symbol")
+	if err != nil || symbol != "SIM" {
+		t.Errorf("Symbol not expected")
+		t.FailNow()
 	}
 
-	result, err = contract.Call(transaction, "decimals")
-	if result != nil && err == nil {
-		decimals, _ := result.ToBigInt()
-		if decimals.Int64() != 18 {
-			t.Errorf("Decimals not expected")
-			t.FailNow()
-		}
+	var decimals uint8
+	err = contract.CallInto(transaction, &decimals, "decimals")
+	if err != nil || decimals != 18 {
+		t.Errorf("Decimals not expected")
+		t.FailNow()
 	}
 
 	bigInt, _ := new(big.Int).SetString("00000000000000000000000000000000000000000000021e19e0c9bab2400000", 16)
 
 	result, err = contract.Call(transaction, "totalSupply")
-	if result != nil && err == nil {
-		total, _ := result.ToBigInt()
-		if total.Cmp(bigInt) != 0 {
-			t.Errorf("Total not expected")
-			t.FailNow()
-		}
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	if total, _ := result[0].(*big.Int); total == nil || total.Cmp(bigInt) != 0 {
+		t.Errorf("Total not expected")
+		t.FailNow()
 	}
 
-	result, err = contract.Call(transaction, "balanceOf", coinbase)
-	if result != nil && err == nil {
-		balance, _ := result.ToBigInt()
+	raw, err := contract.CallRaw(transaction, "balanceOf", coinbase)
+	if raw != nil && err == nil {
+		balance, _ := raw.ToBigInt()
 		if balance.Cmp(bigInt) != 0 {
 			t.Errorf("Balance not expected")
 			t.FailNow()
@@ -145,3 +143,27 @@ func TestEthContract(t *testing.T) {
 	}
 
 }
+
+func TestEthContractDuplicateOutputNames(t *testing.T) {
+
+	mock := providers.NewMockProvider()
+	mock.On("eth_call").Return("0x" + strings.Repeat("0", 63) + "1" + strings.Repeat("0", 63) + "2")
+
+	contract, err := web3.NewWeb3(mock).Eth.NewContract(`[{"type":"function","name":"pair","inputs":[],"outputs":[
+		{"name":"","type":"tuple","components":[{"name":"field1","type":"uint256"},{"name":"","type":"uint256"}]}
+	],"stateMutability":"view"}]`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := contract.Call(&dto.TransactionParameters{To: "0x00000000000000000000000000000000000000cc"}, "pair")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pair := reflect.ValueOf(result[0])
+	if pair.Field(0).Interface().(*big.Int).Int64() != 1 || pair.Field(1).Interface().(*big.Int).Int64() != 2 {
+		t.Errorf("Unexpected result %v", result)
+	}
+
+}
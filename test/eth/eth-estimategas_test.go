@@ -22,17 +22,16 @@
 package test
 
 import (
+	"math/big"
 	"testing"
 
-	web3 "github.com/cellcycle/go-web3"
 	"github.com/cellcycle/go-web3/dto"
-	"github.com/cellcycle/go-web3/providers"
-	"math/big"
+	"github.com/cellcycle/go-web3/test/helpers"
 )
 
 func TestEstimateGas(t *testing.T) {
 
-	var connection = web3.NewWeb3(providers.NewHTTPProvider("127.0.0.1:8545", 10, false))
+	connection, mock := helpers.NewFixtureConnection(t, "eth-estimategas")
 
 	coinbase, err := connection.Eth.GetCoinbase()
 
@@ -56,4 +55,9 @@ func TestEstimateGas(t *testing.T) {
 	}
 	t.Log(gas)
 
+	helpers.ExpectRequests(t, mock,
+		`eth_coinbase null`,
+		`eth_estimateGas [{"from":"0x7e5f4552091a69125d5dfcb7b8c2659029395bdf","to":"0x7e5f4552091a69125d5dfcb7b8c2659029395bdf","gas":"0x9c40","value":"0xa"}]`,
+	)
+
 }
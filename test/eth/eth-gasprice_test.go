@@ -24,13 +24,12 @@ package test
 import (
 	"testing"
 
-	web3 "github.com/cellcycle/go-web3"
-	"github.com/cellcycle/go-web3/providers"
+	"github.com/cellcycle/go-web3/test/helpers"
 )
 
 func TestEthGasPrice(t *testing.T) {
 
-	var connection = web3.NewWeb3(providers.NewHTTPProvider("127.0.0.1:8545", 10, false))
+	connection, mock := helpers.NewFixtureConnection(t, "eth-gasprice")
 
 	gasPrice, err := connection.Eth.GetGasPrice()
 
@@ -45,4 +44,9 @@ func TestEthGasPrice(t *testing.T) {
 	}
 
 	t.Log(gasPrice.Int64())
+
+	helpers.ExpectRequests(t, mock,
+		`eth_gasPrice null`,
+	)
+
 }
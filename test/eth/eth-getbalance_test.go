@@ -24,14 +24,13 @@ package test
 import (
 	"testing"
 
-	web3 "github.com/cellcycle/go-web3"
 	"github.com/cellcycle/go-web3/eth/block"
-	"github.com/cellcycle/go-web3/providers"
+	"github.com/cellcycle/go-web3/test/helpers"
 )
 
 func TestEthGetBalance(t *testing.T) {
 
-	var connection = web3.NewWeb3(providers.NewHTTPProvider("127.0.0.1:8545", 10, false))
+	connection, mock := helpers.NewFixtureConnection(t, "eth-getbalance")
 
 	coinbase, _ := connection.Eth.GetCoinbase()
 
@@ -44,4 +43,9 @@ func TestEthGetBalance(t *testing.T) {
 
 	t.Log(bal)
 
+	helpers.ExpectRequests(t, mock,
+		`eth_coinbase null`,
+		`eth_getBalance ["0x7e5f4552091a69125d5dfcb7b8c2659029395bdf","latest"]`,
+	)
+
 }
@@ -24,13 +24,12 @@ package test
 import (
 	"testing"
 
-	"github.com/cellcycle/go-web3"
-	"github.com/cellcycle/go-web3/providers"
+	"github.com/cellcycle/go-web3/test/helpers"
 )
 
 func TestEthGetBlockByHash(t *testing.T) {
 
-	var connection = web3.NewWeb3(providers.NewHTTPProvider("127.0.0.1:8545", 10, false))
+	connection, mock := helpers.NewFixtureConnection(t, "eth-getblockbyhash")
 
 	blockNumber, err := connection.Eth.GetBlockNumber()
 
@@ -86,4 +85,12 @@ func TestEthGetBlockByHash(t *testing.T) {
 		t.Errorf("Found a block with incorrect hash?")
 		t.FailNow()
 	}
+
+	helpers.ExpectRequests(t, mock,
+		`eth_blockNumber null`,
+		`eth_getBlockByNumber ["0x6",false]`,
+		`eth_getBlockByHash ["0xc3def285767dd0ae33aeebcf62a28a4f1f7249337a6e9d81418307e23afa5cee",false]`,
+		`eth_getBlockByHash ["0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",false]`,
+	)
+
 }
@@ -24,13 +24,12 @@ package test
 import (
 	"testing"
 
-	"github.com/cellcycle/go-web3"
-	"github.com/cellcycle/go-web3/providers"
+	"github.com/cellcycle/go-web3/test/helpers"
 )
 
 func TestEthGetBlockByNumber(t *testing.T) {
 
-	var connection = web3.NewWeb3(providers.NewHTTPProvider("127.0.0.1:8545", 10, false))
+	connection, mock := helpers.NewFixtureConnection(t, "eth-getblockbynumber")
 
 	blockNumber, err := connection.Eth.GetBlockNumber()
 
@@ -50,4 +49,9 @@ func TestEthGetBlockByNumber(t *testing.T) {
 		t.FailNow()
 	}
 
+	helpers.ExpectRequests(t, mock,
+		`eth_blockNumber null`,
+		`eth_getBlockByNumber ["0x6",false]`,
+	)
+
 }
@@ -22,9 +22,8 @@
 package test
 
 import (
-	"github.com/cellcycle/go-web3"
 	"github.com/cellcycle/go-web3/dto"
-	"github.com/cellcycle/go-web3/providers"
+	"github.com/cellcycle/go-web3/test/helpers"
 	"math/big"
 	"testing"
 	"time"
@@ -32,7 +31,7 @@ import (
 
 func TestGetBlockTransactionCountByHash(t *testing.T) {
 
-	var connection = web3.NewWeb3(providers.NewHTTPProvider("127.0.0.1:8545", 10, false))
+	connection, mock := helpers.NewFixtureConnection(t, "eth-getblocktransactioncountbyhash")
 
 	blockNumber, err := connection.Eth.GetBlockNumber()
 
@@ -97,4 +96,14 @@ func TestGetBlockTransactionCountByHash(t *testing.T) {
 		t.FailNow()
 	}
 
+	helpers.ExpectRequests(t, mock,
+		`eth_blockNumber null`,
+		`eth_getBlockByNumber ["0x6",false]`,
+		`eth_getBlockTransactionCountByHash ["0xc3def285767dd0ae33aeebcf62a28a4f1f7249337a6e9d81418307e23afa5cee"]`,
+		`eth_coinbase null`,
+		`eth_sendTransaction [{"from":"0x7e5f4552091a69125d5dfcb7b8c2659029395bdf","to":"0x7e5f4552091a69125d5dfcb7b8c2659029395bdf","gas":"0x9c40","value":"0x30d40"}]`,
+		`eth_getTransactionByHash ["0xb90d79ee32911e6f0d00a937bf925d909273a0fa7f17a946170bd4ad2b530ad4"]`,
+		`eth_getBlockTransactionCountByHash ["0x5f04fba11efa5734f8b039ddb6594737678f6948fa82ab4466419e3d7c954105"]`,
+	)
+
 }
@@ -26,15 +26,14 @@ import (
 	"testing"
 	"time"
 
-	web3 "github.com/cellcycle/go-web3"
 	"github.com/cellcycle/go-web3/dto"
 	"github.com/cellcycle/go-web3/eth/block"
-	"github.com/cellcycle/go-web3/providers"
+	"github.com/cellcycle/go-web3/test/helpers"
 )
 
 func TestGetBlockTransactionCountByNumber(t *testing.T) {
 
-	var connection = web3.NewWeb3(providers.NewHTTPProvider("127.0.0.1:8545", 10, false))
+	connection, mock := helpers.NewFixtureConnection(t, "eth-getblocktransactioncountbynumber")
 
 	// submit a transaction, wait for the block and there should be 1 tx.
 	coinbase, err := connection.Eth.GetCoinbase()
@@ -91,4 +90,13 @@ func TestGetBlockTransactionCountByNumber(t *testing.T) {
 		t.Error("invalid block transaction count")
 		t.FailNow()
 	}
+
+	helpers.ExpectRequests(t, mock,
+		`eth_coinbase null`,
+		`eth_sendTransaction [{"from":"0x7e5f4552091a69125d5dfcb7b8c2659029395bdf","to":"0x7e5f4552091a69125d5dfcb7b8c2659029395bdf","gas":"0x9c40","value":"0x30d40"}]`,
+		`eth_getTransactionByHash ["0xc6d0db76d7f6c45faff2cffe04ff28b6aca10a73c0fde21f4dd023a395fb7740"]`,
+		`eth_getBlockTransactionCountByNumber ["0x8"]`,
+		`eth_getBlockTransactionCountByNumber ["latest"]`,
+	)
+
 }
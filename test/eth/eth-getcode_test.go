@@ -27,11 +27,9 @@ import (
 	"math/big"
 	"testing"
 
-	"github.com/cellcycle/go-web3/eth/block"
-
-	web3 "github.com/cellcycle/go-web3"
 	"github.com/cellcycle/go-web3/dto"
-	"github.com/cellcycle/go-web3/providers"
+	"github.com/cellcycle/go-web3/eth/block"
+	"github.com/cellcycle/go-web3/test/helpers"
 )
 
 func TestEthGetcode(t *testing.T) {
@@ -53,7 +51,7 @@ func TestEthGetcode(t *testing.T) {
 
 	json.Unmarshal(content, &unmarshalResponse)
 
-	var connection = web3.NewWeb3(providers.NewHTTPProvider("127.0.0.1:8545", 10, false))
+	connection, _ := helpers.NewFixtureConnection(t, "eth-getcode")
 	bytecode := unmarshalResponse.Bytecode
 	deployedBytecode := unmarshalResponse.DeployedBytecode
 
@@ -21,9 +21,8 @@
 package test
 
 import (
-	"github.com/cellcycle/go-web3"
 	"github.com/cellcycle/go-web3/dto"
-	"github.com/cellcycle/go-web3/providers"
+	"github.com/cellcycle/go-web3/test/helpers"
 	"math/big"
 	"testing"
 	"time"
@@ -31,7 +30,7 @@ import (
 
 func TestGetTransactionByBlockHashAndIndex(t *testing.T) {
 
-	var connection = web3.NewWeb3(providers.NewHTTPProvider("127.0.0.1:8545", 10, false))
+	connection, mock := helpers.NewFixtureConnection(t, "eth-gettransactionbyblockhashandindex")
 
 	coinbase, err := connection.Eth.GetCoinbase()
 
@@ -91,4 +90,13 @@ func TestGetTransactionByBlockHashAndIndex(t *testing.T) {
 		t.Errorf("Incorrect transaction from hash and index")
 		t.FailNow()
 	}
+
+	helpers.ExpectRequests(t, mock,
+		`eth_coinbase null`,
+		`eth_sendTransaction [{"from":"0x7e5f4552091a69125d5dfcb7b8c2659029395bdf","to":"0x7e5f4552091a69125d5dfcb7b8c2659029395bdf","gas":"0x9c40","value":"0x1e8480"}]`,
+		`eth_getTransactionByHash ["0xff84ff619dc25e43e43b99ee7c4072b2ffd2d03188ca1a2be3f3ec1240b4f168"]`,
+		`eth_getTransactionByBlockHashAndIndex ["0xdfeb4d910bb114bfcdc4bf7e0a1306ac3cd5bbfac7edc5f91be07f0b2a025ed3","0x0"]`,
+		`eth_getTransactionByBlockHashAndIndex ["0xdfeb4d910bb114bfcdc4bf7e0a1306ac3cd5bbfac7edc5f91be07f0b2a025ed3","0x0"]`,
+	)
+
 }
@@ -23,16 +23,15 @@ package test
 import (
 	"testing"
 
-	"github.com/cellcycle/go-web3"
 	"github.com/cellcycle/go-web3/complex/types"
 	"github.com/cellcycle/go-web3/dto"
-	"github.com/cellcycle/go-web3/providers"
+	"github.com/cellcycle/go-web3/test/helpers"
 	"math/big"
 )
 
 func TestGetTransactionByBlockNumberAndIndex(t *testing.T) {
 
-	var connection = web3.NewWeb3(providers.NewHTTPProvider("127.0.0.1:8545", 10, false))
+	connection, mock := helpers.NewFixtureConnection(t, "eth-gettransactionbyblocknumberandindex")
 
 	coinbase, err := connection.Eth.GetCoinbase()
 
@@ -44,7 +43,7 @@ func TestGetTransactionByBlockNumberAndIndex(t *testing.T) {
 	transaction := new(dto.TransactionParameters)
 	transaction.From = coinbase
 	transaction.To = coinbase
-	transaction.Value = big.NewInt(0).Mul(big.NewInt(500), big.NewInt(1E18))
+	transaction.Value = big.NewInt(0).Mul(big.NewInt(500), big.NewInt(1e18))
 	transaction.Gas = big.NewInt(40000)
 	transaction.Data = types.ComplexString("p2p transaction")
 
@@ -70,4 +69,11 @@ func TestGetTransactionByBlockNumberAndIndex(t *testing.T) {
 	t.Log(tx.BlockHash)
 	t.Log(tx.BlockNumber)
 	t.Log(tx.TransactionIndex)
+
+	helpers.ExpectRequests(t, mock,
+		`eth_coinbase null`,
+		`eth_blockNumber null`,
+		`eth_getTransactionByBlockNumberAndIndex ["0xa","0x0"]`,
+	)
+
 }
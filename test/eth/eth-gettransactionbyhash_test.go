@@ -21,9 +21,8 @@
 package test
 
 import (
-	"github.com/cellcycle/go-web3"
 	"github.com/cellcycle/go-web3/dto"
-	"github.com/cellcycle/go-web3/providers"
+	"github.com/cellcycle/go-web3/test/helpers"
 	"math/big"
 	"testing"
 	"time"
@@ -31,7 +30,7 @@ import (
 
 func TestGetTransactionByHash(t *testing.T) {
 
-	var connection = web3.NewWeb3(providers.NewHTTPProvider("127.0.0.1:8545", 10, false))
+	connection, mock := helpers.NewFixtureConnection(t, "eth-gettransactionbyhash")
 
 	coinbase, err := connection.Eth.GetCoinbase()
 
@@ -69,4 +68,10 @@ func TestGetTransactionByHash(t *testing.T) {
 
 	t.Log(tx.BlockNumber)
 
+	helpers.ExpectRequests(t, mock,
+		`eth_coinbase null`,
+		`eth_sendTransaction [{"from":"0x7e5f4552091a69125d5dfcb7b8c2659029395bdf","to":"0x7e5f4552091a69125d5dfcb7b8c2659029395bdf","gas":"0x9c40","value":"0xa"}]`,
+		`eth_getTransactionByHash ["0x18747513d050a80719e656dd7263c5887c067fbbaaad8dc095b561e6d649b205"]`,
+	)
+
 }
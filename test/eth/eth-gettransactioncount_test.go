@@ -23,11 +23,10 @@ package test
 
 import (
 	"fmt"
-	"github.com/cellcycle/go-web3"
 	"github.com/cellcycle/go-web3/complex/types"
 	"github.com/cellcycle/go-web3/dto"
 	"github.com/cellcycle/go-web3/eth/block"
-	"github.com/cellcycle/go-web3/providers"
+	"github.com/cellcycle/go-web3/test/helpers"
 	"math/big"
 	"testing"
 	"time"
@@ -35,7 +34,7 @@ import (
 
 func TestEthGetTransactionCount(t *testing.T) {
 
-	var connection = web3.NewWeb3(providers.NewHTTPProvider("127.0.0.1:8545", 10, false))
+	connection, mock := helpers.NewFixtureConnection(t, "eth-gettransactioncount")
 
 	coinbase, _ := connection.Eth.GetCoinbase()
 
@@ -64,7 +63,7 @@ func TestEthGetTransactionCount(t *testing.T) {
 	transaction := new(dto.TransactionParameters)
 	transaction.From = coinbase
 	transaction.To = coinbase
-	transaction.Value = big.NewInt(0).Mul(big.NewInt(500), big.NewInt(1E18))
+	transaction.Value = big.NewInt(0).Mul(big.NewInt(500), big.NewInt(1e18))
 	transaction.Gas = big.NewInt(40000)
 	transaction.Data = types.ComplexString("p2p transaction")
 
@@ -90,4 +89,13 @@ func TestEthGetTransactionCount(t *testing.T) {
 	}
 
 	t.Log("Final Count: ", newCount)
+
+	helpers.ExpectRequests(t, mock,
+		`eth_coinbase null`,
+		`eth_getTransactionCount ["0x7e5f4552091a69125d5dfcb7b8c2659029395bdf","latest"]`,
+		`eth_getTransactionCount ["0x7e5f4552091a69125d5dfcb7b8c2659029395bdf","latest"]`,
+		`eth_sendTransaction [{"from":"0x7e5f4552091a69125d5dfcb7b8c2659029395bdf","to":"0x7e5f4552091a69125d5dfcb7b8c2659029395bdf","gas":"0x9c40","value":"0x1b1ae4d6e2ef500000","data":"0x703270207472616e73616374696f6e"}]`,
+		`eth_getTransactionCount ["0x7e5f4552091a69125d5dfcb7b8c2659029395bdf","latest"]`,
+	)
+
 }
@@ -22,14 +22,13 @@
 package test
 
 import (
-	"github.com/cellcycle/go-web3"
-	"github.com/cellcycle/go-web3/providers"
+	"github.com/cellcycle/go-web3/test/helpers"
 	"testing"
 )
 
 func TestGetUncleCountByBlockHash(t *testing.T) {
 
-	var connection = web3.NewWeb3(providers.NewHTTPProvider("127.0.0.1:8545", 10, false))
+	connection, mock := helpers.NewFixtureConnection(t, "eth-getunclecountbyblockhash")
 
 	blockNumber, err := connection.Eth.GetBlockNumber()
 
@@ -67,4 +66,11 @@ func TestGetUncleCountByBlockHash(t *testing.T) {
 		t.Errorf("Invalid hash not rejected")
 		t.FailNow()
 	}
+
+	helpers.ExpectRequests(t, mock,
+		`eth_blockNumber null`,
+		`eth_getBlockByNumber ["0xd",false]`,
+		`eth_getUncleCountByBlockHash ["0xbda899e27052a9555fba991ef496a6725c8b42d63b964dfebf1ae5e4f0e5b7ef"]`,
+	)
+
 }
@@ -22,15 +22,14 @@
 package test
 
 import (
-	"github.com/cellcycle/go-web3"
-	"github.com/cellcycle/go-web3/providers"
+	"github.com/cellcycle/go-web3/test/helpers"
 	"math/big"
 	"testing"
 )
 
 func TestGetUncleCountByBlockNumber(t *testing.T) {
 
-	var connection = web3.NewWeb3(providers.NewHTTPProvider("127.0.0.1:8545", 10, false))
+	connection, mock := helpers.NewFixtureConnection(t, "eth-getunclecountbyblocknumber")
 
 	blockNumber, err := connection.Eth.GetBlockNumber()
 
@@ -55,4 +54,11 @@ func TestGetUncleCountByBlockNumber(t *testing.T) {
 		t.Error(err)
 		t.FailNow()
 	}
+
+	helpers.ExpectRequests(t, mock,
+		`eth_blockNumber null`,
+		`eth_getUncleCountByBlockNumber ["0xd"]`,
+		`eth_getUncleCountByBlockNumber ["0x-1"]`,
+	)
+
 }
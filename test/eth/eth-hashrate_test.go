@@ -24,13 +24,12 @@ package test
 import (
 	"testing"
 
-	web3 "github.com/cellcycle/go-web3"
-	"github.com/cellcycle/go-web3/providers"
+	"github.com/cellcycle/go-web3/test/helpers"
 )
 
 func TestEthHashrate(t *testing.T) {
 
-	var connection = web3.NewWeb3(providers.NewHTTPProvider("127.0.0.1:8545", 10, false))
+	connection, mock := helpers.NewFixtureConnection(t, "eth-hashrate")
 
 	rate, err := connection.Eth.GetHashRate()
 
@@ -45,4 +44,9 @@ func TestEthHashrate(t *testing.T) {
 	}
 
 	t.Log(rate)
+
+	helpers.ExpectRequests(t, mock,
+		`eth_hashrate null`,
+	)
+
 }
@@ -24,13 +24,12 @@ package test
 import (
 	"testing"
 
-	web3 "github.com/cellcycle/go-web3"
-	"github.com/cellcycle/go-web3/providers"
+	"github.com/cellcycle/go-web3/test/helpers"
 )
 
 func TestEthMining(t *testing.T) {
 
-	var connection = web3.NewWeb3(providers.NewHTTPProvider("127.0.0.1:8545", 10, false))
+	connection, mock := helpers.NewFixtureConnection(t, "eth-mining")
 
 	isMining, err := connection.Eth.IsMining()
 
@@ -44,4 +43,8 @@ func TestEthMining(t *testing.T) {
 		t.Fail()
 	}
 
+	helpers.ExpectRequests(t, mock,
+		`eth_mining null`,
+	)
+
 }
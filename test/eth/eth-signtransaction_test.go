@@ -24,16 +24,15 @@ import (
 	"testing"
 
 	"fmt"
-	"github.com/cellcycle/go-web3"
 	"github.com/cellcycle/go-web3/complex/types"
 	"github.com/cellcycle/go-web3/dto"
-	"github.com/cellcycle/go-web3/providers"
+	"github.com/cellcycle/go-web3/test/helpers"
 	"math/big"
 )
 
 func TestEthSignTransaction(t *testing.T) {
 
-	var connection = web3.NewWeb3(providers.NewHTTPProvider("127.0.0.1:8545", 10, false))
+	connection, mock := helpers.NewFixtureConnection(t, "eth-signtransaction")
 
 	coinbase, err := connection.Eth.GetCoinbase()
 
@@ -46,9 +45,9 @@ func TestEthSignTransaction(t *testing.T) {
 	transaction.Nonce = big.NewInt(5)
 	transaction.From = coinbase
 	transaction.To = coinbase
-	transaction.Value = big.NewInt(0).Mul(big.NewInt(500), big.NewInt(1E18))
+	transaction.Value = big.NewInt(0).Mul(big.NewInt(500), big.NewInt(1e18))
 	transaction.Gas = big.NewInt(40000)
-	transaction.GasPrice = big.NewInt(1E9)
+	transaction.GasPrice = big.NewInt(1e9)
 	transaction.Data = types.ComplexString("p2p transaction")
 
 	txID, err := connection.Eth.SignTransaction(transaction)
@@ -81,4 +80,10 @@ func TestEthSignTransaction(t *testing.T) {
 		t.Errorf(fmt.Sprintf("Expected %d | Got: %d", transaction.GasPrice.Uint64(), txID.Transaction.GasPrice.Uint64()))
 		t.FailNow()
 	}
+
+	helpers.ExpectRequests(t, mock,
+		`eth_coinbase null`,
+		`eth_signTransaction [{"from":"0x7e5f4552091a69125d5dfcb7b8c2659029395bdf","to":"0x7e5f4552091a69125d5dfcb7b8c2659029395bdf","nonce":"0x5","gas":"0x9c40","gasPrice":"0x3b9aca00","value":"0x1b1ae4d6e2ef500000","data":"0x703270207472616e73616374696f6e"}]`,
+	)
+
 }
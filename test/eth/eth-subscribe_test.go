@@ -0,0 +1,160 @@
+/********************************************************************************
+   This file is part of go-web3.
+   go-web3 is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-web3 is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-web3.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file eth-subscribe_test.go
+ */
+
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	web3 "github.com/cellcycle/go-web3"
+	"github.com/cellcycle/go-web3/constants"
+	"github.com/cellcycle/go-web3/dto"
+	"github.com/cellcycle/go-web3/providers"
+	"github.com/cellcycle/go-web3/test/helpers"
+)
+
+func noCalls(node *helpers.FakeNode, method string, params json.RawMessage) (interface{}, error) {
+	return nil, nil
+}
+
+func TestEthSubscribeNewHeads(t *testing.T) {
+
+	node := helpers.NewFakeNode(noCalls)
+	defer node.Close()
+
+	var connection = web3.NewWeb3(providers.NewWebSocketProvider(node.URL()))
+	defer connection.Provider.Close()
+
+	sub, err := connection.Eth.SubscribeNewHeads()
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	for number := 1; number <= 3; number++ {
+		node.Notify(sub.ID(), map[string]interface{}{
+			"number":     fmt.Sprintf("0x%x", number),
+			"hash":       "0xaa",
+			"parentHash": "0xbb",
+			"gasUsed":    "0x0",
+			"nonce":      "0x0",
+			"timestamp":  "0x5",
+		})
+	}
+
+	for number := int64(1); number <= 3; number++ {
+		select {
+		case header := <-sub.Headers():
+			if header.Number.Int64() != number {
+				t.Errorf("Unexpected header [Expected %d | Got %s]", number, header.Number)
+			}
+		case err := <-sub.Err():
+			t.Error(err)
+			t.FailNow()
+		case <-time.After(2 * time.Second):
+			t.Error("Header not received")
+			t.FailNow()
+		}
+	}
+
+	if err := sub.Unsubscribe(); err != nil {
+		t.Error(err)
+	}
+
+	if len(node.Subscriptions()) != 0 {
+		t.Errorf("Subscription still alive on the node: %v", node.Subscriptions())
+	}
+
+	if _, ok := <-sub.Headers(); ok {
+		t.Error("Headers channel not closed by Unsubscribe")
+	}
+
+}
+
+func TestEthSubscribeLogs(t *testing.T) {
+
+	node := helpers.NewFakeNode(noCalls)
+	defer node.Close()
+
+	var connection = web3.NewWeb3(providers.NewWebSocketProvider(node.URL()))
+	defer connection.Provider.Close()
+
+	sub, err := connection.Eth.SubscribeLogs(&dto.FilterParameters{
+		Address: []string{"0x0000000000000000000000000000000000000001"},
+		Topics:  [][]string{{"0x01"}, nil, {"0x02", "0x03"}},
+	})
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	filter, _ := json.Marshal(dto.FilterParameters{Topics: [][]string{{"0x01"}, nil, {"0x02", "0x03"}}})
+	if string(filter) != `{"topics":["0x01",null,["0x02","0x03"]]}` {
+		t.Errorf("Unexpected filter encoding %s", filter)
+	}
+
+	node.Notify(sub.ID(), map[string]interface{}{
+		"address":          "0x0000000000000000000000000000000000000001",
+		"topics":           []string{"0x01"},
+		"data":             "0x",
+		"blockNumber":      "0x10",
+		"transactionHash":  "0xcc",
+		"transactionIndex": "0x0",
+		"blockHash":        "0xdd",
+		"logIndex":         "0x2",
+	})
+
+	select {
+	case log := <-sub.Logs():
+		if log.BlockNumber.Int64() != 16 || log.LogIndex.Int64() != 2 {
+			t.Errorf("Unexpected log %+v", log)
+		}
+	case err := <-sub.Err():
+		t.Error(err)
+	case <-time.After(2 * time.Second):
+		t.Error("Log not received")
+	}
+
+	// the subscription ends with an error when the connection is lost
+	node.DropConnections()
+
+	select {
+	case err := <-sub.Err():
+		if err == nil {
+			t.Error("Expected the connection error")
+		}
+	case <-time.After(2 * time.Second):
+		t.Error("Subscription not ended by the connection loss")
+	}
+
+}
+
+func TestEthSubscribeUnsupported(t *testing.T) {
+
+	var connection = web3.NewWeb3(providers.NewHTTPProvider("127.0.0.1:8545", 10, false))
+
+	if _, err := connection.Eth.SubscribeNewPendingTransactions(); err != customerror.SUBSCRIPTIONSUNSUPPORTED {
+		t.Errorf("Expected %v, got %v", customerror.SUBSCRIPTIONSUNSUPPORTED, err)
+	}
+
+}
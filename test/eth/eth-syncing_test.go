@@ -24,13 +24,12 @@ package test
 import (
 	"testing"
 
-	web3 "github.com/cellcycle/go-web3"
-	"github.com/cellcycle/go-web3/providers"
+	"github.com/cellcycle/go-web3/test/helpers"
 )
 
 func TestEthSyncing(t *testing.T) {
 
-	var connection = web3.NewWeb3(providers.NewHTTPProvider("127.0.0.1:8545", 10, false))
+	connection, mock := helpers.NewFixtureConnection(t, "eth-syncing")
 
 	_, err := connection.Eth.IsSyncing()
 
@@ -38,4 +37,9 @@ func TestEthSyncing(t *testing.T) {
 		t.Error(err)
 		t.FailNow()
 	}
+
+	helpers.ExpectRequests(t, mock,
+		`eth_syncing null`,
+	)
+
 }
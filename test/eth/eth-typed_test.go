@@ -0,0 +1,127 @@
+/********************************************************************************
+   This file is part of go-web3.
+   go-web3 is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-web3 is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-web3.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file eth-typed_test.go
+ */
+
+package test
+
+import (
+	"math/big"
+	"testing"
+
+	web3 "github.com/cellcycle/go-web3"
+	"github.com/cellcycle/go-web3/complex/types"
+	"github.com/cellcycle/go-web3/dto"
+	"github.com/cellcycle/go-web3/eth/block"
+	"github.com/cellcycle/go-web3/providers"
+	"github.com/cellcycle/go-web3/test/helpers"
+)
+
+const (
+	typedAccount = "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"
+	typedHash    = "0x9c22ff5f21f0b81b113e63f7db6da94fedef11b2119b4088b89664fb9a3cb658"
+)
+
+func TestEthTypedVariants(t *testing.T) {
+
+	mock := providers.NewMockProvider()
+	mock.On("eth_getBalance").Return("0x10")
+	mock.On("eth_accounts").Return([]interface{}{"0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed"})
+	mock.On("eth_sendTransaction").Return(typedHash)
+	mock.On("eth_getTransactionReceipt").Return(map[string]interface{}{
+		"transactionHash":   typedHash,
+		"transactionIndex":  "0x0",
+		"blockHash":         typedHash,
+		"blockNumber":       "0x1",
+		"from":              "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed",
+		"to":                nil,
+		"cumulativeGasUsed": "0x5208",
+		"gasUsed":           "0x5208",
+		"contractAddress":   "0xfb6916095ca1df60bb79ce92ce3ea74c37c5d359",
+		"logs":              []interface{}{},
+		"logsBloom":         "0x",
+		"status":            "0x1",
+	})
+
+	connection := web3.NewWeb3(mock)
+	account := types.MustParseAddress(typedAccount)
+
+	balance, err := connection.Eth.GetBalanceTyped(account, block.LATEST)
+	if err != nil || balance.Cmp(big.NewInt(16)) != 0 {
+		t.Errorf("Unexpected balance %v %v", balance, err)
+	}
+
+	accounts, err := connection.Eth.ListAccountsTyped()
+	if err != nil || len(accounts) != 1 || accounts[0] != account {
+		t.Errorf("Unexpected accounts %v %v", accounts, err)
+	}
+
+	transaction := new(dto.TransactionParameters)
+	transaction.SetFrom(account)
+	transaction.Value = big.NewInt(1)
+
+	hash, err := connection.Eth.SendTransactionTyped(transaction)
+	if err != nil || hash.Hex() != typedHash {
+		t.Fatalf("Unexpected hash %s %v", hash, err)
+	}
+
+	receipt, err := connection.Eth.GetTransactionReceiptTyped(hash)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	contract, err := receipt.ParseContractAddress()
+	if err != nil || contract == nil || contract.Hex() != "0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359" {
+		t.Errorf("Unexpected contract address %v %v", contract, err)
+	}
+
+	if to, err := receipt.ParseTo(); err != nil || to != nil {
+		t.Errorf("Expected no receiver for a contract creation, got %v %v", to, err)
+	}
+
+	if from, err := receipt.ParseFrom(); err != nil || from != account {
+		t.Errorf("Unexpected sender %s %v", from, err)
+	}
+
+	helpers.ExpectRequests(t, mock,
+		`eth_getBalance ["0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed","latest"]`,
+		`eth_accounts null`,
+		`eth_sendTransaction [{"from":"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed","value":"0x1"}]`,
+		`eth_getTransactionReceipt ["0x9c22ff5f21f0b81b113e63f7db6da94fedef11b2119b4088b89664fb9a3cb658"]`,
+	)
+
+}
+
+func TestEthTypedInvalidResult(t *testing.T) {
+
+	mock := providers.NewMockProvider()
+	mock.On("eth_coinbase").Return("0x01")
+
+	if _, err := web3.NewWeb3(mock).Eth.GetCoinbaseTyped(); err == nil {
+		t.Error("Expected an error for an invalid address")
+	}
+
+	log := dto.TransactionLogs{Address: typedAccount, Topics: []string{typedHash, "0x01"}}
+
+	if address, err := log.ParseAddress(); err != nil || address.Hex() != typedAccount {
+		t.Errorf("Unexpected log address %s %v", address, err)
+	}
+
+	if _, err := log.ParseTopics(); err == nil {
+		t.Error("Expected an error for an invalid topic")
+	}
+
+}
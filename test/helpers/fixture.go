@@ -0,0 +1,74 @@
+package helpers
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	web3 "github.com/cellcycle/go-web3"
+	"github.com/cellcycle/go-web3/providers"
+)
+
+// RecordEnv - The environment variable holding the node address the fixtures are recorded against
+const RecordEnv = "WEB3_RECORD"
+
+// FixturePath - The fixture file of name, relative to the test packages
+func FixturePath(name string) string {
+	return filepath.Join("..", "resources", "fixtures", name+".json")
+}
+
+// NewFixtureConnection - A connection replaying the fixture file of name. When
+// WEB3_RECORD holds the address of a node, e.g. http://127.0.0.1:8545, the
+// requests are sent to the node and the fixture is written again at the end
+// of the test. A replayed test fails when some recorded requests were not sent.
+func NewFixtureConnection(t *testing.T, name string) (*web3.Web3, *providers.MockProvider) {
+
+	t.Helper()
+
+	path := FixturePath(name)
+
+	if address := os.Getenv(RecordEnv); address != "" {
+
+		recorder := providers.NewRecordingProvider(providers.NewHTTPProviderURL(address, 10), path)
+
+		t.Cleanup(func() {
+			if err := recorder.Close(); err != nil {
+				t.Errorf("Fixture %s not written: %v", path, err)
+			}
+		})
+
+		return web3.NewWeb3(recorder), recorder
+	}
+
+	mock, err := providers.NewReplayProvider(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		if unused := mock.Unused(); len(unused) > 0 && !t.Failed() {
+			t.Errorf("Recorded requests not sent: %v", unused)
+		}
+	})
+
+	return web3.NewWeb3(mock), mock
+}
+
+// ExpectRequests - Fails t unless the requests received by mock are expected,
+// each written as the method followed by the JSON params: `eth_getBalance ["0x01","latest"]`
+func ExpectRequests(t *testing.T, mock *providers.MockProvider, expected ...string) {
+
+	t.Helper()
+
+	requests := mock.Requests()
+
+	var sent []string
+	for _, request := range requests {
+		sent = append(sent, request.String())
+	}
+
+	if strings.Join(sent, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Unexpected requests\nExpected:\n%s\nGot:\n%s", strings.Join(expected, "\n"), strings.Join(sent, "\n"))
+	}
+}
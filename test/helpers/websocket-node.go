@@ -0,0 +1,141 @@
+package helpers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	"golang.org/x/net/websocket"
+)
+
+// NodeHandler answers a JSON-RPC call of a FakeNode with its result
+type NodeHandler func(node *FakeNode, method string, params json.RawMessage) (interface{}, error)
+
+// FakeNode - An in-process websocket JSON-RPC endpoint for the provider tests
+type FakeNode struct {
+	Server  *httptest.Server
+	handler NodeHandler
+
+	mutex         sync.Mutex
+	connections   []*websocket.Conn
+	subscriptions map[string]*websocket.Conn
+	lastID        int
+	writeMutex    sync.Mutex
+}
+
+// NewFakeNode starts a websocket node answering every call with handler.
+// The eth_subscribe and eth_unsubscribe calls are handled by the node itself.
+func NewFakeNode(handler NodeHandler) *FakeNode {
+	node := &FakeNode{handler: handler, subscriptions: make(map[string]*websocket.Conn)}
+	node.Server = httptest.NewServer(websocket.Handler(node.serve))
+	return node
+}
+
+// URL - The ws:// address of the node
+func (node *FakeNode) URL() string {
+	return "ws" + strings.TrimPrefix(node.Server.URL, "http")
+}
+
+// Subscriptions - The ids of the live subscriptions
+func (node *FakeNode) Subscriptions() []string {
+	node.mutex.Lock()
+	defer node.mutex.Unlock()
+	ids := make([]string, 0, len(node.subscriptions))
+	for id := range node.subscriptions {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// Notify pushes result to the subscription id
+func (node *FakeNode) Notify(id string, result interface{}) error {
+
+	node.mutex.Lock()
+	ws, ok := node.subscriptions[id]
+	node.mutex.Unlock()
+
+	if !ok {
+		return fmt.Errorf("unknown subscription %s", id)
+	}
+
+	return node.send(ws, map[string]interface{}{
+		"jsonrpc": "2.0",
+		"method":  "eth_subscription",
+		"params":  map[string]interface{}{"subscription": id, "result": result},
+	})
+}
+
+// DropConnections closes every open connection, as a restarting node would
+func (node *FakeNode) DropConnections() {
+	node.mutex.Lock()
+	connections := node.connections
+	node.connections = nil
+	node.subscriptions = make(map[string]*websocket.Conn)
+	node.mutex.Unlock()
+
+	for _, ws := range connections {
+		ws.Close()
+	}
+}
+
+// Close stops the node
+func (node *FakeNode) Close() {
+	node.DropConnections()
+	node.Server.Close()
+}
+
+func (node *FakeNode) send(ws *websocket.Conn, message interface{}) error {
+	node.writeMutex.Lock()
+	defer node.writeMutex.Unlock()
+	return websocket.JSON.Send(ws, message)
+}
+
+func (node *FakeNode) serve(ws *websocket.Conn) {
+
+	node.mutex.Lock()
+	node.connections = append(node.connections, ws)
+	node.mutex.Unlock()
+
+	for {
+		var request struct {
+			ID     int             `json:"id"`
+			Method string          `json:"method"`
+			Params json.RawMessage `json:"params"`
+		}
+
+		if err := websocket.JSON.Receive(ws, &request); err != nil {
+			return
+		}
+
+		response := map[string]interface{}{"jsonrpc": "2.0", "id": request.ID}
+
+		switch request.Method {
+		case "eth_subscribe":
+			node.mutex.Lock()
+			node.lastID++
+			id := fmt.Sprintf("0x%x", node.lastID)
+			node.subscriptions[id] = ws
+			node.mutex.Unlock()
+			response["result"] = id
+		case "eth_unsubscribe":
+			var ids []string
+			json.Unmarshal(request.Params, &ids)
+			node.mutex.Lock()
+			_, ok := node.subscriptions[ids[0]]
+			delete(node.subscriptions, ids[0])
+			node.mutex.Unlock()
+			response["result"] = ok
+		default:
+			result, err := node.handler(node, request.Method, request.Params)
+			if err != nil {
+				response["error"] = map[string]interface{}{"code": -32000, "message": err.Error()}
+			} else {
+				response["result"] = result
+			}
+		}
+
+		node.send(ws, response)
+	}
+}
@@ -24,13 +24,12 @@ package test
 import (
 	"testing"
 
-	web3 "github.com/cellcycle/go-web3"
-	"github.com/cellcycle/go-web3/providers"
+	"github.com/cellcycle/go-web3/test/helpers"
 )
 
 func TestNetPeerCount(t *testing.T) {
 
-	var connection = web3.NewWeb3(providers.NewHTTPProvider("127.0.0.1:8545", 10, false))
+	connection, mock := helpers.NewFixtureConnection(t, "net-getpeercount")
 
 	peers, err := connection.Net.GetPeerCount()
 
@@ -41,4 +40,8 @@ func TestNetPeerCount(t *testing.T) {
 
 	t.Log(peers.Uint64())
 
+	helpers.ExpectRequests(t, mock,
+		`net_peerCount null`,
+	)
+
 }
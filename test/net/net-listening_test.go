@@ -25,13 +25,12 @@ import (
 	"errors"
 	"testing"
 
-	web3 "github.com/cellcycle/go-web3"
-	"github.com/cellcycle/go-web3/providers"
+	"github.com/cellcycle/go-web3/test/helpers"
 )
 
 func TestNetListening(t *testing.T) {
 
-	var connection = web3.NewWeb3(providers.NewHTTPProvider("127.0.0.1:8545", 10, false))
+	connection, mock := helpers.NewFixtureConnection(t, "net-listening")
 
 	listening, err := connection.Net.IsListening()
 
@@ -44,4 +43,9 @@ func TestNetListening(t *testing.T) {
 		t.Error(errors.New("Not listening"))
 		t.Fail()
 	}
+
+	helpers.ExpectRequests(t, mock,
+		`net_listening null`,
+	)
+
 }
@@ -26,16 +26,15 @@ import (
 	"sort"
 	"testing"
 
-	web3 "github.com/cellcycle/go-web3"
-	"github.com/cellcycle/go-web3/providers"
+	"github.com/cellcycle/go-web3/test/helpers"
 )
 
 func TestNetVersion(t *testing.T) {
 
-	var connection = web3.NewWeb3(providers.NewHTTPProvider("127.0.0.1:8545", 10, false))
+	connection, mock := helpers.NewFixtureConnection(t, "net-version")
 
 	//Possible options
-    po := []string{"1", "1337", "2", "3", "4", "42"}
+	po := []string{"1", "1337", "2", "3", "4", "42"}
 
 	version, err := connection.Net.GetVersion()
 
@@ -51,4 +50,8 @@ func TestNetVersion(t *testing.T) {
 		t.Fail()
 	}
 
+	helpers.ExpectRequests(t, mock,
+		`net_version null`,
+	)
+
 }
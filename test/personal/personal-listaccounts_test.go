@@ -23,13 +23,12 @@ package test
 import (
 	"testing"
 
-	"github.com/cellcycle/go-web3"
-	"github.com/cellcycle/go-web3/providers"
+	"github.com/cellcycle/go-web3/test/helpers"
 )
 
 func TestPersonalListAccounts(t *testing.T) {
 
-	var connection = web3.NewWeb3(providers.NewHTTPProvider("127.0.0.1:8545", 10, false))
+	connection, mock := helpers.NewFixtureConnection(t, "personal-listaccounts")
 
 	_, err := connection.Personal.ListAccounts()
 
@@ -38,4 +37,8 @@ func TestPersonalListAccounts(t *testing.T) {
 		t.Fail()
 	}
 
+	helpers.ExpectRequests(t, mock,
+		`personal_listAccounts null`,
+	)
+
 }
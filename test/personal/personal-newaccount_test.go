@@ -23,13 +23,12 @@ package test
 import (
 	"testing"
 
-	"github.com/cellcycle/go-web3"
-	"github.com/cellcycle/go-web3/providers"
+	"github.com/cellcycle/go-web3/test/helpers"
 )
 
 func TestPersonalNewAccount(t *testing.T) {
 
-	var connection = web3.NewWeb3(providers.NewHTTPProvider("127.0.0.1:8545", 10, false))
+	connection, mock := helpers.NewFixtureConnection(t, "personal-newaccount")
 	address, err := connection.Personal.NewAccount("password")
 
 	if err != nil {
@@ -38,4 +37,9 @@ func TestPersonalNewAccount(t *testing.T) {
 	}
 
 	t.Log(address)
+
+	helpers.ExpectRequests(t, mock,
+		`personal_newAccount ["password"]`,
+	)
+
 }
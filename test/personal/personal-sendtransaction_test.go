@@ -23,15 +23,14 @@ package test
 import (
 	"testing"
 
-	"github.com/cellcycle/go-web3"
 	"github.com/cellcycle/go-web3/dto"
-	"github.com/cellcycle/go-web3/providers"
+	"github.com/cellcycle/go-web3/test/helpers"
 	"math/big"
 )
 
 func TestPersonalSendTransaction(t *testing.T) {
 
-	var connection = web3.NewWeb3(providers.NewHTTPProvider("127.0.0.1:8545", 10, false))
+	connection, mock := helpers.NewFixtureConnection(t, "personal-sendtransaction")
 
 	coinbase, err := connection.Eth.GetCoinbase()
 
@@ -55,4 +54,9 @@ func TestPersonalSendTransaction(t *testing.T) {
 
 	t.Log(txID)
 
+	helpers.ExpectRequests(t, mock,
+		`eth_coinbase null`,
+		`personal_sendTransaction [{"from":"0x7e5f4552091a69125d5dfcb7b8c2659029395bdf","to":"0x7e5f4552091a69125d5dfcb7b8c2659029395bdf","gas":"0x9c40","value":"0xa"},""]`,
+	)
+
 }
@@ -0,0 +1,56 @@
+/********************************************************************************
+   This file is part of go-web3.
+   go-web3 is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-web3 is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-web3.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file personal-typed_test.go
+ */
+
+package test
+
+import (
+	"testing"
+
+	web3 "github.com/cellcycle/go-web3"
+	"github.com/cellcycle/go-web3/providers"
+	"github.com/cellcycle/go-web3/test/helpers"
+)
+
+func TestPersonalTypedVariants(t *testing.T) {
+
+	mock := providers.NewMockProvider()
+	mock.On("personal_newAccount").Return("0x7e5f4552091a69125d5dfcb7b8c2659029395bdf")
+	mock.On("personal_unlockAccount").Return(true)
+
+	connection := web3.NewWeb3(mock)
+
+	account, err := connection.Personal.NewAccountTyped("secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if account.Hex() != "0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf" {
+		t.Errorf("Unexpected account %s", account.Hex())
+	}
+
+	unlocked, err := connection.Personal.UnlockAccountTyped(account, "secret", 100)
+	if err != nil || !unlocked {
+		t.Errorf("Unexpected unlock %v %v", unlocked, err)
+	}
+
+	helpers.ExpectRequests(t, mock,
+		`personal_newAccount ["secret"]`,
+		`personal_unlockAccount ["0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf","secret",100]`,
+	)
+
+}
@@ -24,13 +24,12 @@ import (
 	"errors"
 	"testing"
 
-	"github.com/cellcycle/go-web3"
-	"github.com/cellcycle/go-web3/providers"
+	"github.com/cellcycle/go-web3/test/helpers"
 )
 
 func TestPersonalUnlockAccount(t *testing.T) {
 
-	var connection = web3.NewWeb3(providers.NewHTTPProvider("127.0.0.1:8545", 10, false))
+	connection, mock := helpers.NewFixtureConnection(t, "personal-unlockaccount")
 
 	accounts, err := connection.Personal.ListAccounts()
 
@@ -51,4 +50,9 @@ func TestPersonalUnlockAccount(t *testing.T) {
 		t.FailNow()
 	}
 
+	helpers.ExpectRequests(t, mock,
+		`personal_listAccounts null`,
+		`personal_unlockAccount ["0x7e5f4552091a69125d5dfcb7b8c2659029395bdf","",100]`,
+	)
+
 }
@@ -0,0 +1,169 @@
+/********************************************************************************
+   This file is part of go-web3.
+   go-web3 is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-web3 is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-web3.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file auth_test.go
+ */
+package test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cellcycle/go-web3/dto"
+	"github.com/cellcycle/go-web3/providers"
+	"golang.org/x/net/websocket"
+)
+
+const jwtSecretHex = "0x7365637265747365637265747365637265747365637265747365637265743332"
+
+// verifyJWT checks the HS256 signature of the bearer token of header and returns its iat claim
+func verifyJWT(t *testing.T, header http.Header, secret []byte) int64 {
+
+	token := strings.TrimPrefix(header.Get("Authorization"), "Bearer ")
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		t.Fatalf("Unexpected token %q", token)
+	}
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	if base64.RawURLEncoding.EncodeToString(mac.Sum(nil)) != parts[2] {
+		t.Fatalf("Invalid signature of %s", token)
+	}
+
+	decoded, _ := base64.RawURLEncoding.DecodeString(parts[0])
+	if string(decoded) != `{"alg":"HS256","typ":"JWT"}` {
+		t.Errorf("Unexpected JWT header %s", decoded)
+	}
+
+	var claims struct {
+		IssuedAt int64 `json:"iat"`
+	}
+	decoded, _ = base64.RawURLEncoding.DecodeString(parts[1])
+	if err := json.Unmarshal(decoded, &claims); err != nil {
+		t.Fatal(err)
+	}
+
+	return claims.IssuedAt
+}
+
+func TestJWTAuthFromFile(t *testing.T) {
+
+	directory, err := ioutil.TempDir("", "jwt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(directory)
+
+	path := filepath.Join(directory, "jwtsecret")
+	ioutil.WriteFile(path, []byte(jwtSecretHex+"\n"), 0600)
+
+	auth, err := providers.NewJWTAuthFromFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// a new token is issued for every request
+	issued := time.Unix(1700000000, 0)
+	auth.Now = func() time.Time {
+		issued = issued.Add(time.Second)
+		return issued
+	}
+
+	var mutex sync.Mutex
+	var headers []http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		headers = append(headers, r.Header.Clone())
+		mutex.Unlock()
+		w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"0x1"}`))
+	}))
+	defer server.Close()
+
+	provider := providers.NewHTTPProviderURL(server.URL, 10).WithAuth(auth)
+
+	for index := 0; index < 2; index++ {
+		if err := provider.SendRequest(&dto.RequestResult{}, "eth_blockNumber", nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	secret := []byte("secretsecretsecretsecretsecret32")
+	if first, second := verifyJWT(t, headers[0], secret), verifyJWT(t, headers[1], secret); first != 1700000001 || second != 1700000002 {
+		t.Errorf("Unexpected issue times %d %d", first, second)
+	}
+
+	ioutil.WriteFile(path, []byte("0xabcd"), 0600)
+	if _, err := providers.NewJWTAuthFromFile(path); err == nil {
+		t.Error("Expected an error for a short secret")
+	}
+
+	ioutil.WriteFile(path, []byte("not hex"), 0600)
+	if _, err := providers.NewJWTAuthFromFile(path); err == nil {
+		t.Error("Expected an error for a secret which isn't hex")
+	}
+
+}
+
+func TestWebSocketAuth(t *testing.T) {
+
+	var mutex sync.Mutex
+	var handshakes []http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		handshakes = append(handshakes, r.Header.Clone())
+		mutex.Unlock()
+
+		websocket.Handler(func(ws *websocket.Conn) {
+			var call struct {
+				ID int `json:"id"`
+			}
+			for websocket.JSON.Receive(ws, &call) == nil {
+				websocket.JSON.Send(ws, map[string]interface{}{"jsonrpc": "2.0", "id": call.ID, "result": "0x1"})
+			}
+		}).ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	provider := providers.NewWebSocketProviderWithOptions("ws"+strings.TrimPrefix(server.URL, "http"), providers.WebSocketOptions{
+		Header: http.Header{"X-Api-Key": {"key"}},
+		Auth:   providers.MultiAuth(providers.BasicAuth("alice", "secret"), providers.BearerToken("token")),
+	})
+	defer provider.Close()
+
+	if err := provider.SendRequest(&dto.RequestResult{}, "eth_blockNumber", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	// the later authenticator wins
+	if len(handshakes) != 1 || handshakes[0].Get("Authorization") != "Bearer token" || handshakes[0].Get("X-Api-Key") != "key" {
+		t.Errorf("Unexpected handshakes %v", handshakes)
+	}
+
+}
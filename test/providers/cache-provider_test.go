@@ -0,0 +1,140 @@
+/********************************************************************************
+   This file is part of go-web3.
+   go-web3 is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-web3 is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-web3.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file cache-provider_test.go
+ */
+package test
+
+import (
+	"context"
+	"testing"
+
+	web3 "github.com/cellcycle/go-web3"
+	"github.com/cellcycle/go-web3/dto"
+	"github.com/cellcycle/go-web3/eth/block"
+	"github.com/cellcycle/go-web3/providers"
+)
+
+const cachedAddress = "0x0000000000000000000000000000000000000001"
+
+func countRequests(mock *providers.MockProvider, method string) int {
+	count := 0
+	for _, request := range mock.Requests() {
+		if request.Method == method {
+			count++
+		}
+	}
+	return count
+}
+
+func TestCacheProviderFinality(t *testing.T) {
+
+	// the head is 0x100, the blocks up to 0xc0 are final
+	mock := providers.NewMockProvider()
+	mock.On("eth_blockNumber").Return("0x100")
+	mock.On("eth_getBalance").Return("0x2a")
+	mock.On("eth_getBlockByHash").Return(map[string]string{"number": "0x10", "hash": "0xaa", "parentHash": "0xbb"})
+	mock.On("eth_getTransactionReceipt", "0x01").Return(map[string]string{"transactionHash": "0x01", "blockNumber": "0x10", "status": "0x1"})
+	mock.On("eth_getTransactionReceipt", "0x02").Return(map[string]string{"transactionHash": "0x02", "blockNumber": "0xff", "status": "0x1"})
+	mock.On("eth_getTransactionReceipt", "0x03").Return(nil)
+
+	cache := providers.NewCacheProvider(mock, providers.CacheOptions{})
+	connection := web3.NewWeb3(cache)
+
+	for index := 0; index < 2; index++ {
+		connection.Eth.GetBlockByHash("0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", false)
+		connection.Eth.GetBalance(cachedAddress, block.LATEST)
+		connection.Eth.GetBalance(cachedAddress, block.PENDING)
+		connection.Eth.GetBalance(cachedAddress, "0xc0")
+		connection.Eth.GetBalance(cachedAddress, "0xc1")
+
+		for _, hash := range []string{"0x01", "0x02", "0x03"} {
+			cache.SendRequest(&dto.RequestResult{}, "eth_getTransactionReceipt", []string{hash})
+		}
+	}
+
+	balance, err := connection.Eth.GetBalance(cachedAddress, "0xc0")
+	if err != nil || balance.Int64() != 42 {
+		t.Errorf("Unexpected cached balance %v %v", balance, err)
+	}
+
+	if sent := countRequests(mock, "eth_getBlockByHash"); sent != 1 {
+		t.Errorf("Block by hash sent %d times", sent)
+	}
+
+	// latest, pending and 0xc1 twice, 0xc0 once
+	if sent := countRequests(mock, "eth_getBalance"); sent != 7 {
+		t.Errorf("Balances sent %d times", sent)
+	}
+
+	// the receipt of a final block is cached, not the recent or the missing one
+	if sent := countRequests(mock, "eth_getTransactionReceipt"); sent != 5 {
+		t.Errorf("Receipts sent %d times", sent)
+	}
+
+	stats := cache.Stats()
+	if stats.Hits != 4 || stats.Misses != 7 || stats.Bypassed != 6 || stats.Entries != 3 {
+		t.Errorf("Unexpected stats %+v", stats)
+	}
+
+}
+
+func TestCacheProviderEviction(t *testing.T) {
+
+	mock := providers.NewMockProvider()
+	mock.On("eth_getBlockByHash").Return(map[string]string{"number": "0x10", "hash": "0xaa", "parentHash": "0xbb"})
+
+	cache := providers.NewCacheProvider(mock, providers.CacheOptions{Size: 2})
+
+	get := func(hash string) {
+		cache.SendRequest(&dto.RequestResult{}, "eth_getBlockByHash", []interface{}{hash, false})
+	}
+
+	get("0x01")
+	get("0x02")
+	get("0x01")
+	// 0x02 is the least recently used
+	get("0x03")
+	get("0x01")
+	get("0x02")
+
+	if sent := countRequests(mock, "eth_getBlockByHash"); sent != 4 {
+		t.Errorf("Blocks sent %d times", sent)
+	}
+
+	if stats := cache.Stats(); stats.Evictions != 2 || stats.Entries != 2 || stats.Hits != 2 {
+		t.Errorf("Unexpected stats %+v", stats)
+	}
+
+	// a batch is answered from the cache, the missing calls are sent and kept
+	batch := []providers.BatchElem{
+		{Method: "eth_getBlockByHash", Params: []interface{}{"0x01", false}},
+		{Method: "eth_getBlockByHash", Params: []interface{}{"0x04", false}},
+	}
+	if err := providers.SendBatchCtx(context.Background(), cache, batch); err != nil {
+		t.Fatal(err)
+	}
+
+	if batch[0].Error != nil || batch[1].Error != nil || batch[1].Result.Result == nil {
+		t.Errorf("Unexpected batch %+v", batch)
+	}
+
+	get("0x04")
+
+	if sent := countRequests(mock, "eth_getBlockByHash"); sent != 5 {
+		t.Errorf("Blocks sent %d times", sent)
+	}
+
+}
@@ -0,0 +1,163 @@
+/********************************************************************************
+   This file is part of go-web3.
+   go-web3 is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-web3 is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-web3.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file http-errors_test.go
+ */
+package test
+
+import (
+	"compress/gzip"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/cellcycle/go-web3/constants"
+	"github.com/cellcycle/go-web3/dto"
+	"github.com/cellcycle/go-web3/providers"
+)
+
+func TestHTTPProviderStatusError(t *testing.T) {
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "abc")
+		w.WriteHeader(http.StatusBadGateway)
+		w.Write([]byte("<html>" + strings.Repeat("bad gateway ", 1000) + "</html>"))
+	}))
+	defer server.Close()
+
+	provider := providers.NewHTTPProviderURL(server.URL+"/v3/key", 10)
+
+	err := provider.SendRequest(&dto.RequestResult{}, "eth_blockNumber", nil)
+
+	var httpError *providers.HTTPError
+	if !errors.As(err, &httpError) {
+		t.Fatalf("Expected an HTTPError, got %v", err)
+	}
+
+	if httpError.StatusCode != http.StatusBadGateway || httpError.Header.Get("X-Request-Id") != "abc" {
+		t.Errorf("Unexpected error %+v", httpError)
+	}
+
+	if len(httpError.Body) != 4096 || !strings.HasPrefix(err.Error(), "HTTP 502 Bad Gateway: <html>bad gateway") {
+		t.Errorf("Unexpected body of %d bytes in %.60s", len(httpError.Body), err)
+	}
+
+}
+
+func TestHTTPProviderRetryAfter(t *testing.T) {
+
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&hits, 1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"0x10"}`))
+	}))
+	defer server.Close()
+
+	// the transport never sends a request again, even a read
+	err := providers.NewHTTPProviderURL(server.URL, 10).SendRequest(&dto.RequestResult{}, "eth_blockNumber", nil)
+
+	var httpError *providers.HTTPError
+	if !errors.As(err, &httpError) || httpError.StatusCode != http.StatusTooManyRequests || atomic.LoadInt32(&hits) != 1 {
+		t.Fatalf("Expected the 429 response, got %v after %d requests", err, hits)
+	}
+
+	// the Retry middleware follows the Retry-After
+	atomic.StoreInt32(&hits, 0)
+	provider := providers.Chain(providers.NewHTTPProviderURL(server.URL, 10), providers.Retry(providers.RetryPolicy{}))
+
+	pointer := &dto.RequestResult{}
+	if err := provider.SendRequest(pointer, "eth_blockNumber", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if number, _ := pointer.ToBigInt(); number.Int64() != 16 || atomic.LoadInt32(&hits) != 2 {
+		t.Errorf("Unexpected result %v after %d requests", number, hits)
+	}
+
+	// a transaction is never sent twice
+	atomic.StoreInt32(&hits, 0)
+	if err := provider.SendRequest(&dto.RequestResult{}, "eth_sendRawTransaction", []string{"0x01"}); err == nil || atomic.LoadInt32(&hits) != 1 {
+		t.Errorf("Expected the 429 response of the transaction, got %v after %d requests", err, hits)
+	}
+
+}
+
+func TestHTTPProviderRateLimited(t *testing.T) {
+
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"jsonrpc":"2.0","id":1,"error":{"code":-32005,"message":"limit exceeded"}}`))
+	}))
+	defer server.Close()
+
+	provider := providers.Chain(providers.NewHTTPProviderURL(server.URL, 10), providers.Retry(providers.RetryPolicy{}))
+
+	err := provider.SendRequest(&dto.RequestResult{}, "eth_blockNumber", nil)
+
+	if !errors.Is(err, customerror.RATELIMITED) {
+		t.Errorf("Expected a rate limit error, got %v", err)
+	}
+
+	var rpcError *dto.RPCError
+	if !errors.As(err, &rpcError) || rpcError.Code != -32005 {
+		t.Errorf("JSON-RPC error of the body not reachable from %v", err)
+	}
+
+	var httpError *providers.HTTPError
+	if !errors.As(err, &httpError) || httpError.RetryAfter.Hours() != 1 {
+		t.Errorf("Unexpected Retry-After in %v", err)
+	}
+
+	if atomic.LoadInt32(&hits) != 1 {
+		t.Errorf("A Retry-After of an hour should not be waited, got %d requests", hits)
+	}
+
+}
+
+func TestHTTPProviderGzip(t *testing.T) {
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept-Encoding") != "gzip" {
+			t.Errorf("Unexpected Accept-Encoding %q", r.Header.Get("Accept-Encoding"))
+		}
+		w.Header().Set("Content-Encoding", "gzip")
+		writer := gzip.NewWriter(w)
+		writer.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"Geth/v1.8.0"}`))
+		writer.Close()
+	}))
+	defer server.Close()
+
+	provider := providers.NewHTTPProviderURLWithClient(server.URL, &http.Client{Transport: &http.Transport{DisableCompression: true}})
+
+	pointer := &dto.RequestResult{}
+	if err := provider.SendRequest(pointer, "web3_clientVersion", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if version, _ := pointer.ToString(); version != "Geth/v1.8.0" {
+		t.Errorf("Unexpected result %s", version)
+	}
+
+}
@@ -0,0 +1,269 @@
+/********************************************************************************
+   This file is part of go-web3.
+   go-web3 is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-web3 is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-web3.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file ipc-multiplex_test.go
+ */
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	web3 "github.com/cellcycle/go-web3"
+	"github.com/cellcycle/go-web3/dto"
+	"github.com/cellcycle/go-web3/providers"
+)
+
+// ipcNode echoes the first param of every call, out of order, and answers
+// eth_subscribe with a subscription that is notified right away
+type ipcNode struct {
+	endpoint    string
+	listener    net.Listener
+	connections int32
+
+	mutex sync.Mutex
+	conns []net.Conn
+}
+
+func newIPCNode(t *testing.T) *ipcNode {
+
+	dir, err := ioutil.TempDir("", "go-web3")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	node := &ipcNode{endpoint: filepath.Join(dir, "geth.ipc")}
+
+	node.listener, err = net.Listen("unix", node.endpoint)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	go func() {
+		for {
+			conn, err := node.listener.Accept()
+			if err != nil {
+				return
+			}
+			atomic.AddInt32(&node.connections, 1)
+			node.mutex.Lock()
+			node.conns = append(node.conns, conn)
+			node.mutex.Unlock()
+			go node.serve(conn)
+		}
+	}()
+
+	return node
+}
+
+func (node *ipcNode) serve(conn net.Conn) {
+
+	var writeMutex sync.Mutex
+	write := func(message interface{}) {
+		encoded, _ := json.Marshal(message)
+		writeMutex.Lock()
+		conn.Write(encoded)
+		writeMutex.Unlock()
+	}
+
+	decoder := json.NewDecoder(conn)
+
+	for {
+		var request struct {
+			ID     int           `json:"id"`
+			Method string        `json:"method"`
+			Params []interface{} `json:"params"`
+		}
+
+		if err := decoder.Decode(&request); err != nil {
+			return
+		}
+
+		if request.Method == "eth_subscribe" {
+			write(map[string]interface{}{"jsonrpc": "2.0", "id": request.ID, "result": "0xabc"})
+			write(map[string]interface{}{
+				"jsonrpc": "2.0",
+				"method":  "eth_subscription",
+				"params":  map[string]interface{}{"subscription": "0xabc", "result": "0x1234"},
+			})
+			continue
+		}
+
+		go func() {
+			time.Sleep(time.Duration(rand.Intn(5)) * time.Millisecond)
+			write(map[string]interface{}{"jsonrpc": "2.0", "id": request.ID, "result": request.Params[0]})
+		}()
+	}
+}
+
+// drop closes the open connections, as a restarting node would
+func (node *ipcNode) drop() {
+	node.mutex.Lock()
+	defer node.mutex.Unlock()
+	for _, conn := range node.conns {
+		conn.Close()
+	}
+	node.conns = nil
+}
+
+func (node *ipcNode) close() {
+	node.listener.Close()
+	node.drop()
+	os.RemoveAll(filepath.Dir(node.endpoint))
+}
+
+func TestIPCProviderMultiplexing(t *testing.T) {
+
+	node := newIPCNode(t)
+	defer node.close()
+
+	provider := providers.NewIPCProvider(node.endpoint)
+	defer provider.Close()
+
+	var wait sync.WaitGroup
+
+	for index := 0; index < 100; index++ {
+		wait.Add(1)
+		go func(index int) {
+			defer wait.Done()
+
+			expected := fmt.Sprintf("call-%d", index)
+			pointer := &dto.RequestResult{}
+
+			if err := provider.SendRequest(pointer, "test_echo", []string{expected}); err != nil {
+				t.Error(err)
+				return
+			}
+
+			if result, _ := pointer.ToString(); result != expected {
+				t.Errorf("Response mismatch [Expected %s | Got %s]", expected, result)
+			}
+		}(index)
+	}
+
+	wait.Wait()
+
+	if atomic.LoadInt32(&node.connections) != 1 {
+		t.Errorf("Expected a single connection, got %d", node.connections)
+	}
+
+}
+
+func TestIPCProviderRedials(t *testing.T) {
+
+	node := newIPCNode(t)
+	defer node.close()
+
+	provider := providers.NewIPCProvider(node.endpoint)
+	defer provider.Close()
+
+	if err := provider.SendRequest(&dto.RequestResult{}, "test_echo", []string{"first"}); err != nil {
+		t.Fatal(err)
+	}
+
+	node.drop()
+
+	// the broken connection is noticed by the reader, then the next call dials again
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		err := provider.SendRequest(&dto.RequestResult{}, "test_echo", []string{"second"})
+		if err == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal(err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if atomic.LoadInt32(&node.connections) != 2 {
+		t.Errorf("Expected a second connection, got %d", node.connections)
+	}
+
+}
+
+func TestIPCProviderSubscription(t *testing.T) {
+
+	node := newIPCNode(t)
+	defer node.close()
+
+	var connection = web3.NewWeb3(providers.NewIPCProvider(node.endpoint))
+	defer connection.Provider.Close()
+
+	sub, err := connection.Eth.SubscribeNewPendingTransactions()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case hash := <-sub.Hashes():
+		if hash != "0x1234" {
+			t.Errorf("Unexpected hash %s", hash)
+		}
+	case err := <-sub.Err():
+		t.Fatal(err)
+	case <-time.After(2 * time.Second):
+		t.Fatal("Notification not received")
+	}
+
+}
+
+func TestIPCProviderReconnects(t *testing.T) {
+
+	node := newIPCNode(t)
+	defer node.close()
+
+	provider := providers.NewIPCProviderWithOptions(node.endpoint, providers.IPCOptions{
+		DialTimeout: time.Second,
+		Reconnect:   &fastReconnect,
+	})
+	defer provider.Close()
+
+	sub, err := provider.SubscribeCtx(context.Background(), "eth", "newPendingTransactions")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the node notifies each subscription once, the second notification comes
+	// from the subscription created again on the new connection
+	for index := 0; index < 2; index++ {
+		select {
+		case notification := <-sub.Notifications():
+			if string(notification) != `"0x1234"` {
+				t.Fatalf("Unexpected notification %s", notification)
+			}
+		case err := <-sub.Err():
+			t.Fatal(err)
+		case <-time.After(2 * time.Second):
+			t.Fatalf("Notification %d not received", index)
+		}
+		node.drop()
+	}
+
+	if atomic.LoadInt32(&node.connections) < 2 {
+		t.Errorf("Expected the connection restored, got %d connections", node.connections)
+	}
+
+}
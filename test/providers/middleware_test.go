@@ -0,0 +1,193 @@
+/********************************************************************************
+   This file is part of go-web3.
+   go-web3 is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-web3 is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-web3.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file middleware_test.go
+ */
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	web3 "github.com/cellcycle/go-web3"
+	"github.com/cellcycle/go-web3/dto"
+	"github.com/cellcycle/go-web3/providers"
+)
+
+// flakyProvider fails the first calls of every method, then answers "0x1"
+// (true to personal_unlockAccount)
+type flakyProvider struct {
+	failures int
+	err      error
+
+	mutex sync.Mutex
+	calls map[string]int
+}
+
+func newFlakyProvider(failures int, err error) *flakyProvider {
+	return &flakyProvider{failures: failures, err: err, calls: make(map[string]int)}
+}
+
+func (provider *flakyProvider) SendRequest(v interface{}, method string, params interface{}) error {
+	return provider.SendRequestCtx(context.Background(), v, method, params)
+}
+
+func (provider *flakyProvider) SendRequestCtx(ctx context.Context, v interface{}, method string, params interface{}) error {
+
+	provider.mutex.Lock()
+	provider.calls[method]++
+	count := provider.calls[method]
+	provider.mutex.Unlock()
+
+	if count <= provider.failures {
+		return provider.err
+	}
+
+	if method == "personal_unlockAccount" {
+		return json.Unmarshal([]byte(`{"jsonrpc":"2.0","id":1,"result":true}`), v)
+	}
+
+	return json.Unmarshal([]byte(`{"jsonrpc":"2.0","id":1,"result":"0x1"}`), v)
+}
+
+func (provider *flakyProvider) Close() error { return nil }
+
+func (provider *flakyProvider) count(method string) int {
+	provider.mutex.Lock()
+	defer provider.mutex.Unlock()
+	return provider.calls[method]
+}
+
+var fastRetry = providers.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}
+
+func TestRetryMiddlewareReadMethods(t *testing.T) {
+
+	flaky := newFlakyProvider(2, errors.New("connection reset by peer"))
+	provider := providers.Chain(flaky, providers.Retry(fastRetry))
+
+	pointer := &dto.RequestResult{}
+	if err := provider.SendRequest(pointer, "eth_blockNumber", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if flaky.count("eth_blockNumber") != 3 {
+		t.Errorf("Expected 3 attempts, got %d", flaky.count("eth_blockNumber"))
+	}
+
+	// a transaction is never sent twice
+	if err := provider.SendRequest(pointer, "eth_sendRawTransaction", []string{"0x00"}); err == nil {
+		t.Error("Expected the transport error")
+	}
+
+	if flaky.count("eth_sendRawTransaction") != 1 {
+		t.Errorf("Transaction sent %d times", flaky.count("eth_sendRawTransaction"))
+	}
+
+}
+
+func TestRetryMiddlewareFinalErrors(t *testing.T) {
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	flaky := newFlakyProvider(5, nil)
+	flaky.err = providers.NewHTTPProviderURL(server.URL, 10).SendRequest(&dto.RequestResult{}, "eth_blockNumber", nil)
+
+	provider := providers.Chain(flaky, providers.Retry(fastRetry))
+
+	if err := provider.SendRequest(&dto.RequestResult{}, "eth_blockNumber", nil); err != flaky.err {
+		t.Errorf("Expected %v, got %v", flaky.err, err)
+	}
+
+	if flaky.count("eth_blockNumber") != 1 {
+		t.Errorf("A 400 status should not be retried, got %d attempts", flaky.count("eth_blockNumber"))
+	}
+
+}
+
+func TestRateLimiterMiddleware(t *testing.T) {
+
+	flaky := newFlakyProvider(0, nil)
+	provider := providers.Chain(flaky, providers.RateLimiter(
+		providers.RateLimit{},
+		map[string]providers.RateLimit{"eth_getLogs": {Rate: 20, Burst: 2}},
+	))
+
+	start := time.Now()
+	for index := 0; index < 4; index++ {
+		provider.SendRequest(&dto.RequestResult{}, "eth_getLogs", nil)
+	}
+
+	// the burst passes, then 2 calls wait for 50ms each
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Errorf("Calls not throttled, took %s", elapsed)
+	}
+
+	start = time.Now()
+	for index := 0; index < 50; index++ {
+		provider.SendRequest(&dto.RequestResult{}, "eth_blockNumber", nil)
+	}
+
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("Unlimited method throttled, took %s", elapsed)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := provider.SendRequestCtx(ctx, &dto.RequestResult{}, "eth_getLogs", nil); err != context.DeadlineExceeded {
+		t.Errorf("Expected the deadline while waiting for a token, got %v", err)
+	}
+
+}
+
+func TestLoggingMiddlewareRedaction(t *testing.T) {
+
+	var entries []providers.LogEntry
+
+	flaky := newFlakyProvider(0, nil)
+	connection := web3.NewWeb3(providers.Chain(flaky, providers.Logging(func(entry providers.LogEntry) {
+		entries = append(entries, entry)
+	})))
+
+	connection.Personal.UnlockAccount("0x0000000000000000000000000000000000000001", "hunter2", 10)
+	connection.Eth.GetBlockNumber()
+
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %d", len(entries))
+	}
+
+	params, _ := json.Marshal(entries[0].Params)
+	if string(params) != `["0x0000000000000000000000000000000000000001","[REDACTED]",10]` {
+		t.Errorf("Unexpected params %s", params)
+	}
+
+	if entries[1].Method != "eth_blockNumber" || entries[1].Err != nil || entries[1].Result == nil {
+		t.Errorf("Unexpected entry %+v", entries[1])
+	}
+
+	if redacted := providers.RedactParams("personal_unknown", []string{"secret"}); redacted.([]interface{})[0] != providers.Redacted {
+		t.Errorf("Params of an unknown personal method not redacted: %v", redacted)
+	}
+
+}
@@ -0,0 +1,141 @@
+/********************************************************************************
+   This file is part of go-web3.
+   go-web3 is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-web3 is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-web3.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file mock-provider_test.go
+ */
+package test
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	web3 "github.com/cellcycle/go-web3"
+	"github.com/cellcycle/go-web3/constants"
+	"github.com/cellcycle/go-web3/eth/block"
+	"github.com/cellcycle/go-web3/providers"
+)
+
+func TestMockProviderScriptedResponses(t *testing.T) {
+
+	mock := providers.NewMockProvider()
+	mock.On("eth_blockNumber").Return("0x10").Once()
+	mock.On("eth_blockNumber").Return("0x11")
+	mock.On("eth_getBalance", "0x0000000000000000000000000000000000000001", "latest").Return("0x2a")
+	mock.On("eth_getBalance").ReturnError(-32000, "nonce too low", nil)
+	mock.On("net_version").Fail(errors.New("connection refused"))
+
+	connection := web3.NewWeb3(mock)
+
+	for _, expected := range []int64{16, 17, 17} {
+		number, err := connection.Eth.GetBlockNumber()
+		if err != nil || number.Int64() != expected {
+			t.Errorf("Expected block %d, got %v %v", expected, number, err)
+		}
+	}
+
+	balance, err := connection.Eth.GetBalance("0x0000000000000000000000000000000000000001", block.LATEST)
+	if err != nil || balance.Int64() != 42 {
+		t.Errorf("Expected the scripted balance, got %v %v", balance, err)
+	}
+
+	// other params fall through to the response of any params
+	_, err = connection.Eth.GetBalance("0x0000000000000000000000000000000000000002", block.LATEST)
+	if !errors.Is(err, customerror.NONCETOOLOW) {
+		t.Errorf("Expected the scripted node error, got %v", err)
+	}
+
+	if _, err := connection.Net.GetVersion(); err == nil || err.Error() != "connection refused" {
+		t.Errorf("Expected the scripted failure, got %v", err)
+	}
+
+	if _, err := connection.Eth.GetGasPrice(); err == nil {
+		t.Error("Expected an error for an unscripted method")
+	}
+
+	requests := mock.Requests()
+	if len(requests) != 7 || requests[3].String() != `eth_getBalance ["0x0000000000000000000000000000000000000001","latest"]` {
+		t.Errorf("Unexpected requests %v", requests)
+	}
+
+	if unused := mock.Unused(); len(unused) != 0 {
+		t.Errorf("Unexpected unused responses %v", unused)
+	}
+
+}
+
+func TestMockProviderRecordAndReplay(t *testing.T) {
+
+	directory, err := ioutil.TempDir("", "fixtures")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(directory)
+
+	path := filepath.Join(directory, "fixture.json")
+
+	node := providers.NewMockProvider()
+	node.On("eth_blockNumber").Return("0x10")
+	node.On("eth_getBlockByNumber").Return(nil)
+	node.On("eth_getBalance").ReturnError(-32000, "header not found", nil)
+
+	recorder := providers.NewRecordingProvider(node, path)
+	connection := web3.NewWeb3(recorder)
+
+	number, _ := connection.Eth.GetBlockNumber()
+	connection.Eth.GetBlockByNumber(number, false)
+	connection.Eth.GetBalance("0x0000000000000000000000000000000000000001", block.LATEST)
+
+	if err := recorder.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	replay, err := providers.NewReplayProvider(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	connection = web3.NewWeb3(replay)
+
+	number, err = connection.Eth.GetBlockNumber()
+	if err != nil || number.Int64() != 16 {
+		t.Errorf("Expected the recorded block number, got %v %v", number, err)
+	}
+
+	// the params differ from the recorded ones
+	if _, err := connection.Eth.GetBlockByNumber(number, true); err == nil {
+		t.Error("Expected an error for a request not recorded")
+	}
+
+	if _, err := connection.Eth.GetBlockByNumber(number, false); err == nil {
+		t.Error("Expected the error of the recorded null block")
+	}
+
+	if _, err := connection.Eth.GetBalance("0x0000000000000000000000000000000000000001", block.LATEST); err == nil || err.Error() != "header not found" {
+		t.Errorf("Expected the recorded node error, got %v", err)
+	}
+
+	// each recorded response is replayed once
+	if _, err := connection.Eth.GetBlockNumber(); err == nil {
+		t.Error("Expected an error once the recorded response was used")
+	}
+
+	if unused := replay.Unused(); len(unused) != 0 {
+		t.Errorf("Unexpected unused responses %v", unused)
+	}
+
+}
@@ -0,0 +1,267 @@
+/********************************************************************************
+   This file is part of go-web3.
+   go-web3 is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-web3 is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-web3.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file multi-provider_test.go
+ */
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	web3 "github.com/cellcycle/go-web3"
+	"github.com/cellcycle/go-web3/dto"
+	"github.com/cellcycle/go-web3/eth/block"
+	"github.com/cellcycle/go-web3/providers"
+)
+
+// fakeEndpoint answers every method with the result scripted for it, or
+// with err while it is down. The params of each call are recorded by method.
+type fakeEndpoint struct {
+	results map[string]string
+	down    int32
+	calls   int32
+
+	mutex  sync.Mutex
+	params map[string][]string
+}
+
+func newFakeEndpoint(head string, balance string) *fakeEndpoint {
+	return &fakeEndpoint{results: map[string]string{
+		"eth_blockNumber": `"` + head + `"`,
+		"eth_getBalance":  `"` + balance + `"`,
+	}}
+}
+
+func (endpoint *fakeEndpoint) SendRequest(v interface{}, method string, params interface{}) error {
+	return endpoint.SendRequestCtx(context.Background(), v, method, params)
+}
+
+func (endpoint *fakeEndpoint) SendRequestCtx(ctx context.Context, v interface{}, method string, params interface{}) error {
+
+	atomic.AddInt32(&endpoint.calls, 1)
+
+	encoded, _ := json.Marshal(params)
+	endpoint.mutex.Lock()
+	if endpoint.params == nil {
+		endpoint.params = make(map[string][]string)
+	}
+	endpoint.params[method] = append(endpoint.params[method], string(encoded))
+	endpoint.mutex.Unlock()
+
+	if atomic.LoadInt32(&endpoint.down) == 1 {
+		return errors.New("connection refused")
+	}
+
+	return json.Unmarshal([]byte(`{"jsonrpc":"2.0","id":1,"result":`+endpoint.results[method]+`}`), v)
+}
+
+func (endpoint *fakeEndpoint) Close() error { return nil }
+
+func TestFailoverProviderPrefersHighestBlock(t *testing.T) {
+
+	behind := newFakeEndpoint("0x10", "0x1")
+	ahead := newFakeEndpoint("0x12", "0x2")
+
+	failover := providers.NewFailoverProvider([]providers.ProviderInterface{behind, ahead}, providers.FailoverOptions{HealthCheckInterval: -1})
+	defer failover.Close()
+
+	failover.CheckHealth(context.Background())
+
+	connection := web3.NewWeb3(failover)
+
+	balance, err := connection.Eth.GetBalance("0x0000000000000000000000000000000000000001", block.LATEST)
+	if err != nil || balance.Int64() != 2 {
+		t.Fatalf("Expected the balance of the endpoint ahead, got %v %v", balance, err)
+	}
+
+	// the endpoint ahead goes down, the next call moves to the other one
+	atomic.StoreInt32(&ahead.down, 1)
+
+	balance, err = connection.Eth.GetBalance("0x0000000000000000000000000000000000000001", block.LATEST)
+	if err != nil || balance.Int64() != 1 {
+		t.Fatalf("Expected the balance of the endpoint behind, got %v %v", balance, err)
+	}
+
+	status := failover.Status()
+	if status[0].Index != 0 || !status[0].Healthy || status[1].Healthy || status[1].LastError == nil {
+		t.Errorf("Unexpected status %+v", status)
+	}
+
+	// a transaction signed by the node is not sent twice
+	atomic.StoreInt32(&behind.down, 1)
+	calls := atomic.LoadInt32(&ahead.calls) + atomic.LoadInt32(&behind.calls)
+
+	if err := failover.SendRequest(&dto.RequestResult{}, "eth_sendTransaction", nil); err == nil {
+		t.Error("Expected the transport error")
+	}
+
+	if sent := atomic.LoadInt32(&ahead.calls) + atomic.LoadInt32(&behind.calls) - calls; sent != 1 {
+		t.Errorf("eth_sendTransaction sent %d times", sent)
+	}
+
+}
+
+func TestQuorumProvider(t *testing.T) {
+
+	var mutex sync.Mutex
+	var reported []providers.QuorumAnswer
+	done := make(chan struct{})
+
+	endpoints := []providers.ProviderInterface{
+		newFakeEndpoint("0x1", "0x64"),
+		newFakeEndpoint("0x1", "0x65"),
+		newFakeEndpoint("0x1", "0x64"),
+	}
+
+	quorum := providers.NewQuorumProvider(endpoints, providers.QuorumOptions{
+		OnDisagreement: func(method string, disagreeing []providers.QuorumAnswer) {
+			mutex.Lock()
+			reported = disagreeing
+			mutex.Unlock()
+			close(done)
+		},
+	})
+
+	connection := web3.NewWeb3(quorum)
+
+	balance, err := connection.Eth.GetBalance("0x0000000000000000000000000000000000000001", "0x1")
+	if err != nil || balance.Int64() != 100 {
+		t.Fatalf("Expected the balance of the majority, got %v %v", balance, err)
+	}
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Disagreement not reported")
+	}
+
+	mutex.Lock()
+	if len(reported) != 1 || reported[0].Index != 1 {
+		t.Errorf("Unexpected disagreeing endpoints %+v", reported)
+	}
+	mutex.Unlock()
+
+	strict := providers.NewQuorumProvider(endpoints, providers.QuorumOptions{Threshold: 3})
+
+	_, err = web3.NewWeb3(strict).Eth.GetBalance("0x0000000000000000000000000000000000000001", "0x1")
+
+	var quorumError *providers.QuorumError
+	if !errors.As(err, &quorumError) || len(quorumError.Answers) != 3 {
+		t.Errorf("Expected a QuorumError with every answer, got %v", err)
+	}
+
+}
+
+func (endpoint *fakeEndpoint) sent(method string) []string {
+	endpoint.mutex.Lock()
+	defer endpoint.mutex.Unlock()
+	return endpoint.params[method]
+}
+
+// slowEndpoint answers once the context of the call is done
+type slowEndpoint struct{}
+
+func (endpoint slowEndpoint) SendRequest(v interface{}, method string, params interface{}) error {
+	return endpoint.SendRequestCtx(context.Background(), v, method, params)
+}
+
+func (endpoint slowEndpoint) SendRequestCtx(ctx context.Context, v interface{}, method string, params interface{}) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func (endpoint slowEndpoint) Close() error { return nil }
+
+func TestQuorumProviderAtHead(t *testing.T) {
+
+	// two nodes in sync a block apart
+	behind := newFakeEndpoint("0x10", "0x1")
+	ahead := newFakeEndpoint("0x11", "0x1")
+	behind.results["eth_getCode"] = `"0x60"`
+	ahead.results["eth_getCode"] = `"0x60"`
+
+	quorum := providers.NewQuorumProvider([]providers.ProviderInterface{behind, ahead}, providers.QuorumOptions{})
+	connection := web3.NewWeb3(quorum)
+
+	balance, err := connection.Eth.GetBalance("0x0000000000000000000000000000000000000001", block.LATEST)
+	if err != nil || balance.Int64() != 1 {
+		t.Fatalf("Expected the balance agreed by both endpoints, got %v %v", balance, err)
+	}
+
+	// the block param left out reads at latest too
+	pointer := &dto.RequestResult{}
+	err = quorum.SendRequest(pointer, "eth_getCode", []string{"0x0000000000000000000000000000000000000001"})
+	if err != nil || pointer.Error != nil {
+		t.Fatal(err, pointer.Error)
+	}
+
+	for _, endpoint := range []*fakeEndpoint{behind, ahead} {
+		balances := endpoint.sent("eth_getBalance")
+		if len(balances) != 1 || balances[0] != `["0x0000000000000000000000000000000000000001","0x10"]` {
+			t.Errorf("Expected the balance read at the lowest head, got %v", balances)
+		}
+		codes := endpoint.sent("eth_getCode")
+		if len(codes) != 1 || codes[0] != `["0x0000000000000000000000000000000000000001","0x10"]` {
+			t.Errorf("Expected the code read at the lowest head, got %v", codes)
+		}
+	}
+
+	// a block number is read as it is
+	_, err = connection.Eth.GetBalance("0x0000000000000000000000000000000000000001", "0x8")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if balances := ahead.sent("eth_getBalance"); balances[len(balances)-1] != `["0x0000000000000000000000000000000000000001","0x8"]` {
+		t.Errorf("Expected the block number kept, got %v", balances)
+	}
+
+}
+
+func TestQuorumProviderLeavesOutCancelledAnswers(t *testing.T) {
+
+	var disagreements int32
+
+	endpoints := []providers.ProviderInterface{newFakeEndpoint("0x10", "0x1"), newFakeEndpoint("0x10", "0x1"), slowEndpoint{}}
+
+	quorum := providers.NewQuorumProvider(endpoints, providers.QuorumOptions{
+		OnDisagreement: func(method string, answers []providers.QuorumAnswer) {
+			atomic.AddInt32(&disagreements, 1)
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	pointer := &dto.RequestResult{}
+	err := quorum.SendRequestCtx(ctx, pointer, "eth_getBalance", []string{"0x0000000000000000000000000000000000000001", "0x1"})
+	cancel()
+
+	if err != nil || pointer.Result != "0x1" {
+		t.Fatalf("Expected the quorum answer, got %v %v", pointer.Result, err)
+	}
+
+	time.Sleep(50 * time.Millisecond)
+
+	if atomic.LoadInt32(&disagreements) != 0 {
+		t.Error("The answer cut by the cancelled context was reported as a disagreement")
+	}
+
+}
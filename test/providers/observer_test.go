@@ -0,0 +1,191 @@
+/********************************************************************************
+   This file is part of go-web3.
+   go-web3 is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-web3 is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-web3.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file observer_test.go
+ */
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	web3 "github.com/cellcycle/go-web3"
+	"github.com/cellcycle/go-web3/dto"
+	"github.com/cellcycle/go-web3/eth/block"
+	"github.com/cellcycle/go-web3/providers"
+)
+
+// observedNode answers eth_blockNumber, fails eth_getBalance with a node error
+// and eth_gasPrice with a 500 status
+func observedNode() *httptest.Server {
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		body, _ := ioutil.ReadAll(r.Body)
+
+		if strings.HasPrefix(string(body), "[") {
+			w.Write([]byte(`[{"jsonrpc":"2.0","id":1,"result":"0x10"},{"jsonrpc":"2.0","id":2,"result":"0x1"}]`))
+			return
+		}
+
+		var request struct {
+			Method string `json:"method"`
+		}
+		json.Unmarshal(body, &request)
+
+		switch request.Method {
+		case "eth_getBalance":
+			w.Write([]byte(`{"jsonrpc":"2.0","id":1,"error":{"code":-32000,"message":"header not found"}}`))
+		case "eth_gasPrice":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"0x10"}`))
+		}
+	}))
+}
+
+func TestObserverMetrics(t *testing.T) {
+
+	server := observedNode()
+	defer server.Close()
+
+	requests := providers.NewMemoryCounter()
+	latency := providers.NewMemoryHistogram()
+	sizes := providers.NewMemoryHistogram(10, 100)
+
+	// the credentials and the path of the url are left out of the endpoint label
+	address := strings.Replace(server.URL, "http://", "http://user:secret@", 1) + "/v3/key"
+	endpoint := server.URL
+
+	provider := providers.Chain(providers.NewHTTPProviderURL(address, 10), providers.Observe(providers.NewMetricsObserver(providers.MetricsOptions{
+		Requests:   requests,
+		Latency:    latency,
+		ParamsSize: sizes,
+	})))
+
+	connection := web3.NewWeb3(provider)
+
+	connection.Eth.GetBlockNumber()
+	connection.Eth.GetBlockNumber()
+	connection.Eth.GetBalance("0x0000000000000000000000000000000000000001", block.LATEST)
+	connection.Eth.GetGasPrice()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	connection.Eth.GetBlockNumberCtx(ctx)
+
+	expected := map[string]uint64{
+		"eth_blockNumber none":     2,
+		"eth_blockNumber canceled": 1,
+		"eth_getBalance rpc":       1,
+		"eth_gasPrice http":        1,
+	}
+
+	for key, value := range expected {
+		labels := strings.Split(key, " ")
+		if got := requests.Value(labels[0], endpoint, labels[1]); got != value {
+			t.Errorf("Expected %d for %s, got %d (%v)", value, key, got, requests.Labels())
+		}
+	}
+
+	if snapshot := latency.Snapshot("eth_blockNumber", endpoint); snapshot.Count != 3 || snapshot.Buckets[len(snapshot.Buckets)-1] != 3 {
+		t.Errorf("Unexpected latency %+v", snapshot)
+	}
+
+	// ["0x0000000000000000000000000000000000000001","latest"] is 55 bytes long
+	if snapshot := sizes.Snapshot("eth_getBalance", endpoint); snapshot.Count != 1 || snapshot.Sum != 55 || snapshot.Buckets[0] != 0 || snapshot.Buckets[1] != 1 {
+		t.Errorf("Unexpected params size %+v", snapshot)
+	}
+
+}
+
+func TestObserverSpans(t *testing.T) {
+
+	server := observedNode()
+	defer server.Close()
+
+	tracer := providers.NewMemoryTracer()
+	provider := providers.Chain(providers.NewHTTPProviderURL(server.URL, 10), providers.Observe(providers.NewTracingObserver(tracer)))
+
+	connection := web3.NewWeb3(provider)
+
+	parentCtx, parent := tracer.Start(context.Background(), "handler", nil)
+	connection.Eth.GetBlockNumberCtx(parentCtx)
+	parent.End()
+
+	connection.Eth.GetBalance("0x0000000000000000000000000000000000000001", block.LATEST)
+
+	batch := []providers.BatchElem{{Method: "eth_blockNumber"}, {Method: "eth_chainId"}}
+	if err := providers.SendBatchCtx(context.Background(), provider, batch); err != nil {
+		t.Fatal(err)
+	}
+
+	spans := tracer.Spans()
+	if len(spans) != 5 {
+		t.Fatalf("Expected 5 spans, got %d", len(spans))
+	}
+
+	call := spans[1]
+	if call.Name != "eth_blockNumber" || call.Parent != spans[0] || !call.Ended || call.Err != nil ||
+		call.Attributes["rpc.system"] != "jsonrpc" || call.Attributes["server.address"] != server.URL {
+		t.Errorf("Unexpected span %+v", call)
+	}
+
+	failed := spans[2]
+	if failed.Err == nil || failed.Attributes["rpc.jsonrpc.error_code"] != -32000 ||
+		failed.Attributes["rpc.jsonrpc.error_message"] != "header not found" || failed.Attributes["error.type"] != "rpc" {
+		t.Errorf("Unexpected span %+v", failed)
+	}
+
+	if spans[3].Attributes["web3.batch"] != true || spans[4].Name != "eth_chainId" || !spans[4].Ended {
+		t.Errorf("Unexpected batch spans %+v %+v", spans[3], spans[4])
+	}
+
+	limited := dto.NewRPCError(&dto.Error{Code: -32005, Message: "limit exceeded"})
+	if class := providers.ClassifyError(limited); class != providers.ErrorRateLimited {
+		t.Errorf("Expected %s, got %s", providers.ErrorRateLimited, class)
+	}
+
+}
+
+func TestObserverSpansOfEachObserver(t *testing.T) {
+
+	server := observedNode()
+	defer server.Close()
+
+	outer := providers.NewMemoryTracer()
+	inner := providers.NewMemoryTracer()
+	provider := providers.Chain(providers.NewHTTPProviderURL(server.URL, 10),
+		providers.Observe(providers.NewTracingObserver(outer), providers.NewTracingObserver(inner)))
+
+	if _, err := web3.NewWeb3(provider).Eth.GetBlockNumber(); err != nil {
+		t.Fatal(err)
+	}
+
+	outerSpans, innerSpans := outer.Spans(), inner.Spans()
+	if len(outerSpans) != 1 || len(innerSpans) != 1 {
+		t.Fatalf("Expected a span from each tracer, got %d and %d", len(outerSpans), len(innerSpans))
+	}
+
+	if !outerSpans[0].Ended || !innerSpans[0].Ended || innerSpans[0].Parent != outerSpans[0] {
+		t.Errorf("Unexpected spans %+v %+v", outerSpans[0], innerSpans[0])
+	}
+
+}
@@ -0,0 +1,111 @@
+/********************************************************************************
+   This file is part of go-web3.
+   go-web3 is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-web3 is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-web3.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file provider-context_test.go
+ */
+package test
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	web3 "github.com/cellcycle/go-web3"
+	"github.com/cellcycle/go-web3/eth/block"
+	"github.com/cellcycle/go-web3/providers"
+	"golang.org/x/net/websocket"
+)
+
+func assertDeadlineExceeded(t *testing.T, connection *web3.Web3) {
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := connection.Eth.GetBalanceCtx(ctx, "0x0000000000000000000000000000000000000000", block.LATEST)
+
+	if err == nil {
+		t.Error("expected the request to be cancelled")
+		t.FailNow()
+	}
+
+	if time.Since(start) > 2*time.Second {
+		t.Errorf("request was not cancelled in time: %s", time.Since(start))
+	}
+}
+
+func TestHTTPProviderContext(t *testing.T) {
+
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	address := strings.TrimPrefix(server.URL, "http://")
+	assertDeadlineExceeded(t, web3.NewWeb3(providers.NewHTTPProvider(address, 10, false)))
+
+}
+
+func TestIPCProviderContext(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "go-web3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	endpoint := filepath.Join(dir, "geth.ipc")
+	listener, err := net.Listen("unix", endpoint)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+
+	assertDeadlineExceeded(t, web3.NewWeb3(providers.NewIPCProvider(endpoint)))
+
+}
+
+func TestWebSocketProviderContext(t *testing.T) {
+
+	release := make(chan struct{})
+	server := httptest.NewServer(websocket.Handler(func(ws *websocket.Conn) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	address := "ws" + strings.TrimPrefix(server.URL, "http")
+	assertDeadlineExceeded(t, web3.NewWeb3(providers.NewWebSocketProvider(address)))
+
+}
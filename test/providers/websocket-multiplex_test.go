@@ -0,0 +1,146 @@
+/********************************************************************************
+   This file is part of go-web3.
+   go-web3 is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-web3 is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-web3.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file websocket-multiplex_test.go
+ */
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cellcycle/go-web3/constants"
+	"github.com/cellcycle/go-web3/dto"
+	"github.com/cellcycle/go-web3/providers"
+	"golang.org/x/net/websocket"
+)
+
+// echoServer answers every request with its first param, after a random
+// delay so the responses come back out of order.
+func echoServer(connections *int32, hold chan struct{}) *httptest.Server {
+	return httptest.NewServer(websocket.Handler(func(ws *websocket.Conn) {
+
+		atomic.AddInt32(connections, 1)
+
+		var writeMutex sync.Mutex
+
+		for {
+			var message []byte
+			if err := websocket.Message.Receive(ws, &message); err != nil {
+				return
+			}
+
+			var request struct {
+				ID     int           `json:"id"`
+				Params []interface{} `json:"params"`
+			}
+			json.Unmarshal(message, &request)
+
+			go func() {
+				if hold != nil {
+					<-hold
+				}
+				time.Sleep(time.Duration(rand.Intn(5)) * time.Millisecond)
+				response, _ := json.Marshal(map[string]interface{}{"jsonrpc": "2.0", "id": request.ID, "result": request.Params[0]})
+				writeMutex.Lock()
+				websocket.Message.Send(ws, string(response))
+				writeMutex.Unlock()
+			}()
+		}
+	}))
+}
+
+func TestWebSocketProviderMultiplexing(t *testing.T) {
+
+	var connections int32
+	server := echoServer(&connections, nil)
+	defer server.Close()
+
+	provider := providers.NewWebSocketProvider("ws" + strings.TrimPrefix(server.URL, "http"))
+	defer provider.Close()
+
+	var wait sync.WaitGroup
+
+	for index := 0; index < 200; index++ {
+		wait.Add(1)
+		go func(index int) {
+			defer wait.Done()
+
+			expected := fmt.Sprintf("call-%d", index)
+			pointer := &dto.RequestResult{}
+
+			if err := provider.SendRequest(pointer, "test_echo", []string{expected}); err != nil {
+				t.Error(err)
+				return
+			}
+
+			if result, _ := pointer.ToString(); result != expected {
+				t.Errorf("Response mismatch [Expected %s | Got %s]", expected, result)
+			}
+		}(index)
+	}
+
+	wait.Wait()
+
+	if atomic.LoadInt32(&connections) != 1 {
+		t.Errorf("Expected a single connection, got %d", connections)
+	}
+
+}
+
+func TestWebSocketProviderCloseFailsInFlight(t *testing.T) {
+
+	var connections int32
+	hold := make(chan struct{})
+	server := echoServer(&connections, hold)
+	defer server.Close()
+	defer close(hold)
+
+	provider := providers.NewWebSocketProvider("ws" + strings.TrimPrefix(server.URL, "http"))
+
+	errs := make(chan error, 10)
+	for index := 0; index < 10; index++ {
+		go func() {
+			errs <- provider.SendRequest(&dto.RequestResult{}, "test_echo", []string{"never"})
+		}()
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	provider.Close()
+
+	for index := 0; index < 10; index++ {
+		select {
+		case err := <-errs:
+			if err != customerror.PROVIDERCLOSED {
+				t.Errorf("Expected %v, got %v", customerror.PROVIDERCLOSED, err)
+			}
+		case <-time.After(2 * time.Second):
+			t.Error("In-flight call not released by Close")
+			t.FailNow()
+		}
+	}
+
+	if err := provider.SendRequest(&dto.RequestResult{}, "test_echo", []string{"late"}); err != customerror.PROVIDERCLOSED {
+		t.Errorf("Expected %v after Close, got %v", customerror.PROVIDERCLOSED, err)
+	}
+
+}
@@ -0,0 +1,293 @@
+/********************************************************************************
+   This file is part of go-web3.
+   go-web3 is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-web3 is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-web3.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file websocket-reconnect_test.go
+ */
+package test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	web3 "github.com/cellcycle/go-web3"
+	"github.com/cellcycle/go-web3/dto"
+	"github.com/cellcycle/go-web3/providers"
+	"github.com/cellcycle/go-web3/test/helpers"
+)
+
+var fastReconnect = providers.ReconnectPolicy{InitialBackoff: 10 * time.Millisecond, MaxBackoff: 50 * time.Millisecond}
+
+// chainNode serves the calls used to backfill, up to the head it is given.
+// The live notifications are pushed while the backfill is in progress.
+type chainNode struct {
+	head int64
+	live []interface{}
+
+	mutex  sync.Mutex
+	filter map[string]interface{}
+}
+
+func header(number int64) map[string]interface{} {
+	return map[string]interface{}{
+		"number":     fmt.Sprintf("0x%x", number),
+		"hash":       fmt.Sprintf("0x%x", number),
+		"parentHash": fmt.Sprintf("0x%x", number-1),
+		"gasUsed":    "0x0",
+		"nonce":      "0x0",
+		"timestamp":  "0x5",
+	}
+}
+
+func logAt(number int64, index int64) map[string]interface{} {
+	return map[string]interface{}{
+		"address":          "0x0000000000000000000000000000000000000001",
+		"topics":           []string{"0x01"},
+		"data":             "0x",
+		"blockNumber":      fmt.Sprintf("0x%x", number),
+		"transactionHash":  "0xcc",
+		"transactionIndex": "0x0",
+		"blockHash":        fmt.Sprintf("0xdd%02x", number),
+		"logIndex":         fmt.Sprintf("0x%x", index),
+	}
+}
+
+func (chain *chainNode) handle(node *helpers.FakeNode, method string, params json.RawMessage) (interface{}, error) {
+
+	head := atomic.LoadInt64(&chain.head)
+
+	chain.mutex.Lock()
+	live := chain.live
+	chain.live = nil
+	chain.mutex.Unlock()
+
+	for _, result := range live {
+		node.Notify(node.Subscriptions()[0], result)
+	}
+
+	switch method {
+	case "eth_blockNumber":
+		return fmt.Sprintf("0x%x", head), nil
+	case "eth_getBlockByNumber":
+		var args []interface{}
+		json.Unmarshal(params, &args)
+		number, _ := strconv.ParseInt(args[0].(string)[2:], 16, 64)
+		if number > head {
+			return nil, nil
+		}
+		return header(number), nil
+	case "eth_getLogs":
+		var args []map[string]interface{}
+		json.Unmarshal(params, &args)
+		chain.mutex.Lock()
+		chain.filter = args[0]
+		chain.mutex.Unlock()
+		from, _ := strconv.ParseInt(args[0]["fromBlock"].(string)[2:], 16, 64)
+		to, _ := strconv.ParseInt(args[0]["toBlock"].(string)[2:], 16, 64)
+		var logs []interface{}
+		for number := from; number <= to; number++ {
+			logs = append(logs, logAt(number, 0), logAt(number, 1))
+		}
+		return logs, nil
+	}
+
+	return nil, fmt.Errorf("unexpected method %s", method)
+}
+
+func TestWebSocketReconnectBackfillsHeads(t *testing.T) {
+
+	chain := &chainNode{head: 2}
+	node := helpers.NewFakeNode(chain.handle)
+	defer node.Close()
+
+	var connection = web3.NewWeb3(providers.NewWebSocketProviderWithReconnect(node.URL(), fastReconnect))
+	defer connection.Provider.Close()
+
+	sub, err := connection.Eth.SubscribeNewHeads()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first := sub.ID()
+	node.Notify(first, header(1))
+	node.Notify(first, header(2))
+
+	// blocks 3 to 5 are produced while the connection is down, the
+	// header of 5 is also pushed once the subscription is restored
+	atomic.StoreInt64(&chain.head, 5)
+	chain.mutex.Lock()
+	chain.live = []interface{}{header(5), header(6)}
+	chain.mutex.Unlock()
+	node.DropConnections()
+
+	for number := int64(1); number <= 6; number++ {
+		select {
+		case header := <-sub.Headers():
+			if header.Number.Int64() != number {
+				t.Fatalf("Unexpected header [Expected %d | Got %s]", number, header.Number)
+			}
+		case err := <-sub.Err():
+			t.Fatal(err)
+		case <-time.After(2 * time.Second):
+			t.Fatalf("Header %d not received", number)
+		}
+	}
+
+	if _, err := connection.Eth.GetBlockNumber(); err != nil {
+		t.Errorf("Calls not served after the reconnection: %v", err)
+	}
+
+}
+
+func TestWebSocketReconnectBackfillsLogs(t *testing.T) {
+
+	chain := &chainNode{head: 16}
+	node := helpers.NewFakeNode(chain.handle)
+	defer node.Close()
+
+	var connection = web3.NewWeb3(providers.NewWebSocketProviderWithReconnect(node.URL(), fastReconnect))
+	defer connection.Provider.Close()
+
+	sub, err := connection.Eth.SubscribeLogs(&dto.FilterParameters{
+		Address: []string{"0x0000000000000000000000000000000000000001"},
+		Topics:  [][]string{{"0x01"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first := sub.ID()
+	node.Notify(first, logAt(16, 0))
+
+	// the second log of block 16 is lost with the connection, a notification of
+	// a block already backfilled is not repeated
+	atomic.StoreInt64(&chain.head, 18)
+	chain.mutex.Lock()
+	chain.live = []interface{}{logAt(18, 0), logAt(19, 0)}
+	chain.mutex.Unlock()
+	node.DropConnections()
+
+	expected := [][2]int64{{16, 0}, {16, 1}, {17, 0}, {17, 1}, {18, 0}, {18, 1}, {19, 0}}
+
+	for _, position := range expected {
+		select {
+		case log := <-sub.Logs():
+			if log.BlockNumber.Int64() != position[0] || log.LogIndex.Int64() != position[1] {
+				t.Fatalf("Unexpected log [Expected %v | Got %s %s]", position, log.BlockNumber, log.LogIndex)
+			}
+		case err := <-sub.Err():
+			t.Fatal(err)
+		case <-time.After(2 * time.Second):
+			t.Fatalf("Log %v not received", position)
+		}
+	}
+
+	chain.mutex.Lock()
+	filter := chain.filter
+	chain.mutex.Unlock()
+
+	if filter["fromBlock"] != "0x10" || filter["toBlock"] != "0x12" || filter["address"] != "0x0000000000000000000000000000000000000001" {
+		t.Errorf("Unexpected backfill filter %v", filter)
+	}
+
+}
+
+func TestWebSocketReconnectReportsGap(t *testing.T) {
+
+	chain := &chainNode{head: 2}
+	node := helpers.NewFakeNode(chain.handle)
+	defer node.Close()
+
+	policy := fastReconnect
+	policy.MaxBackfill = 4
+
+	var connection = web3.NewWeb3(providers.NewWebSocketProviderWithReconnect(node.URL(), policy))
+	defer connection.Provider.Close()
+
+	sub, err := connection.Eth.SubscribeNewHeads()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	node.Notify(sub.ID(), header(2))
+
+	// 8 blocks missed, over the limit of 4: none is fetched
+	atomic.StoreInt64(&chain.head, 10)
+	chain.mutex.Lock()
+	chain.live = []interface{}{header(11)}
+	chain.mutex.Unlock()
+	node.DropConnections()
+
+	var gap *providers.GapError
+
+	for _, number := range []int64{2, 11} {
+		select {
+		case header := <-sub.Headers():
+			if header.Number.Int64() != number {
+				t.Fatalf("Unexpected header [Expected %d | Got %s]", number, header.Number)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatalf("Header %d not received", number)
+		}
+	}
+
+	select {
+	case err := <-sub.Err():
+		if !errors.As(err, &gap) || gap.From != 3 || gap.To != 10 {
+			t.Errorf("Expected the gap of blocks 3 to 10, got %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Error("Gap not reported")
+	}
+
+}
+
+func TestWebSocketReconnectGivesUp(t *testing.T) {
+
+	chain := &chainNode{}
+	node := helpers.NewFakeNode(chain.handle)
+
+	policy := fastReconnect
+	policy.MaxAttempts = 2
+
+	var connection = web3.NewWeb3(providers.NewWebSocketProviderWithReconnect(node.URL(), policy))
+	defer connection.Provider.Close()
+
+	sub, err := connection.Eth.SubscribeNewPendingTransactions()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	node.Close()
+
+	select {
+	case err := <-sub.Err():
+		if err == nil {
+			t.Error("Expected the dial error")
+		}
+	case <-time.After(2 * time.Second):
+		t.Error("Subscription not ended after the last attempt")
+	}
+
+	if _, ok := <-sub.Hashes(); ok {
+		t.Error("Hashes channel not closed")
+	}
+
+}
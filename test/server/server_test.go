@@ -0,0 +1,478 @@
+/********************************************************************************
+   This file is part of go-web3.
+   go-web3 is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-web3 is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-web3.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file server_test.go
+ */
+package test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	web3 "github.com/cellcycle/go-web3"
+	"github.com/cellcycle/go-web3/constants"
+	"github.com/cellcycle/go-web3/dto"
+	"github.com/cellcycle/go-web3/eth/block"
+	"github.com/cellcycle/go-web3/providers"
+	"github.com/cellcycle/go-web3/server"
+	"github.com/cellcycle/go-web3/test/helpers"
+	"golang.org/x/net/websocket"
+)
+
+const account = "0x0000000000000000000000000000000000000001"
+
+// newGateway serves the scripted mock behind a server limited to a few methods
+func newGateway(t *testing.T, options server.Options) (*httptest.Server, *providers.MockProvider) {
+
+	mock := providers.NewMockProvider()
+	mock.On("eth_blockNumber").Return("0x10")
+	mock.On("eth_getBalance", account, "latest").Return("0x2a")
+	mock.On("eth_call").ReturnError(3, "execution reverted", "0x08c379a0")
+
+	if options.ErrorLog == nil {
+		options.ErrorLog = log.New(ioutil.Discard, "", 0)
+	}
+
+	gateway := server.NewServer(mock, options)
+	gateway.HandleFunc("web3_clientVersion", func(ctx context.Context, request *server.Request) (interface{}, error) {
+		return "gateway/" + request.Header.Get("X-Api-Key"), nil
+	})
+
+	httpServer := httptest.NewServer(gateway)
+	t.Cleanup(func() {
+		gateway.Close()
+		httpServer.Close()
+	})
+
+	return httpServer, mock
+}
+
+func TestServerHTTP(t *testing.T) {
+
+	httpServer, mock := newGateway(t, server.Options{Methods: []string{"eth_blockNumber", "eth_getBalance", "eth_call"}})
+
+	connection, err := web3.Dial(httpServer.URL, web3.WithHeader("X-Api-Key", "key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if number, err := connection.Eth.GetBlockNumber(); err != nil || number.Int64() != 16 {
+		t.Errorf("Unexpected block number %v %v", number, err)
+	}
+
+	if balance, err := connection.Eth.GetBalance(account, block.LATEST); err != nil || balance.Int64() != 42 {
+		t.Errorf("Unexpected balance %v %v", balance, err)
+	}
+
+	if version, err := connection.ClientVersion(); err != nil || version != "gateway/key" {
+		t.Errorf("Unexpected version %q %v", version, err)
+	}
+
+	// the error of the node reaches the client with its code and data
+	result, err := connection.Eth.Call(&dto.TransactionParameters{To: account})
+	if err == nil {
+		_, err = result.ToString()
+	}
+	var rpcError *dto.RPCError
+	if !errors.As(err, &rpcError) || rpcError.Code != 3 || rpcError.Data != "0x08c379a0" {
+		t.Errorf("Unexpected call error %v", err)
+	}
+
+	if _, err := connection.Net.GetVersion(); !errors.Is(err, customerror.METHODNOTFOUND) {
+		t.Errorf("Expected a method not found error, got %v", err)
+	}
+
+	helpers.ExpectRequests(t, mock,
+		`eth_blockNumber null`,
+		`eth_getBalance ["0x0000000000000000000000000000000000000001","latest"]`,
+		`eth_call [{"from":"","to":"0x0000000000000000000000000000000000000001"},"latest"]`,
+	)
+
+}
+
+func post(t *testing.T, url string, body string) string {
+
+	response, err := http.Post(url, "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer response.Body.Close()
+
+	answer, _ := ioutil.ReadAll(response.Body)
+	return string(answer)
+}
+
+func TestServerBatch(t *testing.T) {
+
+	httpServer, mock := newGateway(t, server.Options{MaxBatchSize: 4})
+
+	connection, err := web3.Dial(httpServer.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	batch := connection.Eth.NewBatch()
+	number := batch.GetBlockNumber()
+	balance := batch.GetBalance(account, block.LATEST)
+	missing := batch.GetGasPrice()
+
+	if err := batch.Execute(); err != nil {
+		t.Fatal(err)
+	}
+
+	if value, err := number.Result(); err != nil || value.Int64() != 16 {
+		t.Errorf("Unexpected block number %v %v", value, err)
+	}
+	if value, err := balance.Result(); err != nil || value.Int64() != 42 {
+		t.Errorf("Unexpected balance %v %v", value, err)
+	}
+	if _, err := missing.Result(); err == nil {
+		t.Error("Expected the error of the unscripted call")
+	}
+
+	// the forwarded calls of a batch are sent in one batch
+	if sent := len(mock.Requests()); sent != 3 {
+		t.Errorf("%d requests sent", sent)
+	}
+
+	cases := []struct {
+		body     string
+		expected string
+	}{
+		{`{"jsonrpc":"2.0","method":"eth_blockNumber"}`, ``},
+		{`{"jsonrpc":"2.0","id":"a","method":"eth_blockNumber"}`, `{"jsonrpc":"2.0","id":"a","result":"0x10"}`},
+		{`{"jsonrpc":"2.0","id":1,"method":"eth_subscribe","params":["newHeads"]}`, `{"jsonrpc":"2.0","id":1,"error":{"code":-32601,"message":"notifications not supported","data":null}}`},
+		{`{"jsonrpc":"2.0",`, `{"jsonrpc":"2.0","id":null,"error":{"code":-32700,"message":"Parse error: unexpected end of JSON input","data":null}}`},
+		{`[]`, `{"jsonrpc":"2.0","id":null,"error":{"code":-32600,"message":"Empty batch","data":null}}`},
+		{`[1,2,3,4,5]`, `{"jsonrpc":"2.0","id":null,"error":{"code":-32600,"message":"Batch of 5 calls over the limit of 4","data":null}}`},
+		{
+			`[{"jsonrpc":"2.0","id":1,"method":"web3_clientVersion"},{"jsonrpc":"2.0","method":"eth_blockNumber"},1]`,
+			`[{"jsonrpc":"2.0","id":1,"result":"gateway/"},{"jsonrpc":"2.0","id":null,"error":{"code":-32600,"message":"Invalid request: json: cannot unmarshal number into Go value of type server.call","data":null}}]`,
+		},
+	}
+
+	for _, test := range cases {
+		if answer := post(t, httpServer.URL, test.body); answer != test.expected {
+			t.Errorf("%s\n[Expected %s | Got %s]", test.body, test.expected, answer)
+		}
+	}
+
+}
+
+func TestServerRateLimit(t *testing.T) {
+
+	httpServer, _ := newGateway(t, server.Options{
+		Key:       func(request *http.Request) string { return request.Header.Get("X-Api-Key") },
+		RateLimit: providers.RateLimit{Rate: 1.0 / 3600, Burst: 2},
+	})
+
+	limited, _ := web3.Dial(httpServer.URL, web3.WithHeader("X-Api-Key", "a"))
+	other, _ := web3.Dial(httpServer.URL, web3.WithHeader("X-Api-Key", "b"))
+
+	for index := 0; index < 2; index++ {
+		if _, err := limited.Eth.GetBlockNumber(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// the call over the limit is refused right away, not delayed
+	start := time.Now()
+	_, err := limited.Eth.GetBlockNumber()
+
+	var httpError *providers.HTTPError
+	if !errors.As(err, &httpError) || httpError.StatusCode != http.StatusTooManyRequests || !errors.Is(err, customerror.RATELIMITED) {
+		t.Errorf("Expected a 429 status, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+		t.Errorf("Call refused after %s", elapsed)
+	}
+
+	// a batch with a call served is answered with a 200 status
+	if _, err := other.Eth.GetBlockNumber(); err != nil {
+		t.Fatal(err)
+	}
+
+	request, _ := http.NewRequest(http.MethodPost, httpServer.URL, strings.NewReader(
+		`[{"jsonrpc":"2.0","id":1,"method":"eth_blockNumber"},{"jsonrpc":"2.0","id":2,"method":"eth_blockNumber"}]`))
+	request.Header.Set("X-Api-Key", "b")
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	answer, _ := ioutil.ReadAll(response.Body)
+	response.Body.Close()
+
+	expected := `[{"jsonrpc":"2.0","id":1,"result":"0x10"},{"jsonrpc":"2.0","id":2,"error":{"code":-32005,"message":"Rate limit exceeded","data":null}}]`
+	if response.StatusCode != http.StatusOK || string(answer) != expected {
+		t.Errorf("Unexpected batch answer %d %s", response.StatusCode, answer)
+	}
+
+}
+
+func TestServerLimiterIdle(t *testing.T) {
+
+	httpServer, _ := newGateway(t, server.Options{
+		RateLimit:   providers.RateLimit{Rate: 1.0 / 3600, Burst: 1},
+		LimiterIdle: 20 * time.Millisecond,
+	})
+
+	connection, _ := web3.Dial(httpServer.URL)
+
+	if _, err := connection.Eth.GetBlockNumber(); err != nil {
+		t.Fatal(err)
+	}
+
+	// the next token comes in an hour, unless the idle limiter was dropped
+	time.Sleep(50 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	if _, err := connection.Eth.GetBlockNumberCtx(ctx); err != nil {
+		t.Errorf("Expected a new limiter after the idle time, got %v", err)
+	}
+
+}
+
+func TestServerRawResult(t *testing.T) {
+
+	const result = `{"number":"0x1","totalDifficulty":58750003716598352816469,"hash":"0x01"}`
+
+	mock := providers.NewMockProvider()
+	mock.On("eth_getBlockByNumber").Return(json.RawMessage(result))
+
+	httpServer := httptest.NewServer(server.NewServer(mock, server.Options{}))
+	defer httpServer.Close()
+
+	// the number over 2^53 and the order of the keys are kept, alone or in a batch
+	answer := post(t, httpServer.URL, `{"jsonrpc":"2.0","id":1,"method":"eth_getBlockByNumber","params":["0x1",false]}`)
+	if !strings.Contains(answer, `"result":`+result) {
+		t.Errorf("Unexpected answer %s", answer)
+	}
+
+	answer = post(t, httpServer.URL, `[{"jsonrpc":"2.0","id":1,"method":"eth_getBlockByNumber","params":["0x1",false]}]`)
+	if !strings.Contains(answer, `"result":`+result) {
+		t.Errorf("Unexpected batch answer %s", answer)
+	}
+
+}
+
+func TestServerWebSocketSubscriptions(t *testing.T) {
+
+	node := helpers.NewFakeNode(func(node *helpers.FakeNode, method string, params json.RawMessage) (interface{}, error) {
+		return "0x10", nil
+	})
+	defer node.Close()
+
+	upstream := providers.NewWebSocketProvider(node.URL())
+	defer upstream.Close()
+
+	gateway := server.NewServer(upstream, server.Options{})
+	httpServer := httptest.NewServer(gateway)
+	defer httpServer.Close()
+	defer gateway.Close()
+
+	connection, err := web3.Dial("ws" + strings.TrimPrefix(httpServer.URL, "http"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer connection.Provider.Close()
+
+	if number, err := connection.Eth.GetBlockNumber(); err != nil || number.Int64() != 16 {
+		t.Fatalf("Unexpected block number %v %v", number, err)
+	}
+
+	sub, err := connection.Eth.SubscribeNewHeads()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	upstreamIDs := node.Subscriptions()
+	if len(upstreamIDs) != 1 || upstreamIDs[0] == sub.ID() {
+		t.Fatalf("Unexpected subscriptions of the node %v, the client has %s", upstreamIDs, sub.ID())
+	}
+
+	for number := 1; number <= 2; number++ {
+		node.Notify(upstreamIDs[0], map[string]interface{}{
+			"number":     fmt.Sprintf("0x%x", number),
+			"hash":       "0xaa",
+			"parentHash": "0xbb",
+			"gasUsed":    "0x0",
+			"nonce":      "0x0",
+			"timestamp":  "0x5",
+		})
+	}
+
+	for number := int64(1); number <= 2; number++ {
+		select {
+		case header := <-sub.Headers():
+			if header.Number.Int64() != number {
+				t.Errorf("Unexpected header [Expected %d | Got %s]", number, header.Number)
+			}
+		case err := <-sub.Err():
+			t.Fatal(err)
+		case <-time.After(2 * time.Second):
+			t.Fatal("Header not received")
+		}
+	}
+
+	if err := sub.Unsubscribe(); err != nil {
+		t.Fatal(err)
+	}
+
+	if remaining := node.Subscriptions(); len(remaining) != 0 {
+		t.Errorf("Subscription still alive on the node: %v", remaining)
+	}
+
+}
+
+func TestServerPrivateMethods(t *testing.T) {
+
+	httpServer, mock := newGateway(t, server.Options{})
+	mock.On("personal_listAccounts").Return([]string{account})
+	mock.On("admin_peers").Return([]string{})
+
+	for _, method := range []string{"personal_listAccounts", "admin_peers"} {
+		expected := `{"jsonrpc":"2.0","id":1,"error":{"code":-32601,"message":"The method ` + method + ` does not exist/is not available","data":null}}`
+		if answer := post(t, httpServer.URL, `{"jsonrpc":"2.0","id":1,"method":"`+method+`"}`); answer != expected {
+			t.Errorf("%s forwarded: %s", method, answer)
+		}
+	}
+
+	if requests := mock.Requests(); len(requests) != 0 {
+		t.Errorf("Unexpected requests %v", requests)
+	}
+
+	// listed, they are forwarded
+	listed, listedMock := newGateway(t, server.Options{Methods: []string{"personal_listAccounts"}})
+	listedMock.On("personal_listAccounts").Return([]string{account})
+
+	expected := `{"jsonrpc":"2.0","id":1,"result":["` + account + `"]}`
+	if answer := post(t, listed.URL, `{"jsonrpc":"2.0","id":1,"method":"personal_listAccounts"}`); answer != expected {
+		t.Errorf("Listed method not forwarded: %s", answer)
+	}
+
+}
+
+func TestServerInternalError(t *testing.T) {
+
+	var logged bytes.Buffer
+
+	mock := providers.NewMockProvider()
+	mock.On("eth_blockNumber").Fail(errors.New("dial tcp 10.0.0.7:8545: connection refused"))
+
+	httpServer := httptest.NewServer(server.NewServer(mock, server.Options{ErrorLog: log.New(&logged, "", 0)}))
+	defer httpServer.Close()
+
+	expected := `{"jsonrpc":"2.0","id":1,"error":{"code":-32603,"message":"Internal error","data":null}}`
+	if answer := post(t, httpServer.URL, `{"jsonrpc":"2.0","id":1,"method":"eth_blockNumber"}`); answer != expected {
+		t.Errorf("Unexpected answer %s", answer)
+	}
+
+	if !strings.Contains(logged.String(), "eth_blockNumber: dial tcp 10.0.0.7:8545: connection refused") {
+		t.Errorf("Error not logged: %q", logged.String())
+	}
+
+}
+
+func TestServerWebSocketOrigin(t *testing.T) {
+
+	httpServer, _ := newGateway(t, server.Options{Origins: []string{"https://app.example.org"}})
+	address := "ws" + strings.TrimPrefix(httpServer.URL, "http")
+
+	cases := []struct {
+		origin  string
+		allowed bool
+	}{
+		{httpServer.URL, true},
+		{"https://app.example.org", true},
+		{"https://evil.example.org", false},
+	}
+
+	for _, test := range cases {
+		config, _ := websocket.NewConfig(address, test.origin)
+		ws, err := websocket.DialConfig(config)
+		if err == nil {
+			ws.Close()
+		}
+		if (err == nil) != test.allowed {
+			t.Errorf("Origin %s: expected allowed %t, got %v", test.origin, test.allowed, err)
+		}
+	}
+
+}
+
+func TestServerWebSocketCallsInFlight(t *testing.T) {
+
+	var inFlight, highest int32
+	release := make(chan struct{})
+
+	mock := providers.NewMockProvider()
+	gateway := server.NewServer(mock, server.Options{MaxConnectionCalls: 2})
+	gateway.HandleFunc("test_wait", func(ctx context.Context, request *server.Request) (interface{}, error) {
+		current := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			seen := atomic.LoadInt32(&highest)
+			if current <= seen || atomic.CompareAndSwapInt32(&highest, seen, current) {
+				break
+			}
+		}
+		<-release
+		return true, nil
+	})
+
+	httpServer := httptest.NewServer(gateway)
+	defer httpServer.Close()
+	defer gateway.Close()
+
+	ws, err := websocket.Dial("ws"+strings.TrimPrefix(httpServer.URL, "http"), "", httpServer.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ws.Close()
+
+	for index := 1; index <= 5; index++ {
+		websocket.Message.Send(ws, fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"method":"test_wait"}`, index))
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+
+	for index := 0; index < 5; index++ {
+		var answer string
+		ws.SetReadDeadline(time.Now().Add(2 * time.Second))
+		if err := websocket.Message.Receive(ws, &answer); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if seen := atomic.LoadInt32(&highest); seen != 2 {
+		t.Errorf("Expected 2 calls in flight at most, got %d", seen)
+	}
+
+}
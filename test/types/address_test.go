@@ -0,0 +1,221 @@
+/********************************************************************************
+   This file is part of go-web3.
+   go-web3 is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-web3 is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-web3.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file address_test.go
+ */
+
+package test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/cellcycle/go-web3/abi"
+	"github.com/cellcycle/go-web3/complex/types"
+)
+
+// checksumAddresses - the examples of EIP-55
+var checksumAddresses = []string{
+	"0x52908400098527886E0F7030069857D2E4169EE7",
+	"0x8617E340B3D01FA5F11F306F4090FD50E238070D",
+	"0xde709f2102306220921060314715629080e2fb77",
+	"0x27b1fdb04752bbc536007a920d24acb045561c26",
+	"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+	"0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359",
+	"0xdbF03B407c01E7cD3CBea99509d93f8DDDC8C6FB",
+	"0xD1220A0cf47c7B9Be7A2E6BA89F429762e7b9aDb",
+}
+
+func TestAddressChecksum(t *testing.T) {
+
+	for _, text := range checksumAddresses {
+
+		address, err := types.ParseAddress(text)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if address.Hex() != text || !types.IsChecksumAddress(text) {
+			t.Errorf("Unexpected checksum %s of %s", address.Hex(), text)
+		}
+
+		// the lower and upper case addresses aren't checksummed
+		lower, err := types.ParseAddress(strings.ToLower(text))
+		if err != nil || lower != address {
+			t.Errorf("Unexpected lower case address %s %v", lower, err)
+		}
+
+		if upper, err := types.ParseAddress("0x" + strings.ToUpper(text[2:])); err != nil || upper != address {
+			t.Errorf("Unexpected upper case address %s %v", upper, err)
+		}
+	}
+
+	if types.IsChecksumAddress("0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed") {
+		t.Error("A lower case address isn't checksummed")
+	}
+
+}
+
+func TestParseAddressErrors(t *testing.T) {
+
+	for _, text := range []string{
+		"",
+		"5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+		"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAe",
+		"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed00",
+		"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeg",
+		// the checksum of the first letter is wrong
+		"0x5AAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+	} {
+		if address, err := types.ParseAddress(text); err == nil || !address.IsZero() {
+			t.Errorf("Expected an error for %q, got %s", text, address)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a panic for an invalid address")
+		}
+	}()
+
+	types.MustParseAddress("0x01")
+
+}
+
+func TestAddressEncoding(t *testing.T) {
+
+	address := types.MustParseAddress("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed")
+
+	var account struct {
+		Owner   types.Address  `json:"owner"`
+		Spender *types.Address `json:"spender"`
+	}
+
+	encoded, err := json.Marshal(struct {
+		Owner types.Address `json:"owner"`
+	}{address})
+	if err != nil || string(encoded) != `{"owner":"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"}` {
+		t.Errorf("Unexpected JSON %s %v", encoded, err)
+	}
+
+	if err := json.Unmarshal([]byte(`{"owner":"0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed","spender":null}`), &account); err != nil {
+		t.Fatal(err)
+	}
+
+	if account.Owner != address || account.Spender != nil {
+		t.Errorf("Unexpected account %+v", account)
+	}
+
+	if err := json.Unmarshal([]byte(`{"owner":"0x5AAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"}`), &account); err == nil {
+		t.Error("Expected an error for a wrong checksum")
+	}
+
+	value, err := address.Value()
+	if err != nil || !bytes.Equal(value.([]byte), address.Bytes()) {
+		t.Errorf("Unexpected SQL value %v %v", value, err)
+	}
+
+	for _, src := range []interface{}{address.Bytes(), []byte(address.Hex()), strings.ToLower(address.Hex())} {
+		var scanned types.Address
+		if err := scanned.Scan(src); err != nil || scanned != address {
+			t.Errorf("Unexpected scanned address %s of %v: %v", scanned, src, err)
+		}
+	}
+
+	scanned := address
+	if err := scanned.Scan(nil); err != nil || !scanned.IsZero() {
+		t.Errorf("Expected the zero address for NULL, got %s %v", scanned, err)
+	}
+
+	if err := scanned.Scan(int64(1)); err == nil {
+		t.Error("Expected an error for an integer column")
+	}
+
+	other := types.MustParseAddress("0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359")
+	if address.Cmp(other) != -1 || other.Cmp(address) != 1 || address.Cmp(address) != 0 {
+		t.Error("Unexpected comparison of the addresses")
+	}
+
+}
+
+func TestHash(t *testing.T) {
+
+	text := "0x9c22ff5f21f0b81b113e63f7db6da94fedef11b2119b4088b89664fb9a3cb658"
+
+	hash, err := types.ParseHash(strings.ToUpper(text[:10]) + text[10:])
+	if err != nil || hash.Hex() != text || hash.String() != text {
+		t.Fatalf("Unexpected hash %s %v", hash, err)
+	}
+
+	for _, invalid := range []string{"", text[2:], text[:65], text + "00", text[:65] + "z"} {
+		if _, err := types.ParseHash(invalid); err == nil {
+			t.Errorf("Expected an error for %q", invalid)
+		}
+	}
+
+	var logged struct {
+		Topics []types.Hash `json:"topics"`
+	}
+
+	if err := json.Unmarshal([]byte(`{"topics":["`+text+`"]}`), &logged); err != nil || logged.Topics[0] != hash {
+		t.Errorf("Unexpected topics %v %v", logged.Topics, err)
+	}
+
+	if encoded, err := json.Marshal(logged); err != nil || string(encoded) != `{"topics":["`+text+`"]}` {
+		t.Errorf("Unexpected JSON %s %v", encoded, err)
+	}
+
+	var scanned types.Hash
+	if err := scanned.Scan(hash.Bytes()); err != nil || scanned != hash {
+		t.Errorf("Unexpected scanned hash %s %v", scanned, err)
+	}
+
+	if (types.Hash{}).Cmp(hash) != -1 || !(types.Hash{}).IsZero() {
+		t.Error("Unexpected comparison of the hashes")
+	}
+
+}
+
+func TestAddressABI(t *testing.T) {
+
+	arguments := abi.Arguments{
+		{Name: "owner", Type: abi.Type{Kind: abi.AddressKind, Size: 20}},
+		{Name: "hash", Type: abi.Type{Kind: abi.FixedBytesKind, Size: 32}},
+	}
+
+	address := types.MustParseAddress("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed")
+	hash := types.MustParseHash("0x9c22ff5f21f0b81b113e63f7db6da94fedef11b2119b4088b89664fb9a3cb658")
+
+	data, err := arguments.Pack(address, hash)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded struct {
+		Owner types.Address
+		Hash  types.Hash
+	}
+
+	if err := arguments.UnpackInto(&decoded, data); err != nil {
+		t.Fatal(err)
+	}
+
+	if decoded.Owner != address || decoded.Hash != hash {
+		t.Errorf("Unexpected decoded values %+v", decoded)
+	}
+
+}
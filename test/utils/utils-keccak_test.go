@@ -0,0 +1,50 @@
+/********************************************************************************
+   This file is part of go-web3.
+   go-web3 is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-web3 is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-web3.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file utils-keccak_test.go
+ */
+
+package test
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/cellcycle/go-web3/utils"
+)
+
+func TestKeccak256(t *testing.T) {
+
+	tests := []struct {
+		data     [][]byte
+		expected string
+	}{
+		{nil, "c5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470"},
+		{[][]byte{[]byte("test")}, "9c22ff5f21f0b81b113e63f7db6da94fedef11b2119b4088b89664fb9a3cb658"},
+		{[][]byte{[]byte("te"), nil, []byte("st")}, "9c22ff5f21f0b81b113e63f7db6da94fedef11b2119b4088b89664fb9a3cb658"},
+		{[][]byte{[]byte("transfer(address,uint256)")}, "a9059cbb2ab09eb219583f4a59a5d0623ade346d962bcd4e46b11da047c9049b"},
+		// two blocks, the padding filling the second one
+		{[][]byte{[]byte(strings.Repeat("a", 136))}, "a6c4d403279fe3e0af03729caada8374b5ca54d8065329a3ebcaeb4b60aa386e"},
+	}
+
+	for _, test := range tests {
+		hash := utils.Keccak256(test.data...)
+		if hex.EncodeToString(hash[:]) != test.expected {
+			t.Errorf("Unexpected hash %x of %q, expected %s", hash, test.data, test.expected)
+		}
+	}
+
+}
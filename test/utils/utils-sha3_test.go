@@ -22,17 +22,20 @@
 package test
 
 import (
+	"context"
 	"errors"
 	"strings"
 	"testing"
 
 	web3 "github.com/cellcycle/go-web3"
 	"github.com/cellcycle/go-web3/providers"
+	"github.com/cellcycle/go-web3/test/helpers"
 )
 
 func TestUtilsSha3(t *testing.T) {
 
-	var connection = web3.NewWeb3(providers.NewHTTPProvider("127.0.0.1:8545", 10, false))
+	mock := providers.NewMockProvider()
+	connection := web3.NewWeb3(mock)
 
 	sha3String, err := connection.Utils.Sha3("test")
 
@@ -50,4 +53,40 @@ func TestUtilsSha3(t *testing.T) {
 		t.Fail()
 	}
 
+	if hexString, err := connection.Utils.Sha3("0x74657374"); err != nil || hexString != sha3String {
+		t.Errorf("Unexpected hash of the hex data %s %v", hexString, err)
+	}
+
+	if _, err := connection.Utils.Sha3("0xzz"); err == nil {
+		t.Error("Expected an error for invalid hex data")
+	}
+
+	if ctxString, err := connection.Utils.Sha3Ctx(context.Background(), "test"); err != nil || ctxString != sha3String {
+		t.Errorf("Unexpected hash with a context %s %v", ctxString, err)
+	}
+
+	// the hash is computed locally
+	helpers.ExpectRequests(t, mock)
+
+}
+
+func TestUtilsSha3RPC(t *testing.T) {
+
+	connection, mock := helpers.NewFixtureConnection(t, "utils-sha3")
+
+	sha3String, err := connection.Utils.Sha3RPC("test")
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if sha3String != "0x9c22ff5f21f0b81b113e63f7db6da94fedef11b2119b4088b89664fb9a3cb658" {
+		t.Errorf("Unexpected hash %s", sha3String)
+	}
+
+	helpers.ExpectRequests(t, mock,
+		`web3_sha3 ["0x74657374"]`,
+	)
+
 }
@@ -24,13 +24,12 @@ package test
 import (
 	"testing"
 
-	web3 "github.com/cellcycle/go-web3"
-	"github.com/cellcycle/go-web3/providers"
+	"github.com/cellcycle/go-web3/test/helpers"
 )
 
 func TestWeb3ClientVersion(t *testing.T) {
 
-	var connection = web3.NewWeb3(providers.NewHTTPProvider("127.0.0.1:8545", 10, false))
+	connection, mock := helpers.NewFixtureConnection(t, "web3-clientVersion")
 
 	client, err := connection.ClientVersion()
 
@@ -40,4 +39,9 @@ func TestWeb3ClientVersion(t *testing.T) {
 	}
 
 	t.Log(client)
+
+	helpers.ExpectRequests(t, mock,
+		`web3_clientVersion null`,
+	)
+
 }
@@ -0,0 +1,220 @@
+/********************************************************************************
+   This file is part of go-web3.
+   go-web3 is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-web3 is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-web3.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file web3-dial_test.go
+ */
+
+package test
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	web3 "github.com/cellcycle/go-web3"
+	"github.com/cellcycle/go-web3/providers"
+	"golang.org/x/net/websocket"
+)
+
+// headerNode answers web3_clientVersion over HTTP and websocket, keeping the headers it received
+type headerNode struct {
+	mutex   sync.Mutex
+	headers []http.Header
+	paths   []string
+}
+
+func (node *headerNode) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+
+	node.mutex.Lock()
+	node.headers = append(node.headers, request.Header.Clone())
+	node.paths = append(node.paths, request.URL.Path)
+	node.mutex.Unlock()
+
+	if request.Header.Get("Upgrade") == "websocket" {
+		websocket.Handler(func(ws *websocket.Conn) {
+			var call struct {
+				ID int `json:"id"`
+			}
+			for websocket.JSON.Receive(ws, &call) == nil {
+				websocket.JSON.Send(ws, map[string]interface{}{"jsonrpc": "2.0", "id": call.ID, "result": "Geth/v1.8.0"})
+			}
+		}).ServeHTTP(writer, request)
+		return
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	io.WriteString(writer, `{"jsonrpc":"2.0","id":1,"result":"Geth/v1.8.0"}`)
+}
+
+func (node *headerNode) last() (http.Header, string) {
+	node.mutex.Lock()
+	defer node.mutex.Unlock()
+	return node.headers[len(node.headers)-1], node.paths[len(node.paths)-1]
+}
+
+func TestDialHTTP(t *testing.T) {
+
+	node := new(headerNode)
+	server := httptest.NewServer(node)
+	defer server.Close()
+
+	address := strings.TrimPrefix(server.URL, "http://")
+
+	cases := []struct {
+		rawurl        string
+		options       []web3.DialOption
+		authorization string
+	}{
+		{"http://" + address + "/v3/key", nil, ""},
+		{"http://alice:secret@" + address + "/v3/key", nil, "Basic YWxpY2U6c2VjcmV0"},
+		{"http://token@" + address + "/v3/key", nil, "Bearer token"},
+		{"http://alice:secret@" + address + "/v3/key", []web3.DialOption{web3.WithBearerToken("other")}, "Bearer other"},
+	}
+
+	for _, test := range cases {
+
+		connection, err := web3.Dial(test.rawurl, append(test.options, web3.WithHeader("X-Api-Key", "key"))...)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if version, err := connection.ClientVersion(); err != nil || version != "Geth/v1.8.0" {
+			t.Errorf("%s: unexpected answer %q %v", test.rawurl, version, err)
+		}
+
+		header, path := node.last()
+		if header.Get("Authorization") != test.authorization || header.Get("X-Api-Key") != "key" || path != "/v3/key" {
+			t.Errorf("%s: unexpected request %s %v", test.rawurl, path, header)
+		}
+
+		// the credentials never reach the address of the provider
+		if endpoint := connection.Provider.(*providers.HTTPProvider).Endpoint(); strings.Contains(endpoint, "@") {
+			t.Errorf("Credentials left in %s", endpoint)
+		}
+	}
+
+}
+
+func TestDialWebSocket(t *testing.T) {
+
+	node := new(headerNode)
+	server := httptest.NewServer(node)
+	defer server.Close()
+
+	rawurl := "ws://alice:secret@" + strings.TrimPrefix(server.URL, "http://") + "/ws"
+
+	connection, err := web3.Dial(rawurl, web3.WithHeader("X-Api-Key", "key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer connection.Provider.Close()
+
+	if _, ok := connection.Provider.(*providers.WebSocketProvider); !ok {
+		t.Fatalf("Unexpected provider %T", connection.Provider)
+	}
+
+	if version, err := connection.ClientVersion(); err != nil || version != "Geth/v1.8.0" {
+		t.Fatalf("Unexpected answer %q %v", version, err)
+	}
+
+	header, path := node.last()
+	if header.Get("Authorization") != "Basic YWxpY2U6c2VjcmV0" || header.Get("X-Api-Key") != "key" || path != "/ws" {
+		t.Errorf("Unexpected handshake %s %v", path, header)
+	}
+
+}
+
+func TestDialWebSocketProxy(t *testing.T) {
+
+	node := new(headerNode)
+	server := httptest.NewServer(node)
+	defer server.Close()
+
+	target := strings.TrimPrefix(server.URL, "http://")
+
+	var tunnels []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+
+		if request.Method != "CONNECT" {
+			http.Error(writer, "CONNECT only", http.StatusMethodNotAllowed)
+			return
+		}
+		tunnels = append(tunnels, request.Host)
+
+		upstream, err := net.Dial("tcp", request.Host)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadGateway)
+			return
+		}
+
+		conn, _, _ := writer.(http.Hijacker).Hijack()
+		io.WriteString(conn, "HTTP/1.1 200 Connection established\r\n\r\n")
+
+		go func() {
+			io.Copy(upstream, conn)
+			upstream.Close()
+		}()
+		io.Copy(conn, upstream)
+		conn.Close()
+	}))
+	defer proxy.Close()
+
+	proxyURL, _ := url.Parse(proxy.URL)
+
+	connection, err := web3.Dial("ws://"+target, web3.WithProxy(http.ProxyURL(proxyURL)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer connection.Provider.Close()
+
+	if version, err := connection.ClientVersion(); err != nil || version != "Geth/v1.8.0" {
+		t.Fatalf("Unexpected answer %q %v", version, err)
+	}
+
+	if len(tunnels) != 1 || tunnels[0] != target {
+		t.Errorf("Unexpected tunnels %v", tunnels)
+	}
+
+}
+
+func TestDialIPCAndErrors(t *testing.T) {
+
+	connection, err := web3.Dial("/tmp/geth.ipc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := connection.Provider.(*providers.IPCProvider); !ok {
+		t.Errorf("Unexpected provider %T", connection.Provider)
+	}
+
+	if _, err := web3.Dial("/tmp/geth.ipc", web3.WithTimeout(time.Second), web3.WithReconnect(providers.DefaultReconnectPolicy())); err != nil {
+		t.Errorf("Expected the timeout and the reconnection applied to an IPC endpoint, got %v", err)
+	}
+
+	if _, err := web3.Dial("/tmp/geth.ipc", web3.WithBearerToken("token")); err == nil {
+		t.Error("Expected an error for the headers of an IPC endpoint")
+	}
+
+	if _, err := web3.Dial("ftp://127.0.0.1/node"); err == nil {
+		t.Error("Expected an error for an unsupported scheme")
+	}
+
+}
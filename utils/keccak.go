@@ -0,0 +1,27 @@
+/********************************************************************************
+   This file is part of go-web3.
+   go-web3 is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-web3 is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-web3.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file keccak.go
+ */
+
+package utils
+
+import "github.com/cellcycle/go-web3/internal/keccak"
+
+// Keccak256 - The Keccak-256 hash of the concatenation of data, computed locally. It is
+// the hash of Ethereum, with the original padding of Keccak rather than the one of SHA3-256.
+func Keccak256(data ...[]byte) [32]byte {
+	return keccak.Sum256(data...)
+}
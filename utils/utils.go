@@ -22,6 +22,11 @@
 package utils
 
 import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
 	"github.com/cellcycle/go-web3/complex/types"
 	"github.com/cellcycle/go-web3/dto"
 	"github.com/cellcycle/go-web3/providers"
@@ -39,19 +44,54 @@ func NewUtils(provider providers.ProviderInterface) *Utils {
 	return utils
 }
 
-// Sha3 - Returns Keccak-256 (not the standardized SHA3-256) of the given data.
-// Reference: https://github.com/ethereum/wiki/wiki/JSON-RPC#web3_sha3
-//    - DATA - the data to convert into a SHA3 hash
+// Sha3 - Returns Keccak-256 (not the standardized SHA3-256) of the given data,
+// computed locally. The data is hex encoded with its 0x prefix, or else a string.
+//   - DATA - the data to convert into a SHA3 hash
+//
 // Returns:
-// 	  - DATA - The SHA3 result of the given string.
+//   - DATA - The SHA3 result of the given string.
 func (utils *Utils) Sha3(data types.ComplexString) (string, error) {
+	return utils.Sha3Ctx(context.Background(), data)
+}
+
+// Sha3Ctx - Same as Sha3. The hash is computed locally, without a request, ctx
+// is only checked before hashing.
+func (utils *Utils) Sha3Ctx(ctx context.Context, data types.ComplexString) (string, error) {
+
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	decoded, err := hex.DecodeString(strings.TrimPrefix(data.ToHex(), "0x"))
+	if err != nil {
+		return "", fmt.Errorf("Invalid hex data: %v", err)
+	}
+
+	hash := Keccak256(decoded)
+
+	return "0x" + hex.EncodeToString(hash[:]), nil
+}
+
+// Sha3RPC - Returns Keccak-256 (not the standardized SHA3-256) of the given data,
+// computed by the node.
+// Reference: https://github.com/ethereum/wiki/wiki/JSON-RPC#web3_sha3
+//   - DATA - the data to convert into a SHA3 hash
+//
+// Returns:
+//   - DATA - The SHA3 result of the given string.
+func (utils *Utils) Sha3RPC(data types.ComplexString) (string, error) {
+	return utils.Sha3RPCCtx(context.Background(), data)
+}
+
+// Sha3RPCCtx - Same as Sha3RPC, using ctx to cancel the request or set its deadline.
+func (utils *Utils) Sha3RPCCtx(ctx context.Context, data types.ComplexString) (string, error) {
 
 	params := make([]string, 1)
 	params[0] = data.ToHex()
 
 	pointer := &dto.RequestResult{}
 
-	err := utils.provider.SendRequest(pointer, "web3_sha3", params)
+	err := utils.provider.SendRequestCtx(ctx, pointer, "web3_sha3", params)
 
 	if err != nil {
 		return "", err
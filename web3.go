@@ -22,6 +22,7 @@
 package web3
 
 import (
+	"context"
 	"github.com/cellcycle/go-web3/dto"
 	"github.com/cellcycle/go-web3/eth"
 	"github.com/cellcycle/go-web3/net"
@@ -58,14 +59,20 @@ func NewWeb3(provider providers.ProviderInterface) *Web3 {
 // ClientVersion - Returns the current client version.
 // Reference: https://github.com/ethereum/wiki/wiki/JSON-RPC#web3_clientversion
 // Parameters:
-//    - none
+//   - none
+//
 // Returns:
-// 	  - String - The current client version
+//   - String - The current client version
 func (web Web3) ClientVersion() (string, error) {
+	return web.ClientVersionCtx(context.Background())
+}
+
+// ClientVersionCtx - Same as ClientVersion, using ctx to cancel the request or set its deadline.
+func (web Web3) ClientVersionCtx(ctx context.Context) (string, error) {
 
 	pointer := &dto.RequestResult{}
 
-	err := web.Provider.SendRequest(pointer, "web3_clientVersion", nil)
+	err := web.Provider.SendRequestCtx(ctx, pointer, "web3_clientVersion", nil)
 
 	if err != nil {
 		return "", err